@@ -1,11 +1,19 @@
 package example
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/delaneyj/geck/cmd/example/ecs"
+	"github.com/delaneyj/geck/components/mathx"
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -30,47 +38,8 @@ func (sys *RelationshipSystem) Initialize(ctx context.Context, w *ecs.World) err
 // from https://ajmmertens.medium.com/building-games-in-ecs-with-entity-relationships-657275ba2c6c
 func (sys *RelationshipSystem) Tick(ctx context.Context, w *ecs.World) error {
 	sys.foundCount = 0
-	for spaceship := range w.AllSpaceshipEntities {
-
-		spacesphipFaction, ok := w.Faction(spaceship)
-		if !ok {
-			continue
-		}
-
-		dockedTo, ok := w.DockedTo(spaceship)
-		if !ok {
-			continue
-		}
-		if !w.HasPlanetTag(dockedTo.Entity) {
-			continue
-		}
-		planet := dockedTo.Entity
-
-		planetFaction, ok := w.RuledBy(planet)
-		if !ok {
-			continue
-		}
-
-		hasAllies := false
-		for ally := range w.AlliedWith(spacesphipFaction.Entity) {
-			if ally == planetFaction.Entity {
-				sys.foundCount++
-				hasAllies = true
-				break
-			}
-		}
-
-		if !hasAllies {
-			continue
-		}
-
-		// log.Printf(
-		// 	"Spaceship %v Faction(%v) is docked to planet %v Faction(%v)",
-		// 	spaceship,
-		// 	spacesphipFaction,
-		// 	planet,
-		// 	planetFaction,
-		// )
+	// spaceships docked to a planet ruled by an ally of their faction
+	for range w.QueryDockedSpaceship {
 		sys.foundCount++
 	}
 
@@ -161,13 +130,13 @@ func TestECS(t *testing.T) {
 	// // Entity identifiers contain a few bits that make it possible to check whether an entity is alive or not.
 	e := w.NextEntity()
 	assert.True(t, w.IsAlive(e))
-	assert.Equal(t, e.Generation(), uint32(0))
+	assert.Equal(t, 0, e.Generation())
 	w.DestroyEntities(e)
 	assert.False(t, w.IsAlive(e))
 
 	e = w.NextEntity()
 	assert.True(t, w.IsAlive(e))
-	assert.Equal(t, e.Generation(), uint32(1))
+	assert.Equal(t, 1, e.Generation())
 
 	// A component is a type of which instances can be added and removed to entities.
 	// Each component can be added only once to an entity (though not really, see Relation).
@@ -206,7 +175,7 @@ func TestECS(t *testing.T) {
 	w.LinkLikes(alice, bob) // Bob likes Alice
 	assert.True(t, w.LikesIsLinked(bob, alice))
 
-	w.RemoveLikesRelationships(bob, alice)
+	w.RemoveLikesRelationships(alice, bob)
 	assert.False(t, w.LikesIsLinked(bob, alice))
 
 	apples := w.NextEntity()
@@ -229,3 +198,1275 @@ func TestECS(t *testing.T) {
 	w.NextEntities(1000)
 	// log.Print(ee)
 }
+
+func TestECSStaleEntityHandles(t *testing.T) {
+	w := ecs.NewWorld()
+
+	stale := w.NextEntity(
+		ecs.WithPositionFromValues(1, 2, 3),
+		ecs.WithEnemyTag(),
+	)
+	target := w.NextEntity()
+	w.LinkLikes(target, stale)
+	w.DestroyEntities(stale)
+
+	// the index is recycled with a bumped generation
+	recycled := w.NextEntity()
+	assert.Equal(t, stale.Index(), recycled.Index())
+	assert.NotEqual(t, stale, recycled)
+	assert.False(t, w.IsAlive(stale))
+	assert.True(t, w.IsAlive(recycled))
+
+	w.SetPositionFromValues(recycled, 4, 5, 6)
+	w.TagWithEnemy(recycled)
+	w.LinkLikes(target, recycled)
+
+	_, ok := w.Position(stale)
+	assert.False(t, ok)
+	assert.False(t, w.HasEnemyTag(stale))
+	assert.False(t, w.LikesIsLinked(stale, target))
+
+	// writes through a stale handle must not clobber the living entity
+	w.SetPositionFromValues(stale, 7, 8, 9)
+	w.RemoveEnemyTag(stale)
+	w.DestroyEntities(stale)
+
+	assert.True(t, w.IsAlive(recycled))
+	assert.Equal(t, ecs.PositionComponentFromValues(4, 5, 6), w.MustPosition(recycled))
+	assert.True(t, w.HasEnemyTag(recycled))
+	assert.True(t, w.LikesIsLinked(recycled, target))
+
+	// sets never let a stale handle take over a living entity's slot, loaders
+	// write to them without the setters' liveness checks
+	set := ecs.NewSparseSet[int]()
+	set.Upsert(recycled, 1)
+	_, wasAdded := set.Upsert(stale, 2)
+	assert.False(t, wasAdded)
+	assert.False(t, set.Contains(stale))
+	value, ok := set.Data(recycled)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+}
+
+func TestECSDestroyEntitiesRelationships(t *testing.T) {
+	w := ecs.NewWorld()
+
+	federation := w.NextEntity()
+	colonists := w.NextEntity()
+	pirates := w.NextEntity()
+	miners := w.NextEntity()
+
+	w.LinkAlliedWith(federation, colonists)
+	w.LinkAlliedWith(colonists, federation)
+	w.LinkAlliedWith(miners, pirates)
+	w.LinkAlliedWith(pirates, miners)
+	w.LinkEats(miners, pirates, 3)
+
+	// only pairs where pirates is the From or the To are dropped
+	w.DestroyEntities(pirates)
+
+	assert.True(t, w.AlliedWithIsLinked(colonists, federation))
+	assert.True(t, w.AlliedWithIsLinked(federation, colonists))
+	assert.False(t, w.AlliedWithIsLinked(miners, pirates))
+	assert.False(t, w.AlliedWithIsLinked(pirates, miners))
+	assert.False(t, w.EatsIsLinked(pirates, miners))
+	assert.Equal(t, 2, w.AlliedWithPairCount())
+	assert.Empty(t, slices.Collect(w.AlliedWithTargets(miners)))
+	assert.Equal(t, []ecs.Entity{federation}, slices.Collect(w.AlliedWithTargets(colonists)))
+}
+
+func TestECSOnTargetDeleted(t *testing.T) {
+	w := ecs.NewWorld()
+
+	ship := w.NextEntity(ecs.WithName("ship"))
+	engine := w.NextEntity(ecs.WithName("engine"))
+	piston := w.NextEntity(ecs.WithName("piston"))
+	w.LinkChildOf(ship, engine)
+	w.LinkChildOf(engine, piston)
+
+	captain := w.NextEntity()
+	w.LinkLikes(ship, captain)
+
+	// ChildOf deletes its sources recursively, Likes only drops the pair
+	w.DestroyEntities(ship)
+
+	assert.False(t, w.IsAlive(ship))
+	assert.False(t, w.IsAlive(engine))
+	assert.False(t, w.IsAlive(piston))
+	assert.True(t, w.IsAlive(captain))
+	assert.Zero(t, w.LikesPairCount())
+	assert.Zero(t, w.ChildOfPairCount())
+	assert.Zero(t, w.NamesCount())
+}
+
+func TestECSOnTargetDeletedPanic(t *testing.T) {
+	w := ecs.NewWorld()
+
+	farm := w.NextEntity()
+	seed := w.NextEntity()
+	bystander := w.NextEntity()
+	w.LinkGrows(farm, seed)
+
+	// nothing in the batch is destroyed when any of it is refused
+	assert.Panics(t, func() { w.DestroyEntities(bystander, farm) })
+	assert.True(t, w.IsAlive(bystander))
+	assert.True(t, w.IsAlive(farm))
+	assert.True(t, w.GrowsIsLinked(seed, farm))
+
+	// the same goes for children destroyed along with their parent
+	barn := w.NextEntity()
+	w.LinkChildOf(barn, farm)
+	assert.Panics(t, func() { w.DestroyEntities(barn) })
+	assert.True(t, w.IsAlive(barn))
+	assert.True(t, w.ChildOfIsLinked(farm, barn))
+
+	// a source destroyed in the same batch does not block its target
+	w.DestroyEntities(barn, seed)
+	assert.False(t, w.IsAlive(barn))
+	assert.False(t, w.IsAlive(farm))
+	assert.False(t, w.IsAlive(seed))
+	assert.True(t, w.IsAlive(bystander))
+	assert.Zero(t, w.GrowsPairCount())
+}
+
+func TestECSOwningGroup(t *testing.T) {
+	w := ecs.NewWorld()
+
+	matching := func() []ecs.Entity {
+		var entities []ecs.Entity
+		for e := range w.AllVelocitiesEntities {
+			if w.HasPosition(e) {
+				entities = append(entities, e)
+			}
+		}
+		slices.Sort(entities)
+		return entities
+	}
+	grouped := func() []ecs.Entity {
+		entities := slices.Collect(w.QueryExamplePositionVelocityEntities)
+		slices.Sort(entities)
+		return entities
+	}
+
+	entities := w.NextEntities(64)
+	for i, e := range entities {
+		if i%2 == 0 {
+			w.SetVelocityFromValues(e, 1, 0, 0)
+		}
+		if i%3 == 0 {
+			w.SetPositionFromValues(e, float32(i), 0, 0)
+		}
+	}
+	assert.Equal(t, matching(), grouped())
+
+	for i, e := range entities {
+		switch i % 5 {
+		case 0:
+			w.RemovePosition(e)
+		case 1:
+			w.SetPositionFromValues(e, float32(i), 0, 0)
+		case 2:
+			w.DestroyEntities(e)
+		}
+	}
+	assert.Equal(t, matching(), grouped())
+
+	for e, args := range w.QueryExamplePositionVelocity {
+		args.Position.X += args.Velocity.X
+		assert.Equal(t, w.MustPosition(e), *args.Position)
+	}
+}
+
+func TestECSQueryOperators(t *testing.T) {
+	w := ecs.NewWorld()
+
+	moving := w.NextEntity(
+		ecs.WithPositionDefault(),
+		ecs.WithVelocityFromValues(1, 2, 3),
+	)
+	frozen := w.NextEntity(
+		ecs.WithPositionDefault(),
+		ecs.WithVelocityFromValues(1, 2, 3),
+		ecs.WithFrozenTag(),
+	)
+	rotated := w.NextEntity(
+		ecs.WithPositionDefault(),
+		ecs.WithVelocityFromValues(1, 2, 3),
+		ecs.WithRotationDefault(),
+	)
+
+	seen := map[ecs.Entity]bool{}
+	for e, args := range w.QueryMovable {
+		seen[e] = true
+		args.Position.X += args.Velocity.X
+		assert.Equal(t, e == rotated, args.HasRotation)
+		if e == rotated {
+			assert.Equal(t, float32(1), args.Rotation.W)
+		} else {
+			assert.Nil(t, args.Rotation)
+		}
+	}
+	assert.Equal(t, map[ecs.Entity]bool{moving: true, rotated: true}, seen)
+	assert.Equal(t, float32(1), w.MustPosition(moving).X)
+	assert.Equal(t, float32(0), w.MustPosition(frozen).X)
+	assert.ElementsMatch(t, []ecs.Entity{moving, rotated}, slices.Collect(w.QueryMovableEntities))
+
+	ruler := w.NextEntity()
+	planet := w.NextEntity(ecs.WithRuledBy(ruler), ecs.WithPlanetTag())
+	station := w.NextEntity(ecs.WithRuledBy(ruler), ecs.WithSpacestationTag())
+	w.NextEntity(ecs.WithRuledBy(ruler))
+
+	ruled := map[ecs.Entity]ecs.QueryRuledsArgs{}
+	for e, args := range w.QueryRuled {
+		ruled[e] = args
+	}
+	assert.Len(t, ruled, 2)
+	assert.True(t, ruled[planet].HasPlanet)
+	assert.False(t, ruled[planet].HasSpacestation)
+	assert.True(t, ruled[station].HasSpacestation)
+	assert.ElementsMatch(t, []ecs.Entity{planet, station}, slices.Collect(w.QueryRuledEntities))
+}
+
+func TestECSQuerySmallestDriver(t *testing.T) {
+	w := ecs.NewWorld()
+
+	matching := func() []ecs.Entity {
+		var entities []ecs.Entity
+		for e := range w.AllPositionsEntities {
+			if w.HasVelocity(e) && !w.HasFrozenTag(e) {
+				entities = append(entities, e)
+			}
+		}
+		slices.Sort(entities)
+		return entities
+	}
+	queried := func() []ecs.Entity {
+		var entities []ecs.Entity
+		for e, args := range w.QueryMovable {
+			assert.Equal(t, w.MustVelocity(e), args.Velocity)
+			entities = append(entities, e)
+		}
+		slices.Sort(entities)
+		assert.Equal(t, entities, slices.Sorted(w.QueryMovableEntities))
+		return entities
+	}
+
+	// few velocities, many positions
+	entities := w.NextEntities(256)
+	for i, e := range entities {
+		w.SetPositionFromValues(e, float32(i), 0, 0)
+		if i%50 == 0 {
+			w.SetVelocityFromValues(e, float32(i), 0, 0)
+		}
+	}
+	assert.Len(t, queried(), 6)
+	assert.Equal(t, matching(), queried())
+
+	// flip it around so velocities are the larger set
+	for i, e := range entities {
+		w.SetVelocityFromValues(e, float32(i), 0, 0)
+		if i%7 != 0 {
+			w.RemovePosition(e)
+		}
+	}
+	assert.Len(t, queried(), 37)
+	assert.Equal(t, matching(), queried())
+}
+
+func TestECSRelationshipQueries(t *testing.T) {
+	w := ecs.NewWorld()
+
+	parent := w.NextEntity(ecs.WithName("parent"))
+	other := w.NextEntity(ecs.WithName("other"))
+	a := w.NextEntity(ecs.WithName("a"))
+	b := w.NextEntity(ecs.WithName("b"))
+	c := w.NextEntity(ecs.WithName("c"))
+	w.LinkChildOf(parent, a)
+	w.LinkChildOf(parent, b)
+	w.LinkChildOf(other, c)
+
+	children := map[ecs.Entity]string{}
+	for e, args := range w.QueryChild(parent) {
+		assert.Equal(t, parent, args.ChildOf.To)
+		children[e] = args.Name.Value
+	}
+	assert.Equal(t, map[ecs.Entity]string{a: "a", b: "b"}, children)
+	assert.ElementsMatch(t, []ecs.Entity{c}, slices.Collect(w.QueryChildEntities(other)))
+
+	// sources without the required Name are skipped, and matches can be
+	// unlinked while iterating
+	unnamed := w.NextEntity()
+	w.LinkChildOf(other, unnamed)
+	for e := range w.QueryChildEntities(other) {
+		w.UnlinkChildOf(e, other)
+	}
+	assert.False(t, w.ChildOfIsLinked(c, other))
+	assert.True(t, w.ChildOfIsLinked(unnamed, other))
+	assert.Empty(t, slices.Collect(w.QueryChildEntities(other)))
+
+	apple := w.NextEntity()
+	pear := w.NextEntity()
+	w.LinkEats(apple, a, 2)
+	w.LinkEats(pear, a, 3)
+	w.LinkEats(pear, b, 4)
+
+	eaten := map[ecs.Entity]map[ecs.Entity]uint8{}
+	for e, args := range w.QueryNamedEater {
+		if eaten[e] == nil {
+			eaten[e] = map[ecs.Entity]uint8{}
+		}
+		eaten[e][args.Eats.To] = args.Eats.Amount
+	}
+	assert.Equal(t, map[ecs.Entity]map[ecs.Entity]uint8{
+		a: {apple: 2, pear: 3},
+		b: {pear: 4},
+	}, eaten)
+	assert.ElementsMatch(t, []ecs.Entity{a, b}, slices.Collect(w.QueryNamedEaterEntities))
+
+	// wildcard pairs can be unlinked and linked while iterating, each pair
+	// that matched when the entity was reached is yielded once
+	visited := 0
+	for e, args := range w.QueryNamedEater {
+		visited++
+		w.UnlinkEats(e, args.Eats.To)
+		w.LinkEats(w.NextEntity(), e, 1)
+	}
+	assert.Equal(t, 3, visited)
+	assert.False(t, w.EatsIsLinked(a, apple))
+	assert.False(t, w.EatsIsLinked(a, pear))
+	assert.False(t, w.EatsIsLinked(b, pear))
+}
+
+type orderedSystem struct {
+	name     string
+	reliesOn []*orderedSystem
+	ticked   *[]string
+}
+
+func (sys *orderedSystem) Name() string {
+	return sys.name
+}
+
+func (sys *orderedSystem) ReliesOn() ecs.ReliedOnIter {
+	return func(reliedOn ecs.System) bool {
+		return slices.Contains(sys.reliesOn, reliedOn.(*orderedSystem))
+	}
+}
+
+func (sys *orderedSystem) Initialize(ctx context.Context, w *ecs.World) error {
+	return nil
+}
+
+func (sys *orderedSystem) Tick(ctx context.Context, w *ecs.World) error {
+	*sys.ticked = append(*sys.ticked, sys.name)
+	return nil
+}
+
+func TestECSSystemOrder(t *testing.T) {
+	w := ecs.NewWorld()
+
+	var ticked []string
+	input := &orderedSystem{name: "input", ticked: &ticked}
+	physics := &orderedSystem{name: "physics", ticked: &ticked, reliesOn: []*orderedSystem{input}}
+	render := &orderedSystem{name: "render", ticked: &ticked}
+	render.reliesOn = []*orderedSystem{physics, input}
+	audio := &orderedSystem{name: "audio", ticked: &ticked}
+
+	assert.NoError(t, w.AddSystems(t.Context(), render, audio, physics))
+	assert.NoError(t, w.AddSystems(t.Context(), input))
+	assert.NoError(t, w.Tick(t.Context()))
+	assert.Equal(t, []string{"audio", "input", "physics", "render"}, ticked)
+
+	// a cycle is rejected and leaves the schedule alone
+	input.reliesOn = []*orderedSystem{render}
+	err := w.AddSystems(t.Context(), &orderedSystem{name: "late", ticked: &ticked})
+	assert.ErrorContains(t, err, "dependency cycle")
+	assert.ErrorContains(t, err, "physics")
+	input.reliesOn = nil
+
+	ticked = nil
+	assert.NoError(t, w.Tick(t.Context()))
+	assert.Equal(t, []string{"audio", "input", "physics", "render"}, ticked)
+}
+
+type accessSystem struct {
+	access ecs.Access
+	tick   func(w *ecs.World) error
+}
+
+func (sys *accessSystem) ReliesOn() ecs.ReliedOnIter {
+	return nil
+}
+
+func (sys *accessSystem) Initialize(ctx context.Context, w *ecs.World) error {
+	return nil
+}
+
+func (sys *accessSystem) Access() ecs.Access {
+	return sys.access
+}
+
+func (sys *accessSystem) Tick(ctx context.Context, w *ecs.World) error {
+	return sys.tick(w)
+}
+
+func TestECSParallelSystems(t *testing.T) {
+	w := ecs.NewWorld()
+	w.SetTickMode(ecs.TickParallel)
+
+	for i := range 100 {
+		w.NextEntity(
+			ecs.WithPositionDefault(),
+			ecs.WithVelocityFromValues(float32(i), 0, 0),
+			ecs.WithRotationDefault(),
+		)
+	}
+
+	// both systems only finish once the other has started, so they must tick
+	// at the same time
+	moverStarted, spinnerStarted := make(chan struct{}), make(chan struct{})
+	waitFor := func(started chan struct{}) error {
+		select {
+		case <-started:
+			return nil
+		case <-time.After(5 * time.Second):
+			return errors.New("systems did not tick in parallel")
+		}
+	}
+
+	mover := &accessSystem{
+		access: ecs.QueryExamplePositionVelocityAccess,
+		tick: func(w *ecs.World) error {
+			close(moverStarted)
+			for _, args := range w.QueryExamplePositionVelocity {
+				args.Position.X += args.Velocity.X
+			}
+			return waitFor(spinnerStarted)
+		},
+	}
+	spinner := &accessSystem{
+		access: ecs.Access{Writes: []ecs.ComponentID{ecs.RotationID}},
+		tick: func(w *ecs.World) error {
+			close(spinnerStarted)
+			for _, r := range w.AllMutableRotations {
+				r.W = 0
+			}
+			return waitFor(moverStarted)
+		},
+	}
+	moved := 0
+	// reads positions, which the mover writes, so it has to tick afterwards
+	counter := &accessSystem{
+		access: ecs.Access{Reads: []ecs.ComponentID{ecs.PositionID}},
+		tick: func(w *ecs.World) error {
+			for _, p := range w.AllPositions {
+				if p.X != 0 {
+					moved++
+				}
+			}
+			return nil
+		},
+	}
+
+	assert.NoError(t, w.AddSystems(t.Context(), mover, spinner, counter))
+	assert.NoError(t, w.Tick(t.Context()))
+	assert.Equal(t, 99, moved)
+	for _, r := range w.AllRotations {
+		assert.Zero(t, r.W)
+	}
+}
+
+func TestECSParallelSystemsImplicitReads(t *testing.T) {
+	w := ecs.NewWorld()
+	w.SetTickMode(ecs.TickParallel)
+
+	prefab := w.NextEntity(ecs.WithHealthDefault())
+	entities := w.NextEntities(100, ecs.WithPositionDefault(), ecs.WithVelocityDefault())
+
+	// writes to observed sets check the other sets of the observing query
+	stop := w.OnQueryMovableEnter(func(evt ecs.QueryMovableEnterEvent) {})
+	defer stop()
+
+	// each system keeps the change tick it ticked at, systems sharing a stage
+	// share a change tick
+	system := func(access ecs.Access, tick func(w *ecs.World)) (*accessSystem, *uint64) {
+		tickedAt := new(uint64)
+		return &accessSystem{
+			access: access,
+			tick: func(w *ecs.World) error {
+				tick(w)
+				*tickedAt = w.ChangeTick()
+				return nil
+			},
+		}, tickedAt
+	}
+
+	positioner, positioned := system(ecs.Access{Writes: []ecs.ComponentID{ecs.PositionID}}, func(w *ecs.World) {
+		for i, e := range entities {
+			w.SetPositionFromValues(e, float32(i), 0, 0)
+		}
+	})
+	freezer, frozen := system(ecs.Access{Writes: []ecs.ComponentID{ecs.FrozenID}}, func(w *ecs.World) {
+		for _, e := range entities[:50] {
+			w.TagWithFrozen(e)
+		}
+	})
+	// reading an inheritable component reads IsA to find the prefab
+	healer, healed := system(ecs.Access{Reads: []ecs.ComponentID{ecs.HealthID}}, func(w *ecs.World) {
+		for _, e := range entities {
+			w.Health(e)
+		}
+	})
+	instancer, instanced := system(ecs.Access{Writes: []ecs.ComponentID{ecs.IsAID}}, func(w *ecs.World) {
+		for _, e := range entities {
+			w.LinkIsA(prefab, e)
+		}
+	})
+
+	assert.NoError(t, w.AddSystems(t.Context(), positioner, freezer, healer, instancer))
+	assert.NoError(t, w.Tick(t.Context()))
+
+	assert.NotEqual(t, *positioned, *frozen)
+	assert.NotEqual(t, *healed, *instanced)
+	// systems whose implicit reads don't conflict still tick together
+	assert.Equal(t, *positioned, *healed)
+	for _, e := range entities {
+		health, ok := w.Health(e)
+		assert.True(t, ok)
+		assert.Equal(t, float32(100), health.Current)
+	}
+}
+
+func TestECSCommandBuffer(t *testing.T) {
+	w := ecs.NewWorld()
+
+	entities := w.NextEntities(100)
+	for i, e := range entities {
+		w.SetPositionFromValues(e, float32(i), 0, 0)
+	}
+
+	// removing while iterating swaps entities under the iterator, deferring
+	// the removal visits every entity
+	visited := 0
+	cmds := w.Commands()
+	for e, p := range w.AllPositions {
+		visited++
+		if int(p.X)%2 == 0 {
+			cmds.RemovePosition(e)
+		} else {
+			cmds.TagWithFrozen(e)
+			cmds.LinkEats(entities[0], e, 1)
+		}
+	}
+	assert.Equal(t, 100, visited)
+	assert.Equal(t, 100, w.PositionsCount())
+	assert.Equal(t, 150, cmds.Len())
+
+	w.FlushCommands()
+	assert.Zero(t, cmds.Len())
+	assert.Equal(t, 50, w.PositionsCount())
+	assert.Equal(t, 50, w.FrozenTagCount())
+	assert.Equal(t, 50, w.EatsPairCount())
+
+	// a later system sees the commands of an earlier one
+	destroyer := &accessSystem{
+		tick: func(w *ecs.World) error {
+			for e := range w.AllFrozenEntities {
+				w.Commands().DestroyEntities(e)
+			}
+			return nil
+		},
+	}
+	checker := &accessSystem{
+		tick: func(w *ecs.World) error {
+			assert.Zero(t, w.FrozenTagCount())
+			return nil
+		},
+	}
+	assert.NoError(t, w.AddSystems(t.Context(), destroyer, checker))
+	assert.NoError(t, w.Tick(t.Context()))
+	assert.Zero(t, w.EatsPairCount())
+}
+
+func TestECSSnapshot(t *testing.T) {
+	w := ecs.NewWorld()
+
+	entities := w.NextEntities(10)
+	for i, e := range entities {
+		w.SetPositionFromValues(e, float32(i), 1, 2)
+		if i%2 == 0 {
+			w.SetVelocityFromValues(e, 1, 0, 0)
+		}
+	}
+	w.SetName(entities[0], "first")
+	w.SetDirection(entities[1], ecs.EnumDirectionNorth|ecs.EnumDirectionEast)
+	w.TagWithEnemy(entities[2], entities[3])
+	w.LinkEats(entities[4], entities[5], 7)
+	w.LinkLikes(entities[4], entities[6])
+	w.SetGravityResource(-1.5)
+	w.DestroyEntities(entities[9])
+
+	var buf bytes.Buffer
+	assert.NoError(t, w.SaveSnapshot(&buf))
+
+	loaded := ecs.NewWorld()
+	loaded.NextEntities(3, ecs.WithFrozenTag())
+	assert.NoError(t, loaded.LoadSnapshot(&buf))
+
+	assert.Zero(t, loaded.FrozenTagCount())
+	assert.False(t, loaded.IsAlive(entities[9]))
+	assert.Equal(t, w.PositionsCount(), loaded.PositionsCount())
+	for e, p := range w.AllPositions {
+		assert.Equal(t, p, loaded.MustPosition(e))
+	}
+	assert.Equal(t, "first", loaded.MustName(entities[0]).Value)
+	assert.Equal(t, ecs.EnumDirectionNorth|ecs.EnumDirectionEast, loaded.MustDirection(entities[1]).Values)
+	assert.True(t, loaded.HasEnemyTag(entities[3]))
+	pair, ok := loaded.EatsPair(entities[5], entities[4])
+	assert.True(t, ok)
+	assert.Equal(t, uint8(7), pair.Amount)
+	assert.True(t, loaded.LikesIsLinked(entities[6], entities[4]))
+	assert.Equal(t, float32(-1.5), loaded.MustGravityResource().G)
+
+	// owning groups are rebuilt and recycled handles match
+	assert.Equal(t,
+		slices.Sorted(w.QueryExamplePositionVelocityEntities),
+		slices.Sorted(loaded.QueryExamplePositionVelocityEntities),
+	)
+	assert.Equal(t, w.NextEntity(), loaded.NextEntity())
+
+	data, err := w.MarshalBinary()
+	assert.NoError(t, err)
+	for _, n := range []int{0, 3, 10, len(data) / 2, len(data) - 1} {
+		assert.Error(t, loaded.UnmarshalBinary(data[:n]))
+	}
+	assert.Zero(t, loaded.PositionsCount())
+}
+
+func TestECSSnapshotMigration(t *testing.T) {
+	w := ecs.NewWorld()
+
+	entities := w.NextEntities(3)
+	w.SetName(entities[0], "first")
+	w.TagWithEnemy(entities[1], entities[2])
+	w.SetHealth(entities[0], ecs.HealthComponent{Current: 50, Max: 80, Regen: 5})
+
+	data, err := w.MarshalBinary()
+	assert.NoError(t, err)
+
+	// deprecated fields are never saved
+	loaded := ecs.NewWorld()
+	assert.NoError(t, loaded.UnmarshalBinary(data))
+	assert.Equal(t, ecs.HealthComponent{Current: 50, Max: 80}, loaded.MustHealth(entities[0]))
+
+	// newer snapshots can't be read
+	newer := slices.Clone(data)
+	newer[5] = ecs.SchemaVersion + 1
+	assert.Error(t, loaded.UnmarshalBinary(newer))
+
+	// pretend version 1 called the Name field Label and had an Enemx tag
+	older := slices.Clone(data)
+	older[5] = ecs.SchemaVersion - 1
+	older = bytes.Replace(older, []byte("\x05Value"), []byte("\x05Label"), 1)
+	older = bytes.Replace(older, []byte("Example.Enemy"), []byte("Example.Enemx"), 1)
+
+	var steps []uint32
+	loaded.RegisterMigration(ecs.SchemaVersion-1, func(w *ecs.World, m *ecs.Migration) error {
+		steps = append(steps, m.From)
+		for _, leftover := range m.Leftovers("Example.Enemx") {
+			w.TagWithEnemy(leftover.Entity)
+		}
+		for _, leftover := range m.Leftovers("Builtin.Name") {
+			// the renamed field is left at its reset value
+			assert.Equal(t, "", w.MustName(leftover.Entity).Value)
+			w.SetName(leftover.Entity, leftover.Fields["Label"].(string)+"!")
+		}
+		return nil
+	})
+	assert.NoError(t, loaded.UnmarshalBinary(older))
+	assert.Equal(t, []uint32{ecs.SchemaVersion - 1}, steps)
+	assert.True(t, loaded.HasEnemyTag(entities[1]))
+	assert.True(t, loaded.HasEnemyTag(entities[2]))
+
+	assert.Equal(t, "first!", loaded.MustName(entities[0]).Value)
+
+	// a failing hook fails the load
+	loaded.RegisterMigration(ecs.SchemaVersion-1, func(w *ecs.World, m *ecs.Migration) error {
+		return errors.New("boom")
+	})
+	assert.Error(t, loaded.UnmarshalBinary(older))
+	assert.Zero(t, loaded.HealthsCount())
+}
+
+func TestECSJSON(t *testing.T) {
+	fixture := `{
+		"resources": {"components": {"Example.Gravity": {"g": -1.5}}},
+		"entities": [
+			{"name": "station", "tags": ["Xxx.Spacestation"]},
+			{
+				"name": "ship",
+				"tags": ["Xxx.Spaceship"],
+				"components": {
+					"Xxx.DockedTo": {"entity": "station"},
+					"Example.Position": {"x": 1}
+				}
+			},
+			{
+				"id": 7,
+				"relationships": {
+					"Example.Eats": [{"to": "ship", "amount": 3}],
+					"Builtin.ChildOf": ["station"]
+				}
+			}
+		]
+	}`
+
+	w := ecs.NewWorld()
+	entities, err := w.ImportJSON(strings.NewReader(fixture))
+	assert.NoError(t, err)
+	assert.Len(t, entities, 3)
+	station, ship, eater := entities[0], entities[1], entities[2]
+
+	assert.Equal(t, "ship", w.MustName(ship).Value)
+	assert.True(t, w.HasSpacestationTag(station))
+	assert.Equal(t, station, w.MustDockedTo(ship).Entity)
+	assert.Equal(t, ecs.PositionComponent{X: 1}, w.MustPosition(ship))
+	assert.Equal(t, float32(-1.5), w.MustGravityResource().G)
+	pair, ok := w.EatsPair(eater, ship)
+	assert.True(t, ok)
+	assert.Equal(t, uint8(3), pair.Amount)
+	assert.True(t, w.ChildOfIsLinked(eater, station))
+
+	// a fresh world hands out the same handles, so the export round trips
+	var exported bytes.Buffer
+	assert.NoError(t, w.ExportJSON(&exported))
+	loaded := ecs.NewWorld()
+	_, err = loaded.ImportJSON(bytes.NewReader(exported.Bytes()))
+	assert.NoError(t, err)
+	var reexported bytes.Buffer
+	assert.NoError(t, loaded.ExportJSON(&reexported))
+	assert.JSONEq(t, exported.String(), reexported.String())
+
+	// mistakes in fixtures are reported and nothing is left behind
+	for _, bad := range []string{
+		`{"entities": [{"components": {"Example.Positon": {}}}]}`,
+		`{"entities": [{"components": {"Example.Position": {"w": 1}}}]}`,
+		`{"entities": [{"tags": ["Example.Friend"]}]}`,
+		`{"entities": [{"relationships": {"Example.Likes": ["nobody"]}}]}`,
+		`{"entities": [{"name": "a"}, {"name": "a"}, {"relationships": {"Example.Likes": ["a"]}}]}`,
+	} {
+		before := loaded.PositionsCount()
+		_, err := loaded.ImportJSON(strings.NewReader(bad))
+		assert.Error(t, err, bad)
+		assert.Equal(t, before, loaded.PositionsCount())
+		assert.Zero(t, loaded.LikesPairCount())
+	}
+
+	// resources are only written once every entity decoded
+	_, err = loaded.ImportJSON(strings.NewReader(`{
+		"resources": {"components": {
+			"Example.Gravity": {"g": 9.8},
+			"Xxx.DockedTo": {"entity": "new"}
+		}},
+		"entities": [{"name": "new"}, {"tags": ["Example.Friend"]}]
+	}`))
+	assert.Error(t, err)
+	assert.Equal(t, float32(-1.5), loaded.MustGravityResource().G)
+	assert.False(t, loaded.HasDockedToResource())
+}
+
+func TestECSDelta(t *testing.T) {
+	server, client := ecs.NewWorld(), ecs.NewWorld()
+	sync := func(prev ecs.Snapshot) ecs.Delta {
+		t.Helper()
+		delta := server.Diff(prev)
+		data, err := delta.MarshalBinary()
+		assert.NoError(t, err)
+		var decoded ecs.Delta
+		assert.NoError(t, decoded.UnmarshalBinary(data))
+		assert.Equal(t, delta, decoded)
+		assert.NoError(t, client.ApplyDelta(decoded))
+
+		var want, got bytes.Buffer
+		assert.NoError(t, server.ExportJSON(&want))
+		assert.NoError(t, client.ExportJSON(&got))
+		assert.JSONEq(t, want.String(), got.String())
+		return delta
+	}
+
+	prev := server.Snapshot()
+	entities := server.NextEntities(4)
+	for i, e := range entities {
+		server.SetPositionFromValues(e, float32(i), 0, 0)
+	}
+	server.SetName(entities[0], "first")
+	server.TagWithEnemy(entities[1])
+	server.LinkEats(entities[2], entities[3], 5)
+	server.SetGravityResource(-3)
+	delta := sync(prev)
+	assert.Equal(t, entities, delta.Created)
+	assert.Len(t, delta.Position.Added, 4)
+
+	prev = server.Snapshot()
+	delta = server.Diff(prev)
+	assert.True(t, delta.IsEmpty())
+
+	server.MustMutablePosition(entities[1]).Y = 7
+	server.RemoveEnemyTag(entities[1])
+	server.LinkEats(entities[2], entities[3], 6)
+	server.DestroyEntities(entities[0])
+	recycled := server.NextEntity(ecs.WithName("second"))
+	delta = sync(prev)
+	assert.Equal(t, []ecs.Entity{entities[0]}, delta.Destroyed)
+	assert.Equal(t, []ecs.Entity{recycled}, delta.Created)
+	assert.Equal(t, []ecs.ComponentChange[ecs.PositionComponent]{
+		{Entity: entities[1], Fields: 1 << 1, Value: ecs.PositionComponent{Y: 7}},
+	}, delta.Position.Changed)
+	// the destroyed entity's components aren't listed
+	assert.Empty(t, delta.Position.Removed)
+	assert.Len(t, delta.Eats.Linked, 1)
+
+	// unlinking and removing from an entity that survives
+	prev = server.Snapshot()
+	server.UnlinkEats(entities[3], entities[2])
+	server.RemovePosition(entities[2])
+	delta = sync(prev)
+	assert.Len(t, delta.Eats.Unlinked, 1)
+	assert.Equal(t, []ecs.Entity{entities[2]}, delta.Position.Removed)
+
+	// a delta that doesn't fit is rejected
+	assert.Error(t, ecs.NewWorld().ApplyDelta(ecs.Delta{
+		Position: ecs.ComponentDelta[ecs.PositionComponent]{
+			Changed: []ecs.ComponentChange[ecs.PositionComponent]{
+				{Entity: entities[1], Fields: 1},
+			},
+		},
+	}))
+
+	// nothing is applied when any of it doesn't fit
+	w := ecs.NewWorld()
+	doomed := w.NextEntity(ecs.WithPositionDefault())
+	prefab := w.NextEntity(ecs.WithHealthDefault())
+	instance := w.NextEntity(ecs.WithPrefab(prefab))
+	err := w.ApplyDelta(ecs.Delta{
+		Destroyed: []ecs.Entity{doomed},
+		Health: ecs.ComponentDelta[ecs.HealthComponent]{
+			Changed: []ecs.ComponentChange[ecs.HealthComponent]{
+				{Entity: instance, Fields: 1, Value: ecs.HealthComponent{Current: 5}},
+			},
+		},
+	})
+	assert.ErrorContains(t, err, "has no Health to change")
+	assert.True(t, w.IsAlive(doomed))
+	// inherited components are not the instance's to change
+	assert.False(t, w.HasHealth(instance))
+	assert.Equal(t, float32(100), w.MustHealth(instance).Current)
+}
+
+type changeSystem struct {
+	reliesOn ecs.System
+	tick     func(ctx context.Context, w *ecs.World)
+}
+
+func (sys *changeSystem) ReliesOn() ecs.ReliedOnIter {
+	return func(reliedOn ecs.System) bool {
+		return reliedOn == sys.reliesOn
+	}
+}
+
+func (sys *changeSystem) Initialize(ctx context.Context, w *ecs.World) error {
+	return nil
+}
+
+func (sys *changeSystem) Tick(ctx context.Context, w *ecs.World) error {
+	sys.tick(ctx, w)
+	return nil
+}
+
+func TestECSChangeTicks(t *testing.T) {
+	w := ecs.NewWorld()
+	moving, still := w.NextEntity(), w.NextEntity()
+	w.SetPositionFromValues(moving, 0, 0, 0)
+	w.SetPositionFromValues(still, 0, 0, 0)
+
+	shouldMove := false
+	var seen []ecs.Entity
+	mover := &changeSystem{tick: func(ctx context.Context, w *ecs.World) {
+		if shouldMove {
+			w.MustMutablePosition(moving).X++
+		}
+	}}
+	watcher := &changeSystem{reliesOn: mover, tick: func(ctx context.Context, w *ecs.World) {
+		seen = slices.Sorted(w.QueryMovedEntities(ecs.LastRunTick(ctx)))
+		// its own writes are not seen on its next tick
+		w.SetPositionFromValues(still, 0, 0, 0)
+	}}
+	assert.NoError(t, w.AddSystems(context.Background(), watcher, mover))
+
+	tick := func() []ecs.Entity {
+		t.Helper()
+		assert.NoError(t, w.Tick(context.Background()))
+		return seen
+	}
+	assert.Equal(t, []ecs.Entity{moving, still}, tick())
+	assert.Empty(t, tick())
+	shouldMove = true
+	assert.Equal(t, []ecs.Entity{moving}, tick())
+
+	// polling outside of systems
+	since := w.AdvanceChangeTick()
+	spawned := w.NextEntity(ecs.WithName("spawned"))
+	w.SetVelocityFromValues(spawned, 1, 0, 0)
+	w.SetVelocityFromValues(moving, 1, 0, 0)
+	assert.Equal(t, []ecs.Entity{spawned}, slices.Collect(w.QuerySpawnedEntities(since)))
+	w.SetName(moving, "moving")
+	assert.Equal(t, []ecs.Entity{moving, spawned}, slices.Sorted(w.QuerySpawnedEntities(since)))
+	assert.False(t, w.PositionChangedSince(moving, since))
+	assert.True(t, w.VelocityAddedSince(spawned, since))
+
+	since = w.AdvanceChangeTick()
+	w.SetVelocityFromValues(spawned, 2, 0, 0)
+	assert.True(t, w.VelocityChangedSince(spawned, since))
+	assert.False(t, w.VelocityAddedSince(spawned, since))
+}
+
+func TestECSChangeTicksSkippedEntities(t *testing.T) {
+	w := ecs.NewWorld()
+	moving, frozen, still := w.NextEntity(), w.NextEntity(), w.NextEntity()
+	for _, e := range []ecs.Entity{moving, frozen, still} {
+		w.SetPositionFromValues(e, 0, 0, 0)
+	}
+	w.SetVelocityFromValues(moving, 1, 0, 0)
+	w.SetVelocityFromValues(frozen, 1, 0, 0)
+	w.TagWithFrozen(frozen)
+
+	// only the matches the query yields get mutable positions
+	since := w.AdvanceChangeTick()
+	var yielded []ecs.Entity
+	for e := range w.QueryMovable {
+		yielded = append(yielded, e)
+	}
+	assert.Equal(t, []ecs.Entity{moving}, yielded)
+	assert.Equal(t, []ecs.Entity{moving}, slices.Collect(w.QueryMovedEntities(since)))
+
+	since = w.AdvanceChangeTick()
+	yielded = nil
+	for e := range w.QueryDrifting {
+		yielded = append(yielded, e)
+	}
+	assert.ElementsMatch(t, []ecs.Entity{moving, frozen}, yielded)
+	assert.ElementsMatch(t, []ecs.Entity{moving, frozen}, slices.Collect(w.QueryMovedEntities(since)))
+	assert.False(t, w.PositionChangedSince(still, since))
+}
+
+// valueSystem is ticked as a value holding a slice, so it can't be compared
+type valueSystem struct {
+	lastRunTicks []*uint64
+}
+
+func (sys valueSystem) ReliesOn() ecs.ReliedOnIter {
+	return nil
+}
+
+func (sys valueSystem) Initialize(ctx context.Context, w *ecs.World) error {
+	return nil
+}
+
+func (sys valueSystem) Tick(ctx context.Context, w *ecs.World) error {
+	*sys.lastRunTicks[0] = ecs.LastRunTick(ctx)
+	return nil
+}
+
+func TestECSChangeTicksUncomparableSystems(t *testing.T) {
+	for _, mode := range []ecs.TickMode{ecs.TickSequential, ecs.TickParallel} {
+		w := ecs.NewWorld()
+		w.SetTickMode(mode)
+
+		var first, second uint64
+		assert.NoError(t, w.AddSystems(t.Context(),
+			valueSystem{lastRunTicks: []*uint64{&first}},
+			valueSystem{lastRunTicks: []*uint64{&second}},
+		))
+		assert.NoError(t, w.Tick(t.Context()))
+		assert.Zero(t, first)
+		assert.Zero(t, second)
+
+		assert.NoError(t, w.Tick(t.Context()))
+		assert.NotZero(t, first)
+		assert.Less(t, first, second)
+	}
+}
+
+func TestECSQueryObservers(t *testing.T) {
+	w := ecs.NewWorld()
+	var entered, exited []ecs.Entity
+	stopEnter := w.OnQueryMovableEnter(func(evt ecs.QueryMovableEnterEvent) {
+		entered = append(entered, evt.Entity)
+	})
+	defer stopEnter()
+	stopExit := w.OnQueryMovableExit(func(evt ecs.QueryMovableExitEvent) {
+		exited = append(exited, evt.Entity)
+	})
+	defer stopExit()
+
+	e := w.NextEntity(ecs.WithPositionFromValues(0, 0, 0))
+	assert.Empty(t, entered)
+	w.SetVelocityFromValues(e, 1, 0, 0)
+	assert.Equal(t, []ecs.Entity{e}, entered)
+
+	// writes that keep it matching fire nothing, optional terms are ignored
+	w.SetVelocityFromValues(e, 2, 0, 0)
+	w.SetRotationFromValues(e, 0, 0, 0, 1)
+	assert.Len(t, entered, 1)
+	assert.Empty(t, exited)
+
+	w.TagWithFrozen(e)
+	assert.Equal(t, []ecs.Entity{e}, exited)
+	w.RemoveFrozenTag(e)
+	assert.Equal(t, []ecs.Entity{e, e}, entered)
+	w.RemoveVelocity(e)
+	assert.Equal(t, []ecs.Entity{e, e}, exited)
+
+	w.SetVelocityFromValues(e, 1, 0, 0)
+	w.DestroyEntities(e)
+	assert.Equal(t, []ecs.Entity{e, e, e}, exited)
+
+	// builder options enter once the last required term is set
+	other := w.NextEntity(ecs.WithPositionFromValues(0, 0, 0), ecs.WithVelocityFromValues(1, 0, 0))
+	assert.Equal(t, []ecs.Entity{e, e, e, other}, entered)
+}
+
+func TestECSEntityEvents(t *testing.T) {
+	w := ecs.NewWorld()
+	var created, destroyed, enemies [][]ecs.Entity
+	var removed []string
+	stops := []ecs.UnsubscribeFunc{
+		w.OnEntitiesCreated(func(evt ecs.EntitiesCreatedEvent) {
+			created = append(created, evt.Entities)
+		}),
+		w.OnEntitiesDestroyed(func(evt ecs.EntitiesDestroyedEvent) {
+			destroyed = append(destroyed, evt.Entities)
+		}),
+		w.OnEnemyRemoved(func(evt ecs.EnemyRemovedEvent) {
+			removed = append(removed, "enemy")
+			enemies = append(enemies, evt.Entities)
+		}),
+		w.OnHealthRemoved(func(evt ecs.HealthRemovedEvent) {
+			removed = append(removed, "health")
+		}),
+	}
+	defer func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}()
+
+	batch := w.NextEntities(3, ecs.WithEnemyTag())
+	assert.Equal(t, [][]ecs.Entity{batch}, created)
+	w.SetHealthFromValues(batch[0], 10, 10)
+
+	// stale handles are left out of the batch
+	w.DestroyEntities(batch[0], batch[0], batch[1])
+	assert.Equal(t, [][]ecs.Entity{batch[:2]}, destroyed)
+	assert.ElementsMatch(t, []string{"health", "enemy"}, removed)
+	assert.Equal(t, [][]ecs.Entity{batch[:2]}, enemies)
+
+	w.DestroyEntities(batch[0])
+	assert.Len(t, destroyed, 1)
+
+	// tag events carry every entity of the call
+	w.RemoveEnemyTag(batch[2], batch[2])
+	assert.Equal(t, [][]ecs.Entity{batch[:2], batch[2:]}, enemies)
+
+	// sources deleted with their target are part of the same batch
+	parent, child, grandchild := w.NextEntity(), w.NextEntity(), w.NextEntity()
+	w.LinkChildOf(parent, child)
+	w.LinkChildOf(child, grandchild)
+	destroyed = nil
+	w.DestroyEntities(parent)
+	assert.Equal(t, [][]ecs.Entity{{parent, child, grandchild}}, destroyed)
+	assert.False(t, w.IsAlive(grandchild))
+}
+
+func TestECSRelationshipEvents(t *testing.T) {
+	w := ecs.NewWorld()
+	var linked, unlinked []ecs.AlliedWithRelationshipPair
+	stopLinked := w.OnAlliedWithLinked(func(evt ecs.AlliedWithLinkedEvent) {
+		linked = append(linked, evt.Pair)
+	})
+	defer stopLinked()
+	stopUnlinked := w.OnAlliedWithUnlinked(func(evt ecs.AlliedWithUnlinkedEvent) {
+		unlinked = append(unlinked, evt.Pair)
+	})
+	defer stopUnlinked()
+
+	a, b, c := w.NextEntity(), w.NextEntity(), w.NextEntity()
+	w.LinkAlliedWith(b, a)
+	w.LinkAlliedWith(b, a)
+	w.LinkAlliedWith(c, a)
+	w.LinkAlliedWith(a, a)
+	assert.Equal(t, []ecs.AlliedWithRelationshipPair{
+		{From: a, To: b}, {From: a, To: c}, {From: a, To: a},
+	}, linked)
+
+	w.UnlinkAlliedWith(a, b)
+	w.UnlinkAlliedWith(a, b)
+	assert.Equal(t, []ecs.AlliedWithRelationshipPair{{From: a, To: b}}, unlinked)
+
+	// destroying removes every pair once, including links to itself
+	w.DestroyEntities(a)
+	assert.ElementsMatch(t, []ecs.AlliedWithRelationshipPair{
+		{From: a, To: b}, {From: a, To: c}, {From: a, To: a},
+	}, unlinked)
+	assert.Zero(t, w.AlliedWithPairCount())
+}
+
+func TestECSPrefabs(t *testing.T) {
+	w := ecs.NewWorld()
+	base := w.NextEntity(ecs.WithVelocityFromValues(1, 0, 0), ecs.WithRotationFromValues(0, 0, 0, 1))
+	prefab := w.NextEntity(ecs.WithPrefab(base), ecs.WithVelocityFromValues(2, 0, 0))
+	asteroids := w.NextEntities(3, ecs.WithPrefab(prefab), ecs.WithPositionFromValues(0, 0, 0))
+	a := asteroids[0]
+
+	// reads fall back to the nearest prefab, owning is still tracked apart
+	assert.Equal(t, float32(2), w.MustVelocity(a).X)
+	assert.Equal(t, float32(1), w.MustRotation(a).W)
+	assert.False(t, w.HasVelocity(a))
+	assert.Equal(t, 2, w.VelocitiesCount())
+
+	// writes give the instance its own copy and leave the prefab alone
+	w.MustMutableVelocity(a).X = 5
+	assert.True(t, w.HasVelocity(a))
+	assert.Equal(t, float32(2), w.MustVelocity(prefab).X)
+	assert.Equal(t, float32(2), w.MustVelocity(asteroids[1]).X)
+	w.SetRotationFromValues(asteroids[1], 1, 0, 0, 0)
+	assert.Equal(t, float32(1), w.MustRotation(base).W)
+
+	for _, args := range w.QueryDrifting {
+		args.Position.X += args.Velocity.X
+	}
+	assert.Equal(t, float32(5), w.MustPosition(a).X)
+	assert.Equal(t, float32(2), w.MustPosition(asteroids[2]).X)
+	// the plain query only matches owned velocities
+	assert.Equal(t, []ecs.Entity{a}, slices.Collect(w.QueryExamplePositionVelocityEntities))
+
+	w.UnlinkIsA(asteroids[2], prefab)
+	_, ok := w.Velocity(asteroids[2])
+	assert.False(t, ok)
+	assert.ElementsMatch(t, asteroids[:2], slices.Collect(w.QueryDriftingEntities))
+
+	// cycles stop at the depth limit instead of recursing forever
+	w.LinkIsA(prefab, base)
+	_, ok = w.Gravity(a)
+	assert.False(t, ok)
+}
+
+func TestECSHierarchy(t *testing.T) {
+	w := ecs.NewWorld()
+	named := func(name string, parent ...ecs.Entity) ecs.Entity {
+		e := w.NextEntity(ecs.WithName(name))
+		for _, p := range parent {
+			w.LinkChildOf(p, e)
+		}
+		return e
+	}
+	ship := named("ship")
+	engine := named("engine", ship)
+	left := named("left", engine)
+	right := named("right", engine)
+	hull := named("hull", ship)
+
+	parent, ok := w.ParentOf(left)
+	assert.True(t, ok)
+	assert.Equal(t, engine, parent)
+	_, ok = w.ParentOf(ship)
+	assert.False(t, ok)
+	assert.Equal(t, []ecs.Entity{engine, ship}, slices.Collect(w.Ancestors(left)))
+
+	assert.Equal(t, []ecs.Entity{engine, left, right, hull}, slices.Collect(w.DepthFirst(ship)))
+	assert.Equal(t, []ecs.Entity{engine, hull, left, right}, slices.Collect(w.BreadthFirst(ship)))
+
+	found, ok := w.Lookup("ship/engine/left")
+	assert.True(t, ok)
+	assert.Equal(t, left, found)
+	_, ok = w.Lookup("engine/left")
+	assert.False(t, ok)
+	found, ok = w.LookupFrom(ship, "hull")
+	assert.True(t, ok)
+	assert.Equal(t, hull, found)
+	path, ok := w.Path(right)
+	assert.True(t, ok)
+	assert.Equal(t, "ship/engine/right", path)
+
+	assert.True(t, errors.Is(w.Reparent(engine, left), ecs.ErrHierarchyCycle))
+	assert.True(t, errors.Is(w.Reparent(ship, ship), ecs.ErrHierarchyCycle))
+	assert.NoError(t, w.Reparent(left, hull))
+	assert.Equal(t, []ecs.Entity{hull, ship}, slices.Collect(w.Ancestors(left)))
+	_, ok = w.Lookup("ship/engine/left")
+	assert.False(t, ok)
+	_, ok = w.Lookup("ship/hull/left")
+	assert.True(t, ok)
+}
+
+func TestECSStructFields(t *testing.T) {
+	w := ecs.NewWorld()
+	e := w.NextEntity()
+
+	transform := ecs.DefaultTransformComponent()
+	assert.Equal(t, mathx.Vector3[float32]{X: 1, Y: 1, Z: 1}, transform.Scale)
+	assert.Equal(t, mathx.Quaternion[float32]{W: 1}, transform.Orientation)
+	assert.Equal(t, mathx.Vector3[float32]{X: 1, Y: 1, Z: 1}, ecs.DefaultBoundsComponent().Box.Max)
+	assert.Equal(t, float32(10), ecs.DefaultAttributeComponent().Strength.Base)
+	assert.Equal(t, mathx.Quaternion[float32]{W: 1}, ecs.DefaultSocket().Turn)
+
+	w.SetTransformFromValues(e,
+		mathx.Vector3[float32]{X: 1, Y: 2, Z: 3},
+		mathx.Quaternion[float32]{W: 1},
+		mathx.Vector3[float32]{X: 2, Y: 2, Z: 2},
+	)
+	w.SetBounds(e, mathx.Box3[float32]{Max: mathx.Vector3[float32]{X: 4, Y: 4, Z: 4}})
+	w.SetAttributeFromValues(e, ecs.StatFromValues(12, 3), ecs.DefaultStat())
+	w.SetLoadout(e, []ecs.Socket{
+		ecs.SocketFromValues("left", mathx.Vector3[float32]{X: -1}, mathx.Quaternion[float32]{W: 1}, ecs.DefaultStat()),
+		ecs.SocketFromValues("right", mathx.Vector3[float32]{X: 1}, mathx.Quaternion[float32]{W: 1}, ecs.StatFromValues(5, 5)),
+	})
+
+	// clones do not share the slots
+	loadout := w.MustLoadout(e)
+	clone := loadout.Clone()
+	clone.Slots[0].Label = "changed"
+	assert.Equal(t, "left", w.MustLoadout(e).Slots[0].Label)
+
+	var buf bytes.Buffer
+	assert.NoError(t, w.SaveSnapshot(&buf))
+	loaded := ecs.NewWorld()
+	assert.NoError(t, loaded.LoadSnapshot(&buf))
+	assert.Equal(t, w.MustTransform(e), loaded.MustTransform(e))
+	assert.Equal(t, w.MustBounds(e), loaded.MustBounds(e))
+	assert.Equal(t, w.MustAttribute(e), loaded.MustAttribute(e))
+	assert.Equal(t, w.MustLoadout(e), loaded.MustLoadout(e))
+
+	var exported bytes.Buffer
+	assert.NoError(t, w.ExportJSON(&exported))
+	assert.Contains(t, exported.String(), `"bonus": 5`)
+	fromJSON := ecs.NewWorld()
+	_, err := fromJSON.ImportJSON(bytes.NewReader(exported.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, w.MustLoadout(e), fromJSON.MustLoadout(e))
+	assert.Equal(t, w.MustTransform(e), fromJSON.MustTransform(e))
+
+	prev := w.Snapshot()
+	w.MustMutableTransform(e).Translation.Z = 9
+	delta := w.Diff(prev)
+	assert.Len(t, delta.Transform.Changed, 1)
+	assert.Empty(t, delta.Loadout.Changed)
+	assert.NoError(t, loaded.ApplyDelta(delta))
+	assert.Equal(t, float32(9), loaded.MustTransform(e).Translation.Z)
+
+	router := chi.NewRouter()
+	assert.NoError(t, ecs.SetupRoutes(context.Background(), w, router))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sparsesets/loadouts/", nil))
+	assert.Contains(t, rec.Body.String(), "Offset")
+	assert.Contains(t, rec.Body.String(), "right")
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	geckpb "github.com/delaneyj/geck/pb/gen/geck/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+type configFormat string
+
+const (
+	formatJSON      configFormat = "json"
+	formatYAML      configFormat = "yaml"
+	formatTextProto configFormat = "textproto"
+	formatBinary    configFormat = "binpb"
+)
+
+// formatOf picks the format from the flag, falling back to the extension
+func formatOf(path, flagValue string) (configFormat, error) {
+	if flagValue != "" {
+		switch f := configFormat(flagValue); f {
+		case formatJSON, formatYAML, formatTextProto, formatBinary:
+			return f, nil
+		default:
+			return "", fmt.Errorf("unknown format '%s', expected json, yaml, textproto or binpb", flagValue)
+		}
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		return formatJSON, nil
+	case ".yaml", ".yml":
+		return formatYAML, nil
+	case ".txtpb", ".textproto", ".textpb", ".pbtxt":
+		return formatTextProto, nil
+	case ".binpb", ".pb":
+		return formatBinary, nil
+	default:
+		return "", fmt.Errorf("cannot tell the format of '%s' from '%s', pass -format", path, ext)
+	}
+}
+
+// loadConfig reads the options, a relative folder path is resolved against the
+// config's directory so it does not depend on where geckgen runs
+func loadConfig(path, flagValue string) (*geckpb.GeneratorOptions, error) {
+	format, err := formatOf(path, flagValue)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	opts := &geckpb.GeneratorOptions{}
+	switch format {
+	case formatJSON:
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(b, opts)
+	case formatYAML:
+		// protojson owns the field names and enum spellings, YAML only has to
+		// turn into the same JSON
+		var doc any
+		if err = yaml.Unmarshal(b, &doc); err != nil {
+			break
+		}
+		var asJSON []byte
+		if asJSON, err = json.Marshal(doc); err != nil {
+			break
+		}
+		err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(asJSON, opts)
+	case formatTextProto:
+		err = prototext.Unmarshal(b, opts)
+	case formatBinary:
+		err = proto.Unmarshal(b, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s config '%s': %w", format, path, err)
+	}
+
+	if opts.FolderPath == "" {
+		return nil, fmt.Errorf("config '%s' has no folder path", path)
+	}
+	if !filepath.IsAbs(opts.FolderPath) {
+		opts.FolderPath = filepath.Join(filepath.Dir(path), opts.FolderPath)
+	}
+	if opts.PackageName == "" {
+		opts.PackageName = filepath.Base(opts.FolderPath)
+	}
+
+	return opts, nil
+}
+
+func marshalConfig(opts *geckpb.GeneratorOptions, format configFormat) ([]byte, error) {
+	jsonOpts := protojson.MarshalOptions{Multiline: true, Indent: "  "}
+	switch format {
+	case formatJSON:
+		return jsonOpts.Marshal(opts)
+	case formatYAML:
+		asJSON, err := jsonOpts.Marshal(opts)
+		if err != nil {
+			return nil, err
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(asJSON, &doc); err != nil {
+			return nil, err
+		}
+		// JSON is valid YAML, drop the flow style and the quotes so it is
+		// written as block YAML
+		var unflow func(n *yaml.Node)
+		unflow = func(n *yaml.Node) {
+			n.Style &^= yaml.FlowStyle
+			if n.Kind == yaml.ScalarNode && n.Tag == "!!str" && readsAsString(n.Value) {
+				n.Style &^= yaml.DoubleQuotedStyle
+			}
+			for _, c := range n.Content {
+				unflow(c)
+			}
+		}
+		unflow(&doc)
+		return yaml.Marshal(&doc)
+	case formatTextProto:
+		return prototext.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(opts)
+	default:
+		return proto.Marshal(opts)
+	}
+}
+
+// readsAsString is true when s needs no quotes to be read back as the same
+// string, "true" or "1" would not
+func readsAsString(s string) bool {
+	var v any
+	if err := yaml.Unmarshal([]byte(s), &v); err != nil {
+		return false
+	}
+	str, ok := v.(string)
+	return ok && str == s
+}
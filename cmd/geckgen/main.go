@@ -2,34 +2,336 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"io/fs"
 	"log"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/delaneyj/geck/generator"
 	geckpb "github.com/delaneyj/geck/pb/gen/geck/v1"
-	"google.golang.org/protobuf/encoding/protojson"
+	"github.com/pmezard/go-difflib/difflib"
 )
 
+const usage = `geckgen generates a bespoke ECS from a config file.
+
+Usage:
+
+	geckgen <command> [flags]
+
+Commands:
+
+	generate  write the ECS described by the config
+	validate  check the config without writing anything
+	init      scaffold a new config
+	diff      show what generate would change
+
+Configs can be JSON, YAML, textproto or binary protobuf GeneratorOptions, the
+format comes from the extension unless -format is given. Run geckgen <command>
+-h for the flags of a command. Without a command geckgen generates from
+./geckgen.json.
+`
+
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 	ctx := context.Background()
-	if err := run(ctx); err != nil {
-		panic(err)
+	if err := run(ctx, os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		args = []string{"generate"}
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "generate":
+		return runGenerate(ctx, args)
+	case "validate":
+		return runValidate(args, stdout)
+	case "init":
+		return runInit(args, stdout)
+	case "diff":
+		return runDiff(ctx, args, stdout)
+	case "help", "-h", "-help", "--help":
+		fmt.Fprint(stdout, usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command '%s'", cmd)
+	}
+}
+
+// configFlags are shared by every command reading a config
+type configFlags struct {
+	path, format string
+}
+
+func newFlagSet(name string, cf *configFlags) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.StringVar(&cf.path, "c", "geckgen.json", "path to the config")
+	flags.StringVar(&cf.format, "format", "", "config format: json, yaml, textproto or binpb, defaults to the extension")
+	return flags
+}
+
+func runGenerate(ctx context.Context, args []string) error {
+	cf := configFlags{}
+	flags := newFlagSet("generate", &cf)
+	force := flags.Bool("force", false, "overwrite files without the generated header, once, to take over a folder generated before headers")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts, err := loadConfig(cf.path, cf.format)
+	if err != nil {
+		return err
+	}
+
+	var buildOpts []generator.BuildOption
+	if *force {
+		buildOpts = append(buildOpts, generator.WithForce())
+	}
+	return located(cf.path, generator.BuildECS(ctx, opts, buildOpts...))
+}
+
+// located prefixes diagnostics with the config they were found in
+func located(path string, err error) error {
+	var diags generator.Diagnostics
+	if !errors.As(err, &diags) {
+		return err
+	}
+	lines := make([]string, len(diags))
+	for i, d := range diags {
+		lines[i] = fmt.Sprintf("%s: %s", path, d)
+	}
+	return fmt.Errorf("invalid config:\n%s", strings.Join(lines, "\n"))
+}
+
+func runValidate(args []string, stdout io.Writer) error {
+	cf := configFlags{}
+	flags := newFlagSet("validate", &cf)
+	asJSON := flags.Bool("json", false, "print the diagnostics as a JSON array, for editors")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts, err := loadConfig(cf.path, cf.format)
+	if err != nil {
+		return err
+	}
+
+	diags := generator.Validate(opts)
+	if *asJSON {
+		if diags == nil {
+			diags = generator.Diagnostics{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diags); err != nil {
+			return fmt.Errorf("failed to encode diagnostics: %w", err)
+		}
+	} else {
+		for _, d := range diags {
+			fmt.Fprintf(stdout, "%s: %s\n", cf.path, d)
+		}
+	}
+
+	if len(diags) > 0 {
+		return fmt.Errorf("'%s' has %d problems", cf.path, len(diags))
+	}
+	if !*asJSON {
+		fmt.Fprintf(stdout, "%s is valid\n", cf.path)
 	}
+	return nil
 }
 
-func run(ctx context.Context) error {
+func runInit(args []string, stdout io.Writer) error {
+	cf := configFlags{}
+	flags := newFlagSet("init", &cf)
+	folder := flags.String("folder", "./ecs", "folder the ECS is generated into, relative to the config")
+	force := flags.Bool("force", false, "overwrite an existing config")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := formatOf(cf.path, cf.format)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(cf.path); err == nil && !*force {
+		return fmt.Errorf("'%s' already exists, pass -force to overwrite it", cf.path)
+	}
+
+	b, err := marshalConfig(scaffold(*folder), format)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := os.WriteFile(cf.path, b, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	fmt.Fprintf(stdout, "wrote %s, run geckgen generate -c %s next\n", cf.path, cf.path)
+	return nil
+}
+
+// scaffold is a small config showing components, tags and queries
+func scaffold(folder string) *geckpb.GeneratorOptions {
+	f32 := func(name string) *geckpb.FieldDefinition {
+		return &geckpb.FieldDefinition{Name: name, ResetValue: &geckpb.FieldDefinition_F32{}}
+	}
+	return &geckpb.GeneratorOptions{
+		PackageName: filepath.Base(folder),
+		FolderPath:  folder,
+		Version:     1,
+		Bundles: []*geckpb.BundleDefinition{
+			{
+				Name: "game",
+				Components: []*geckpb.ComponentDefinition{
+					{Name: "Position", Fields: []*geckpb.FieldDefinition{f32("X"), f32("Y")}},
+					{Name: "Velocity", Fields: []*geckpb.FieldDefinition{f32("X"), f32("Y")}},
+					{Name: "Frozen"},
+				},
+			},
+		},
+		Queries: []*geckpb.QueryDefinition{
+			{
+				Alias: "Movable",
+				Entries: []*geckpb.QueryDefinition_ComponentOrTag{
+					{BundleName: "game", Name: "Position", IsMutable: true},
+					{BundleName: "game", Name: "Velocity"},
+					{BundleName: "game", Name: "Frozen", Operator: geckpb.QueryDefinition_OPERATOR_WITHOUT},
+				},
+			},
+		},
+	}
+}
+
+func runDiff(ctx context.Context, args []string, stdout io.Writer) error {
+	cf := configFlags{}
+	flags := newFlagSet("diff", &cf)
+	stat := flags.Bool("stat", false, "only list the files that would change")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts, err := loadConfig(cf.path, cf.format)
+	if err != nil {
+		return err
+	}
+	target := opts.FolderPath
 
-	opts := &geckpb.GeneratorOptions{}
-	b, err := os.ReadFile("./geckgen.json")
+	// rendering writes nothing and runs no post generation commands, so the
+	// preview cannot touch the module
+	rendered, err := generator.Render(opts)
 	if err != nil {
-		return fmt.Errorf("failed to read bundle definition: %w", err)
+		return located(cf.path, err)
 	}
+	after := make(map[string]string, len(rendered))
+	for name, contents := range rendered {
+		after[name] = string(contents)
+	}
+
+	// only files geckgen owns are compared, hand written files next to them
+	// are neither changed nor removed by generate
+	owned, err := generator.ReadManifest(target)
+	if err != nil {
+		return err
+	}
+	if owned == nil {
+		// generated before manifests, generate overwrites the same names
+		owned = slices.Collect(maps.Keys(after))
+	}
+
+	before, err := readFiles(target, owned)
+	if err != nil {
+		return err
+	}
+
+	changed := 0
+	for _, name := range mergedNames(before, after) {
+		old, hadOld := before[name]
+		next, hasNext := after[name]
+		if hadOld && hasNext && old == next {
+			continue
+		}
+		changed++
+
+		if *stat {
+			status := "M"
+			switch {
+			case !hadOld:
+				status = "A"
+			case !hasNext:
+				status = "D"
+			}
+			fmt.Fprintf(stdout, "%s %s\n", status, name)
+			continue
+		}
 
-	jsonOpts := protojson.UnmarshalOptions{DiscardUnknown: true}
-	if err = jsonOpts.Unmarshal(b, opts); err != nil {
-		return fmt.Errorf("failed to unmarshal bundle definition: %w", err)
+		fromFile, toFile := "a/"+name, "b/"+name
+		if !hadOld {
+			fromFile = "/dev/null"
+		}
+		if !hasNext {
+			toFile = "/dev/null"
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(old),
+			B:        difflib.SplitLines(next),
+			FromFile: fromFile,
+			ToFile:   toFile,
+			Context:  3,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diff '%s': %w", name, err)
+		}
+		fmt.Fprint(stdout, diff)
+	}
+
+	if changed == 0 {
+		fmt.Fprintf(stdout, "%s is up to date\n", target)
+	}
+	return nil
+}
+
+// readFiles reads the named files in root, missing ones are skipped
+func readFiles(root string, names []string) (map[string]string, error) {
+	files := map[string]string{}
+	for _, name := range names {
+		b, err := os.ReadFile(filepath.Join(root, name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s': %w", name, err)
+		}
+		files[name] = string(b)
+	}
+	return files, nil
+}
+
+func mergedNames(a, b map[string]string) []string {
+	names := make([]string, 0, len(a)+len(b))
+	for name := range a {
+		names = append(names, name)
+	}
+	for name := range b {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
 	}
-	return generator.BuildECS(ctx, opts)
+	slices.Sort(names)
+	return names
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/delaneyj/geck/generator"
+	geckpb "github.com/delaneyj/geck/pb/gen/geck/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfig writes opts as a JSON config in a temporary folder
+func writeConfig(t *testing.T, opts *geckpb.GeneratorOptions) string {
+	t.Helper()
+	b, err := marshalConfig(opts, formatJSON)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "geckgen.json")
+	require.NoError(t, os.WriteFile(path, b, 0644))
+	return path
+}
+
+func TestDiffIsReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	ran := filepath.Join(dir, "ran")
+	opts := scaffold(filepath.Join(dir, "ecs"))
+	opts.PostGenerateCommands = []string{"touch " + ran}
+	path := writeConfig(t, opts)
+
+	stdout := &bytes.Buffer{}
+	require.NoError(t, runDiff(t.Context(), []string{"-c", path, "-stat"}, stdout))
+	assert.Contains(t, stdout.String(), "A ecs_world.go\n")
+
+	// nothing is written and no post generation command runs
+	assert.NoFileExists(t, ran)
+	assert.NoDirExists(t, opts.FolderPath)
+}
+
+func TestValidate(t *testing.T) {
+	valid := writeConfig(t, scaffold("ecs"))
+	stdout := &bytes.Buffer{}
+	require.NoError(t, runValidate([]string{"-c", valid}, stdout))
+	assert.Equal(t, valid+" is valid\n", stdout.String())
+
+	stdout.Reset()
+	require.NoError(t, runValidate([]string{"-c", valid, "-json"}, stdout))
+	assert.JSONEq(t, "[]", stdout.String())
+
+	opts := scaffold("ecs")
+	opts.Bundles[0].Components[0].Name = "Snapshot"
+	opts.Bundles[0].Components[1].Fields[1].Name = "X"
+	invalid := writeConfig(t, opts)
+
+	stdout.Reset()
+	assert.Error(t, runValidate([]string{"-c", invalid}, stdout))
+	assert.Contains(t, stdout.String(), invalid+": bundles[0].components[0]: ")
+
+	stdout.Reset()
+	assert.Error(t, runValidate([]string{"-c", invalid, "-json"}, stdout))
+	var diags []generator.Diagnostic
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &diags))
+	located := make([]string, len(diags))
+	for i, d := range diags {
+		located[i] = d.Path + " " + string(d.Code)
+	}
+	assert.Equal(t, []string{
+		"bundles[0].components[0] reserved-name",
+		"bundles[0].components[1].fields[1] duplicate-name",
+		"queries[0].entries[0] unknown-reference",
+	}, located)
+}
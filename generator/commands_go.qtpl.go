@@ -0,0 +1,130 @@
+// Code generated by qtc from "commands_go.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// package generator
+//
+
+//line generator/commands_go.qtpl:3
+package generator
+
+//line generator/commands_go.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line generator/commands_go.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line generator/commands_go.qtpl:3
+func streamcommandsTemplate(qw422016 *qt422016.Writer, data *ecsTmplData) {
+//line generator/commands_go.qtpl:3
+	qw422016.N().S(`
+package `)
+//line generator/commands_go.qtpl:4
+	qw422016.E().S(data.PackageName)
+//line generator/commands_go.qtpl:4
+	qw422016.N().S(`
+
+import (
+    "slices"
+    "sync"
+)
+
+// CommandBuffer records structural changes so they can be applied at a sync
+// point instead of mutating sets that are being iterated. It is safe to record
+// from several goroutines.
+type CommandBuffer struct {
+    mu       sync.Mutex
+    commands []func(w *World)
+}
+
+func NewCommandBuffer() *CommandBuffer {
+    return &CommandBuffer{}
+}
+
+func (cb *CommandBuffer) record(cmd func(w *World)) {
+    cb.mu.Lock()
+    defer cb.mu.Unlock()
+    cb.commands = append(cb.commands, cmd)
+}
+
+func (cb *CommandBuffer) Len() int {
+    cb.mu.Lock()
+    defer cb.mu.Unlock()
+    return len(cb.commands)
+}
+
+func (cb *CommandBuffer) Clear() {
+    cb.mu.Lock()
+    defer cb.mu.Unlock()
+    cb.commands = nil
+}
+
+// Apply runs the recorded commands in order and clears them, commands recorded
+// while applying run before it returns
+func (cb *CommandBuffer) Apply(w *World) {
+    for {
+        cb.mu.Lock()
+        commands := cb.commands
+        cb.commands = nil
+        cb.mu.Unlock()
+
+        if len(commands) == 0 {
+            return
+        }
+        for _, cmd := range commands {
+            cmd(w)
+        }
+    }
+}
+
+func (cb *CommandBuffer) DestroyEntities(entities ...Entity) {
+    entities = slices.Clone(entities)
+    cb.record(func(w *World) {
+        w.DestroyEntities(entities...)
+    })
+}
+
+// Commands is the world's buffer, Tick flushes it after every system
+func (w *World) Commands() *CommandBuffer {
+    return w.commands
+}
+
+func (w *World) FlushCommands() {
+    w.commands.Apply(w)
+}
+
+`)
+//line generator/commands_go.qtpl:75
+}
+
+//line generator/commands_go.qtpl:75
+func writecommandsTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
+//line generator/commands_go.qtpl:75
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/commands_go.qtpl:75
+	streamcommandsTemplate(qw422016, data)
+//line generator/commands_go.qtpl:75
+	qt422016.ReleaseWriter(qw422016)
+//line generator/commands_go.qtpl:75
+}
+
+//line generator/commands_go.qtpl:75
+func commandsTemplate(data *ecsTmplData) string {
+//line generator/commands_go.qtpl:75
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/commands_go.qtpl:75
+	writecommandsTemplate(qb422016, data)
+//line generator/commands_go.qtpl:75
+	qs422016 := string(qb422016.B)
+//line generator/commands_go.qtpl:75
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/commands_go.qtpl:75
+	return qs422016
+//line generator/commands_go.qtpl:75
+}
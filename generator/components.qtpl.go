@@ -29,501 +29,753 @@ package `)
 	qw422016.E().S(data.PackageName)
 //line generator/components.qtpl:4
 	qw422016.N().S(`
+
+import (
+    "slices"
+
+    "github.com/delaneyj/geck/components/mathx"
 `)
-//line generator/components.qtpl:6
+//line generator/components.qtpl:10
 	if data.HasAnyEvents {
-//line generator/components.qtpl:6
-		qw422016.N().S(`import "github.com/btvoidx/mint"
+//line generator/components.qtpl:10
+		qw422016.N().S(`    "github.com/btvoidx/mint"
 `)
-//line generator/components.qtpl:8
+//line generator/components.qtpl:12
 	}
-//line generator/components.qtpl:8
-	qw422016.N().S(`
+//line generator/components.qtpl:12
+	qw422016.N().S(`)
+
 `)
-//line generator/components.qtpl:11
+//line generator/components.qtpl:16
 	npp := data.Name.Plural.Pascal
 	nsp := data.Name.Singular.Pascal
 	nsc := data.Name.Singular.Camel
 	ss := nsc + "Components"
 
-//line generator/components.qtpl:15
+//line generator/components.qtpl:20
 	qw422016.N().S(`
 type `)
-//line generator/components.qtpl:17
+//line generator/components.qtpl:22
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:17
+//line generator/components.qtpl:22
 	qw422016.N().S(`Component struct {
 `)
-//line generator/components.qtpl:18
+//line generator/components.qtpl:23
 	for _, f := range data.Fields {
-//line generator/components.qtpl:18
+//line generator/components.qtpl:24
+		if f.IsDeprecated {
+//line generator/components.qtpl:24
+			qw422016.N().S(`    // Deprecated: kept so older snapshots can still be read, it is not saved
+    // and the FromValues helpers leave it at its reset value
+`)
+//line generator/components.qtpl:27
+		}
+//line generator/components.qtpl:27
 		qw422016.N().S(`    `)
-//line generator/components.qtpl:19
+//line generator/components.qtpl:28
 		qw422016.E().S(f.Name.Singular.Pascal)
-//line generator/components.qtpl:19
+//line generator/components.qtpl:28
 		qw422016.N().S(` `)
-//line generator/components.qtpl:19
+//line generator/components.qtpl:28
 		qw422016.E().S(f.Type.Singular.Original)
-//line generator/components.qtpl:19
+//line generator/components.qtpl:28
 		qw422016.N().S(`
 `)
-//line generator/components.qtpl:20
+//line generator/components.qtpl:29
 	}
-//line generator/components.qtpl:20
+//line generator/components.qtpl:29
 	qw422016.N().S(`}
 
 func `)
-//line generator/components.qtpl:23
+//line generator/components.qtpl:32
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:23
+//line generator/components.qtpl:32
 	qw422016.N().S(`ComponentFromValues(
 `)
-//line generator/components.qtpl:24
-	for _, f := range data.Fields {
-//line generator/components.qtpl:24
+//line generator/components.qtpl:33
+	for _, f := range data.WritableFields() {
+//line generator/components.qtpl:33
 		qw422016.N().S(`    `)
-//line generator/components.qtpl:25
+//line generator/components.qtpl:34
 		qw422016.E().S(f.Name.Singular.Camel)
-//line generator/components.qtpl:25
+//line generator/components.qtpl:34
 		qw422016.N().S(`Arg `)
-//line generator/components.qtpl:25
+//line generator/components.qtpl:34
 		qw422016.E().S(f.Type.Singular.Original)
-//line generator/components.qtpl:25
+//line generator/components.qtpl:34
 		qw422016.N().S(`,
 `)
-//line generator/components.qtpl:26
+//line generator/components.qtpl:35
 	}
-//line generator/components.qtpl:26
+//line generator/components.qtpl:35
 	qw422016.N().S(`) `)
-//line generator/components.qtpl:27
+//line generator/components.qtpl:36
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:27
+//line generator/components.qtpl:36
 	qw422016.N().S(`Component {
     return `)
-//line generator/components.qtpl:28
+//line generator/components.qtpl:37
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:28
+//line generator/components.qtpl:37
 	qw422016.N().S(`Component{
 `)
-//line generator/components.qtpl:29
+//line generator/components.qtpl:38
 	for _, f := range data.Fields {
-//line generator/components.qtpl:29
+//line generator/components.qtpl:38
 		qw422016.N().S(`        `)
-//line generator/components.qtpl:30
+//line generator/components.qtpl:39
 		qw422016.E().S(f.Name.Singular.Pascal)
-//line generator/components.qtpl:30
+//line generator/components.qtpl:39
 		qw422016.N().S(`: `)
-//line generator/components.qtpl:30
-		qw422016.E().S(f.Name.Singular.Camel)
-//line generator/components.qtpl:30
-		qw422016.N().S(`Arg,
+//line generator/components.qtpl:39
+		qw422016.N().S(f.FromValuesArg())
+//line generator/components.qtpl:39
+		qw422016.N().S(`,
 `)
-//line generator/components.qtpl:31
+//line generator/components.qtpl:40
 	}
-//line generator/components.qtpl:31
+//line generator/components.qtpl:40
 	qw422016.N().S(`    }
 }
 
 func Default`)
-//line generator/components.qtpl:35
+//line generator/components.qtpl:44
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:35
+//line generator/components.qtpl:44
 	qw422016.N().S(`Component() `)
-//line generator/components.qtpl:35
+//line generator/components.qtpl:44
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:35
+//line generator/components.qtpl:44
 	qw422016.N().S(`Component {
     return `)
-//line generator/components.qtpl:36
+//line generator/components.qtpl:45
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:36
+//line generator/components.qtpl:45
 	qw422016.N().S(`Component{
 `)
-//line generator/components.qtpl:37
+//line generator/components.qtpl:46
 	for _, f := range data.Fields {
-//line generator/components.qtpl:37
+//line generator/components.qtpl:46
 		qw422016.N().S(`        `)
-//line generator/components.qtpl:38
+//line generator/components.qtpl:47
 		qw422016.E().S(f.Name.Singular.Pascal)
-//line generator/components.qtpl:38
+//line generator/components.qtpl:47
 		qw422016.N().S(`: `)
-//line generator/components.qtpl:38
+//line generator/components.qtpl:47
 		qw422016.N().S(f.ResetValue)
-//line generator/components.qtpl:38
+//line generator/components.qtpl:47
 		qw422016.N().S(`,
 `)
-//line generator/components.qtpl:39
+//line generator/components.qtpl:48
 	}
-//line generator/components.qtpl:39
+//line generator/components.qtpl:48
 	qw422016.N().S(`    }
 }
 
 func (c `)
-//line generator/components.qtpl:43
+//line generator/components.qtpl:52
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:43
+//line generator/components.qtpl:52
 	qw422016.N().S(`Component) Clone() `)
-//line generator/components.qtpl:43
+//line generator/components.qtpl:52
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:43
+//line generator/components.qtpl:52
 	qw422016.N().S(`Component {
     return `)
-//line generator/components.qtpl:44
+//line generator/components.qtpl:53
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:44
+//line generator/components.qtpl:53
 	qw422016.N().S(`Component{
 `)
-//line generator/components.qtpl:45
+//line generator/components.qtpl:54
 	for _, f := range data.Fields {
-//line generator/components.qtpl:45
+//line generator/components.qtpl:54
 		qw422016.N().S(`        `)
-//line generator/components.qtpl:46
-		qw422016.E().S(f.Name.Singular.Pascal)
-//line generator/components.qtpl:46
-		qw422016.N().S(`: c.`)
-//line generator/components.qtpl:46
+//line generator/components.qtpl:55
 		qw422016.E().S(f.Name.Singular.Pascal)
-//line generator/components.qtpl:46
+//line generator/components.qtpl:55
+		qw422016.N().S(`: `)
+//line generator/components.qtpl:55
+		qw422016.N().S(cloneField(f, "c."+f.Name.Singular.Pascal))
+//line generator/components.qtpl:55
 		qw422016.N().S(`,
 `)
-//line generator/components.qtpl:47
+//line generator/components.qtpl:56
 	}
-//line generator/components.qtpl:47
+//line generator/components.qtpl:56
 	qw422016.N().S(`    }
 }
 
 
 `)
-//line generator/components.qtpl:52
+//line generator/components.qtpl:61
 	if data.IsOnlyOneField {
-//line generator/components.qtpl:52
+//line generator/components.qtpl:61
 		qw422016.N().S(`    func (w *World) Set`)
-//line generator/components.qtpl:53
+//line generator/components.qtpl:62
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:53
+//line generator/components.qtpl:62
 		qw422016.N().S(`(e Entity, arg `)
-//line generator/components.qtpl:53
+//line generator/components.qtpl:62
 		qw422016.E().S(data.Fields[0].Type.Singular.Original)
-//line generator/components.qtpl:53
+//line generator/components.qtpl:62
 		qw422016.N().S(`) (old `)
-//line generator/components.qtpl:53
+//line generator/components.qtpl:62
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:53
+//line generator/components.qtpl:62
 		qw422016.N().S(`Component, wasAdded bool){
         c := `)
-//line generator/components.qtpl:54
+//line generator/components.qtpl:63
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:54
+//line generator/components.qtpl:63
 		qw422016.N().S(`Component{
             `)
-//line generator/components.qtpl:55
+//line generator/components.qtpl:64
 		qw422016.E().S(data.Fields[0].Name.Singular.Pascal)
-//line generator/components.qtpl:55
+//line generator/components.qtpl:64
 		qw422016.N().S(`: arg,
         }
 `)
-//line generator/components.qtpl:57
+//line generator/components.qtpl:66
 	} else {
-//line generator/components.qtpl:57
+//line generator/components.qtpl:66
 		qw422016.N().S(`    func (w *World) Set`)
-//line generator/components.qtpl:58
+//line generator/components.qtpl:67
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:58
+//line generator/components.qtpl:67
 		qw422016.N().S(`(e Entity, c `)
-//line generator/components.qtpl:58
+//line generator/components.qtpl:67
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:58
+//line generator/components.qtpl:67
 		qw422016.N().S(`Component) (old `)
-//line generator/components.qtpl:58
+//line generator/components.qtpl:67
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:58
+//line generator/components.qtpl:67
 		qw422016.N().S(`Component, wasAdded bool) {
 `)
-//line generator/components.qtpl:59
+//line generator/components.qtpl:68
+	}
+//line generator/components.qtpl:68
+	qw422016.N().S(`    if !w.IsAlive(e) {
+        return old, false
+    }
+`)
+//line generator/components.qtpl:72
+	if len(data.ObservedBy) > 0 {
+//line generator/components.qtpl:72
+		qw422016.N().S(`    `)
+//line generator/components.qtpl:73
+		streamqueryObservedBefore(qw422016, data, "e")
+//line generator/components.qtpl:73
+		qw422016.N().S(`
+`)
+//line generator/components.qtpl:74
 	}
-//line generator/components.qtpl:59
-	qw422016.N().S(`    old, wasAdded = w.`)
-//line generator/components.qtpl:60
+//line generator/components.qtpl:74
+	qw422016.N().S(`
+    old, wasAdded = w.`)
+//line generator/components.qtpl:76
 	qw422016.E().S(ss)
-//line generator/components.qtpl:60
+//line generator/components.qtpl:76
 	qw422016.N().S(`.Upsert(e, c);
 
     // depending on the generation flags, these might be unused
     _, _ = old, wasAdded
 
 `)
-//line generator/components.qtpl:65
+//line generator/components.qtpl:81
+	if data.OwnedBySet != nil {
+//line generator/components.qtpl:81
+		qw422016.N().S(`    if wasAdded {
+        w.`)
+//line generator/components.qtpl:83
+		qw422016.E().S(data.OwnedBySet.Name.Singular.Camel)
+//line generator/components.qtpl:83
+		qw422016.N().S(`GroupAdd(e)
+    }
+`)
+//line generator/components.qtpl:85
+	}
+//line generator/components.qtpl:86
+	if len(data.ObservedBy) > 0 {
+//line generator/components.qtpl:86
+		qw422016.N().S(`    `)
+//line generator/components.qtpl:87
+		streamqueryObservedAfter(qw422016, data, "e")
+//line generator/components.qtpl:87
+		qw422016.N().S(`
+`)
+//line generator/components.qtpl:88
+	}
+//line generator/components.qtpl:88
+	qw422016.N().S(`
+`)
+//line generator/components.qtpl:90
 	if data.ShouldGenAdded {
-//line generator/components.qtpl:65
+//line generator/components.qtpl:90
 		qw422016.N().S(`    if wasAdded {
         fireEvent(w, `)
-//line generator/components.qtpl:67
+//line generator/components.qtpl:92
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:67
+//line generator/components.qtpl:92
 		qw422016.N().S(`AddedEvent{Entity: e, Component: c})
     }
 `)
-//line generator/components.qtpl:69
+//line generator/components.qtpl:94
 	}
-//line generator/components.qtpl:70
+//line generator/components.qtpl:95
 	if data.ShouldGenChanged {
-//line generator/components.qtpl:70
+//line generator/components.qtpl:95
 		qw422016.N().S(`    fireEvent(w, `)
-//line generator/components.qtpl:71
+//line generator/components.qtpl:96
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:71
+//line generator/components.qtpl:96
 		qw422016.N().S(`ChangedEvent{Entity: e, Old: old, New: c})
 `)
-//line generator/components.qtpl:72
+//line generator/components.qtpl:97
 	}
-//line generator/components.qtpl:72
+//line generator/components.qtpl:97
 	qw422016.N().S(`
     return old, wasAdded
 }
 
 `)
-//line generator/components.qtpl:77
+//line generator/components.qtpl:102
 	if !data.IsOnlyOneField {
-//line generator/components.qtpl:77
+//line generator/components.qtpl:102
 		qw422016.N().S(`
 func (w *World) Set`)
-//line generator/components.qtpl:78
+//line generator/components.qtpl:103
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:78
+//line generator/components.qtpl:103
 		qw422016.N().S(`FromValues(
     e Entity,
 `)
-//line generator/components.qtpl:80
-		for _, f := range data.Fields {
-//line generator/components.qtpl:80
+//line generator/components.qtpl:105
+		for _, f := range data.WritableFields() {
+//line generator/components.qtpl:105
 			qw422016.N().S(`    `)
-//line generator/components.qtpl:81
+//line generator/components.qtpl:106
 			qw422016.E().S(f.Name.Singular.Camel)
-//line generator/components.qtpl:81
+//line generator/components.qtpl:106
 			qw422016.N().S(`Arg `)
-//line generator/components.qtpl:81
+//line generator/components.qtpl:106
 			qw422016.E().S(f.Type.Singular.Original)
-//line generator/components.qtpl:81
+//line generator/components.qtpl:106
 			qw422016.N().S(`,
 `)
-//line generator/components.qtpl:82
+//line generator/components.qtpl:107
 		}
-//line generator/components.qtpl:82
+//line generator/components.qtpl:107
 		qw422016.N().S(`) {
     old, _ := w.Set`)
-//line generator/components.qtpl:84
+//line generator/components.qtpl:109
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:84
+//line generator/components.qtpl:109
 		qw422016.N().S(`(e, `)
-//line generator/components.qtpl:84
+//line generator/components.qtpl:109
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:84
+//line generator/components.qtpl:109
 		qw422016.N().S(`Component{
 `)
-//line generator/components.qtpl:85
+//line generator/components.qtpl:110
 		for _, f := range data.Fields {
-//line generator/components.qtpl:85
+//line generator/components.qtpl:110
 			qw422016.N().S(`        `)
-//line generator/components.qtpl:86
+//line generator/components.qtpl:111
 			qw422016.E().S(f.Name.Singular.Pascal)
-//line generator/components.qtpl:86
+//line generator/components.qtpl:111
 			qw422016.N().S(`: `)
-//line generator/components.qtpl:86
-			qw422016.E().S(f.Name.Singular.Camel)
-//line generator/components.qtpl:86
-			qw422016.N().S(`Arg,
+//line generator/components.qtpl:111
+			qw422016.N().S(f.FromValuesArg())
+//line generator/components.qtpl:111
+			qw422016.N().S(`,
 `)
-//line generator/components.qtpl:87
+//line generator/components.qtpl:112
 		}
-//line generator/components.qtpl:87
+//line generator/components.qtpl:112
 		qw422016.N().S(`    })
 
     // depending on the generation flags, these might be unused
     _ = old
 
 `)
-//line generator/components.qtpl:93
+//line generator/components.qtpl:118
 		if data.ShouldGenChanged {
-//line generator/components.qtpl:93
+//line generator/components.qtpl:118
 			qw422016.N().S(`    fireEvent(w, `)
-//line generator/components.qtpl:94
+//line generator/components.qtpl:119
 			qw422016.E().S(nsp)
-//line generator/components.qtpl:94
+//line generator/components.qtpl:119
 			qw422016.N().S(`ChangedEvent{Entity: e, Old: old, New: w.Must`)
-//line generator/components.qtpl:94
+//line generator/components.qtpl:119
 			qw422016.E().S(nsp)
-//line generator/components.qtpl:94
+//line generator/components.qtpl:119
 			qw422016.N().S(`(e)})
 `)
-//line generator/components.qtpl:95
+//line generator/components.qtpl:120
 		}
-//line generator/components.qtpl:95
+//line generator/components.qtpl:120
 		qw422016.N().S(`}
 `)
-//line generator/components.qtpl:97
+//line generator/components.qtpl:122
 	}
-//line generator/components.qtpl:97
+//line generator/components.qtpl:122
 	qw422016.N().S(`
+`)
+//line generator/components.qtpl:124
+	if data.IsInheritable() {
+//line generator/components.qtpl:124
+		qw422016.N().S(`// `)
+//line generator/components.qtpl:125
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:125
+		qw422016.N().S(` falls back to e's IsA prefabs when e does not own one
 func (w *World) `)
-//line generator/components.qtpl:99
-	qw422016.E().S(nsp)
-//line generator/components.qtpl:99
-	qw422016.N().S(`(e Entity) (c `)
-//line generator/components.qtpl:99
-	qw422016.E().S(nsp)
-//line generator/components.qtpl:99
-	qw422016.N().S(`Component, ok bool) {
-    return w.`)
-//line generator/components.qtpl:100
-	qw422016.E().S(ss)
-//line generator/components.qtpl:100
-	qw422016.N().S(`.Data(e)
+//line generator/components.qtpl:126
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:126
+		qw422016.N().S(`(e Entity) (c `)
+//line generator/components.qtpl:126
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:126
+		qw422016.N().S(`Component, ok bool) {
+    if c, ok = w.`)
+//line generator/components.qtpl:127
+		qw422016.E().S(ss)
+//line generator/components.qtpl:127
+		qw422016.N().S(`.Data(e); ok {
+        return c, ok
+    }
+    return inherit(w, w.`)
+//line generator/components.qtpl:130
+		qw422016.E().S(ss)
+//line generator/components.qtpl:130
+		qw422016.N().S(`, e)
 }
 
+// Mutable`)
+//line generator/components.qtpl:133
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:133
+		qw422016.N().S(` gives e its own copy first when it is inherited
 func (w *World) Mutable`)
-//line generator/components.qtpl:103
-	qw422016.E().S(nsp)
-//line generator/components.qtpl:103
-	qw422016.N().S(`(e Entity) (c *`)
-//line generator/components.qtpl:103
-	qw422016.E().S(nsp)
-//line generator/components.qtpl:103
-	qw422016.N().S(`Component, ok bool) {
+//line generator/components.qtpl:134
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:134
+		qw422016.N().S(`(e Entity) (c *`)
+//line generator/components.qtpl:134
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:134
+		qw422016.N().S(`Component, ok bool) {
+    if c, ok = w.`)
+//line generator/components.qtpl:135
+		qw422016.E().S(ss)
+//line generator/components.qtpl:135
+		qw422016.N().S(`.DataMutable(e); ok {
+        return c, ok
+    }
+    inherited, ok := inherit(w, w.`)
+//line generator/components.qtpl:138
+		qw422016.E().S(ss)
+//line generator/components.qtpl:138
+		qw422016.N().S(`, e)
+    if !ok {
+        return nil, false
+    }
+`)
+//line generator/components.qtpl:142
+		if data.IsOnlyOneField {
+//line generator/components.qtpl:142
+			qw422016.N().S(`    w.Set`)
+//line generator/components.qtpl:143
+			qw422016.E().S(nsp)
+//line generator/components.qtpl:143
+			qw422016.N().S(`(e, inherited.Clone().`)
+//line generator/components.qtpl:143
+			qw422016.E().S(data.Fields[0].Name.Singular.Pascal)
+//line generator/components.qtpl:143
+			qw422016.N().S(`)
+`)
+//line generator/components.qtpl:144
+		} else {
+//line generator/components.qtpl:144
+			qw422016.N().S(`    w.Set`)
+//line generator/components.qtpl:145
+			qw422016.E().S(nsp)
+//line generator/components.qtpl:145
+			qw422016.N().S(`(e, inherited.Clone())
+`)
+//line generator/components.qtpl:146
+		}
+//line generator/components.qtpl:146
+		qw422016.N().S(`    return w.`)
+//line generator/components.qtpl:147
+		qw422016.E().S(ss)
+//line generator/components.qtpl:147
+		qw422016.N().S(`.DataMutable(e)
+}
+`)
+//line generator/components.qtpl:149
+	} else {
+//line generator/components.qtpl:149
+		qw422016.N().S(`func (w *World) `)
+//line generator/components.qtpl:150
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:150
+		qw422016.N().S(`(e Entity) (c `)
+//line generator/components.qtpl:150
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:150
+		qw422016.N().S(`Component, ok bool) {
     return w.`)
-//line generator/components.qtpl:104
-	qw422016.E().S(ss)
-//line generator/components.qtpl:104
-	qw422016.N().S(`.DataMutable(e)
+//line generator/components.qtpl:151
+		qw422016.E().S(ss)
+//line generator/components.qtpl:151
+		qw422016.N().S(`.Data(e)
 }
 
+func (w *World) Mutable`)
+//line generator/components.qtpl:154
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:154
+		qw422016.N().S(`(e Entity) (c *`)
+//line generator/components.qtpl:154
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:154
+		qw422016.N().S(`Component, ok bool) {
+    return w.`)
+//line generator/components.qtpl:155
+		qw422016.E().S(ss)
+//line generator/components.qtpl:155
+		qw422016.N().S(`.DataMutable(e)
+}
+`)
+//line generator/components.qtpl:157
+	}
+//line generator/components.qtpl:157
+	qw422016.N().S(`
 func (w *World) MustMutable`)
-//line generator/components.qtpl:107
+//line generator/components.qtpl:159
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:107
+//line generator/components.qtpl:159
 	qw422016.N().S(`(e Entity) *`)
-//line generator/components.qtpl:107
+//line generator/components.qtpl:159
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:107
+//line generator/components.qtpl:159
 	qw422016.N().S(`Component {
     c, ok := w.Mutable`)
-//line generator/components.qtpl:108
+//line generator/components.qtpl:160
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:108
+//line generator/components.qtpl:160
 	qw422016.N().S(`(e)
     if !ok {
         panic("entity does not have `)
-//line generator/components.qtpl:110
+//line generator/components.qtpl:162
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:110
+//line generator/components.qtpl:162
 	qw422016.N().S(`")
     }
     return c
 }
 
 func (w *World) Must`)
-//line generator/components.qtpl:115
+//line generator/components.qtpl:167
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:115
+//line generator/components.qtpl:167
 	qw422016.N().S(`(e Entity) `)
-//line generator/components.qtpl:115
+//line generator/components.qtpl:167
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:115
+//line generator/components.qtpl:167
 	qw422016.N().S(`Component {
     c, ok := w.`)
-//line generator/components.qtpl:116
-	qw422016.E().S(ss)
-//line generator/components.qtpl:116
-	qw422016.N().S(`.Data(e)
+//line generator/components.qtpl:168
+	qw422016.E().S(nsp)
+//line generator/components.qtpl:168
+	qw422016.N().S(`(e)
     if !ok {
         panic("entity does not have `)
-//line generator/components.qtpl:118
+//line generator/components.qtpl:170
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:118
+//line generator/components.qtpl:170
 	qw422016.N().S(`")
     }
     return c
 }
 
 func (w *World) Remove`)
-//line generator/components.qtpl:123
+//line generator/components.qtpl:175
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:123
+//line generator/components.qtpl:175
 	qw422016.N().S(`(e Entity) {
-    wasRemoved := w.`)
-//line generator/components.qtpl:124
+`)
+//line generator/components.qtpl:176
+	if len(data.ObservedBy) > 0 {
+//line generator/components.qtpl:176
+		qw422016.N().S(`    `)
+//line generator/components.qtpl:177
+		streamqueryObservedBefore(qw422016, data, "e")
+//line generator/components.qtpl:177
+		qw422016.N().S(`
+`)
+//line generator/components.qtpl:178
+	}
+//line generator/components.qtpl:179
+	if data.OwnedBySet != nil {
+//line generator/components.qtpl:179
+		qw422016.N().S(`    w.`)
+//line generator/components.qtpl:180
+		qw422016.E().S(data.OwnedBySet.Name.Singular.Camel)
+//line generator/components.qtpl:180
+		qw422016.N().S(`GroupRemove(e)
+`)
+//line generator/components.qtpl:181
+	}
+//line generator/components.qtpl:181
+	qw422016.N().S(`    wasRemoved := w.`)
+//line generator/components.qtpl:182
 	qw422016.E().S(ss)
-//line generator/components.qtpl:124
+//line generator/components.qtpl:182
 	qw422016.N().S(`.Remove(e)
 
     // depending on the generation flags, these might be unused
     _ = wasRemoved
-
 `)
-//line generator/components.qtpl:129
+//line generator/components.qtpl:186
+	if len(data.ObservedBy) > 0 {
+//line generator/components.qtpl:186
+		qw422016.N().S(`    `)
+//line generator/components.qtpl:187
+		streamqueryObservedAfter(qw422016, data, "e")
+//line generator/components.qtpl:187
+		qw422016.N().S(`
+`)
+//line generator/components.qtpl:188
+	}
+//line generator/components.qtpl:188
+	qw422016.N().S(`
+`)
+//line generator/components.qtpl:190
 	if data.ShouldGenRemoved {
-//line generator/components.qtpl:129
+//line generator/components.qtpl:190
 		qw422016.N().S(`    if wasRemoved {
         fireEvent(w, `)
-//line generator/components.qtpl:131
+//line generator/components.qtpl:192
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:131
+//line generator/components.qtpl:192
 		qw422016.N().S(`RemovedEvent{Entity: e})
     }
 `)
-//line generator/components.qtpl:133
+//line generator/components.qtpl:194
 	}
-//line generator/components.qtpl:133
+//line generator/components.qtpl:194
 	qw422016.N().S(`}
 
-func (w *World) Has`)
-//line generator/components.qtpl:136
+`)
+//line generator/components.qtpl:197
+	if data.IsInheritable() {
+//line generator/components.qtpl:197
+		qw422016.N().S(`// Has`)
+//line generator/components.qtpl:198
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:198
+		qw422016.N().S(` is only true when e owns the component, inherited ones are
+// not counted
+`)
+//line generator/components.qtpl:200
+	}
+//line generator/components.qtpl:200
+	qw422016.N().S(`func (w *World) Has`)
+//line generator/components.qtpl:201
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:136
+//line generator/components.qtpl:201
 	qw422016.N().S(`(e Entity) bool {
     return w.`)
-//line generator/components.qtpl:137
-	qw422016.E().S(ss)
-//line generator/components.qtpl:137
-	qw422016.N().S(`.Contains(e)
+//line generator/components.qtpl:202
+	qw422016.E().S(ss)
+//line generator/components.qtpl:202
+	qw422016.N().S(`.Contains(e)
+}
+
+`)
+//line generator/components.qtpl:205
+	if data.ShouldTrackChanges {
+//line generator/components.qtpl:205
+		qw422016.N().S(`// `)
+//line generator/components.qtpl:206
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:206
+		qw422016.N().S(`ChangedSince is true when e's `)
+//line generator/components.qtpl:206
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:206
+		qw422016.N().S(` was set, added or mutably
+// accessed after the given change tick
+func (w *World) `)
+//line generator/components.qtpl:208
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:208
+		qw422016.N().S(`ChangedSince(e Entity, since uint64) bool {
+    return w.`)
+//line generator/components.qtpl:209
+		qw422016.E().S(ss)
+//line generator/components.qtpl:209
+		qw422016.N().S(`.changedSince(e, since)
+}
+
+func (w *World) `)
+//line generator/components.qtpl:212
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:212
+		qw422016.N().S(`AddedSince(e Entity, since uint64) bool {
+    return w.`)
+//line generator/components.qtpl:213
+		qw422016.E().S(ss)
+//line generator/components.qtpl:213
+		qw422016.N().S(`.addedSince(e, since)
 }
-
+`)
+//line generator/components.qtpl:215
+	}
+//line generator/components.qtpl:215
+	qw422016.N().S(`
 func (w *World) `)
-//line generator/components.qtpl:140
+//line generator/components.qtpl:217
 	qw422016.E().S(npp)
-//line generator/components.qtpl:140
+//line generator/components.qtpl:217
 	qw422016.N().S(`Count() int {
     return w.`)
-//line generator/components.qtpl:141
+//line generator/components.qtpl:218
 	qw422016.E().S(ss)
-//line generator/components.qtpl:141
+//line generator/components.qtpl:218
 	qw422016.N().S(`.Len()
 }
 
 func (w *World) `)
-//line generator/components.qtpl:144
+//line generator/components.qtpl:221
 	qw422016.E().S(npp)
-//line generator/components.qtpl:144
+//line generator/components.qtpl:221
 	qw422016.N().S(`Capacity() int {
     return w.`)
-//line generator/components.qtpl:145
+//line generator/components.qtpl:222
 	qw422016.E().S(ss)
-//line generator/components.qtpl:145
+//line generator/components.qtpl:222
 	qw422016.N().S(`.Cap()
 }
 
 func (w *World) All`)
-//line generator/components.qtpl:148
+//line generator/components.qtpl:225
 	qw422016.E().S(npp)
-//line generator/components.qtpl:148
+//line generator/components.qtpl:225
 	qw422016.N().S(`(yield func(e Entity, c `)
-//line generator/components.qtpl:148
+//line generator/components.qtpl:225
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:148
+//line generator/components.qtpl:225
 	qw422016.N().S(`Component) bool) {
     for e, c := range w.`)
-//line generator/components.qtpl:149
+//line generator/components.qtpl:226
 	qw422016.E().S(ss)
-//line generator/components.qtpl:149
+//line generator/components.qtpl:226
 	qw422016.N().S(`.All {
         if !yield(e, c) {
             break
@@ -532,18 +784,18 @@ func (w *World) All`)
 }
 
 func (w *World) AllMutable`)
-//line generator/components.qtpl:156
+//line generator/components.qtpl:233
 	qw422016.E().S(npp)
-//line generator/components.qtpl:156
+//line generator/components.qtpl:233
 	qw422016.N().S(`(yield func(e Entity, c *`)
-//line generator/components.qtpl:156
+//line generator/components.qtpl:233
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:156
+//line generator/components.qtpl:233
 	qw422016.N().S(`Component) bool) {
     for e, c := range w.`)
-//line generator/components.qtpl:157
+//line generator/components.qtpl:234
 	qw422016.E().S(ss)
-//line generator/components.qtpl:157
+//line generator/components.qtpl:234
 	qw422016.N().S(`.AllMutable {
         if !yield(e, c) {
             break
@@ -552,14 +804,14 @@ func (w *World) AllMutable`)
 }
 
 func (w *World) All`)
-//line generator/components.qtpl:164
+//line generator/components.qtpl:241
 	qw422016.E().S(npp)
-//line generator/components.qtpl:164
+//line generator/components.qtpl:241
 	qw422016.N().S(`Entities(yield func(e Entity) bool) {
     for e := range w.`)
-//line generator/components.qtpl:165
+//line generator/components.qtpl:242
 	qw422016.E().S(ss)
-//line generator/components.qtpl:165
+//line generator/components.qtpl:242
 	qw422016.N().S(`.AllEntities {
         if !yield(e) {
             break
@@ -568,193 +820,214 @@ func (w *World) All`)
 }
 
 func (w *World) AllMutable`)
-//line generator/components.qtpl:172
+//line generator/components.qtpl:249
 	qw422016.E().S(npp)
-//line generator/components.qtpl:172
+//line generator/components.qtpl:249
 	qw422016.N().S(`Entities(yield func(e Entity) bool) {
     w.All`)
-//line generator/components.qtpl:173
+//line generator/components.qtpl:250
 	qw422016.E().S(npp)
-//line generator/components.qtpl:173
+//line generator/components.qtpl:250
 	qw422016.N().S(`Entities(yield)
 }
 
 // `)
-//line generator/components.qtpl:176
+//line generator/components.qtpl:253
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:176
+//line generator/components.qtpl:253
 	qw422016.N().S(`Builder
 func With`)
-//line generator/components.qtpl:177
+//line generator/components.qtpl:254
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:177
+//line generator/components.qtpl:254
 	qw422016.N().S(`Default() EntityBuilderOption {
 `)
-//line generator/components.qtpl:178
+//line generator/components.qtpl:255
 	if data.IsOnlyOneField {
-//line generator/components.qtpl:178
+//line generator/components.qtpl:255
 		qw422016.N().S(`    return With`)
-//line generator/components.qtpl:179
+//line generator/components.qtpl:256
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:179
+//line generator/components.qtpl:256
 		qw422016.N().S(`(Default`)
-//line generator/components.qtpl:179
+//line generator/components.qtpl:256
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:179
+//line generator/components.qtpl:256
 		qw422016.N().S(`Component().`)
-//line generator/components.qtpl:179
+//line generator/components.qtpl:256
 		qw422016.E().S(data.Fields[0].Name.Singular.Pascal)
-//line generator/components.qtpl:179
+//line generator/components.qtpl:256
 		qw422016.N().S(`)
 `)
-//line generator/components.qtpl:180
+//line generator/components.qtpl:257
 	} else {
-//line generator/components.qtpl:180
+//line generator/components.qtpl:257
 		qw422016.N().S(`    return With`)
-//line generator/components.qtpl:181
+//line generator/components.qtpl:258
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:181
+//line generator/components.qtpl:258
 		qw422016.N().S(`(Default`)
-//line generator/components.qtpl:181
+//line generator/components.qtpl:258
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:181
+//line generator/components.qtpl:258
 		qw422016.N().S(`Component())
 `)
-//line generator/components.qtpl:182
+//line generator/components.qtpl:259
 	}
-//line generator/components.qtpl:182
+//line generator/components.qtpl:259
 	qw422016.N().S(`}
 
 `)
-//line generator/components.qtpl:185
+//line generator/components.qtpl:262
 	if data.IsOnlyOneField {
-//line generator/components.qtpl:185
+//line generator/components.qtpl:262
 		qw422016.N().S(`func With`)
-//line generator/components.qtpl:186
+//line generator/components.qtpl:263
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:186
+//line generator/components.qtpl:263
 		qw422016.N().S(`(arg `)
-//line generator/components.qtpl:186
+//line generator/components.qtpl:263
 		qw422016.E().S(data.Fields[0].Type.Singular.Original)
-//line generator/components.qtpl:186
+//line generator/components.qtpl:263
 		qw422016.N().S(`) EntityBuilderOption {
     c := `)
-//line generator/components.qtpl:187
+//line generator/components.qtpl:264
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:187
+//line generator/components.qtpl:264
 		qw422016.N().S(`Component{
         `)
-//line generator/components.qtpl:188
+//line generator/components.qtpl:265
 		qw422016.E().S(data.Fields[0].Name.Singular.Pascal)
-//line generator/components.qtpl:188
+//line generator/components.qtpl:265
 		qw422016.N().S(`: arg,
     }
 `)
-//line generator/components.qtpl:190
+//line generator/components.qtpl:267
 	} else {
-//line generator/components.qtpl:190
+//line generator/components.qtpl:267
 		qw422016.N().S(`func With`)
-//line generator/components.qtpl:191
+//line generator/components.qtpl:268
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:191
+//line generator/components.qtpl:268
 		qw422016.N().S(`(c `)
-//line generator/components.qtpl:191
+//line generator/components.qtpl:268
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:191
+//line generator/components.qtpl:268
 		qw422016.N().S(`Component) EntityBuilderOption {
 `)
-//line generator/components.qtpl:192
+//line generator/components.qtpl:269
 	}
-//line generator/components.qtpl:192
+//line generator/components.qtpl:269
 	qw422016.N().S(`    return func(w *World, e Entity) {
-        w.`)
-//line generator/components.qtpl:194
-	qw422016.E().S(ss)
-//line generator/components.qtpl:194
-	qw422016.N().S(`.Upsert(e, c)
-    }
+`)
+//line generator/components.qtpl:271
+	if data.IsOnlyOneField {
+//line generator/components.qtpl:271
+		qw422016.N().S(`        w.Set`)
+//line generator/components.qtpl:272
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:272
+		qw422016.N().S(`(e, c.`)
+//line generator/components.qtpl:272
+		qw422016.E().S(data.Fields[0].Name.Singular.Pascal)
+//line generator/components.qtpl:272
+		qw422016.N().S(`)
+`)
+//line generator/components.qtpl:273
+	} else {
+//line generator/components.qtpl:273
+		qw422016.N().S(`        w.Set`)
+//line generator/components.qtpl:274
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:274
+		qw422016.N().S(`(e, c)
+`)
+//line generator/components.qtpl:275
+	}
+//line generator/components.qtpl:275
+	qw422016.N().S(`    }
 }
 
 `)
-//line generator/components.qtpl:198
+//line generator/components.qtpl:279
 	if !data.IsOnlyOneField {
-//line generator/components.qtpl:198
+//line generator/components.qtpl:279
 		qw422016.N().S(`func With`)
-//line generator/components.qtpl:199
+//line generator/components.qtpl:280
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:199
+//line generator/components.qtpl:280
 		qw422016.N().S(`FromValues(
 `)
-//line generator/components.qtpl:200
-		for _, f := range data.Fields {
-//line generator/components.qtpl:200
+//line generator/components.qtpl:281
+		for _, f := range data.WritableFields() {
+//line generator/components.qtpl:281
 			qw422016.N().S(`    `)
-//line generator/components.qtpl:201
+//line generator/components.qtpl:282
 			qw422016.E().S(f.Name.Singular.Camel)
-//line generator/components.qtpl:201
+//line generator/components.qtpl:282
 			qw422016.N().S(`Arg `)
-//line generator/components.qtpl:201
+//line generator/components.qtpl:282
 			qw422016.E().S(f.Type.Singular.Original)
-//line generator/components.qtpl:201
+//line generator/components.qtpl:282
 			qw422016.N().S(`,
 `)
-//line generator/components.qtpl:202
+//line generator/components.qtpl:283
 		}
-//line generator/components.qtpl:202
+//line generator/components.qtpl:283
 		qw422016.N().S(`) EntityBuilderOption {
     return func(w *World, e Entity) {
         w.Set`)
-//line generator/components.qtpl:205
+//line generator/components.qtpl:286
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:205
+//line generator/components.qtpl:286
 		qw422016.N().S(`FromValues(e,
 `)
-//line generator/components.qtpl:206
-		for _, f := range data.Fields {
-//line generator/components.qtpl:206
+//line generator/components.qtpl:287
+		for _, f := range data.WritableFields() {
+//line generator/components.qtpl:287
 			qw422016.N().S(`            `)
-//line generator/components.qtpl:207
+//line generator/components.qtpl:288
 			qw422016.E().S(f.Name.Singular.Camel)
-//line generator/components.qtpl:207
+//line generator/components.qtpl:288
 			qw422016.N().S(`Arg,
 `)
-//line generator/components.qtpl:208
+//line generator/components.qtpl:289
 		}
-//line generator/components.qtpl:208
+//line generator/components.qtpl:289
 		qw422016.N().S(`        )
     }
 }
 `)
-//line generator/components.qtpl:212
+//line generator/components.qtpl:293
 	}
-//line generator/components.qtpl:212
+//line generator/components.qtpl:293
 	qw422016.N().S(`
 
 // Events
 `)
-//line generator/components.qtpl:216
+//line generator/components.qtpl:297
 	if data.ShouldGenAdded {
-//line generator/components.qtpl:216
+//line generator/components.qtpl:297
 		qw422016.N().S(`type `)
-//line generator/components.qtpl:217
+//line generator/components.qtpl:298
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:217
+//line generator/components.qtpl:298
 		qw422016.N().S(`AddedEvent struct {
     Entity Entity
     Component `)
-//line generator/components.qtpl:219
+//line generator/components.qtpl:300
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:219
+//line generator/components.qtpl:300
 		qw422016.N().S(`Component
 }
 func (w *World) On`)
-//line generator/components.qtpl:221
+//line generator/components.qtpl:302
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:221
+//line generator/components.qtpl:302
 		qw422016.N().S(`Added(fn func(evt `)
-//line generator/components.qtpl:221
+//line generator/components.qtpl:302
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:221
+//line generator/components.qtpl:302
 		qw422016.N().S(`AddedEvent)) UnsubscribeFunc {
     unsub := mint.On(w.eventBus, fn)
     return func() {
@@ -762,34 +1035,34 @@ func (w *World) On`)
     }
 }
 `)
-//line generator/components.qtpl:227
+//line generator/components.qtpl:308
 	}
-//line generator/components.qtpl:227
+//line generator/components.qtpl:308
 	qw422016.N().S(`
 `)
-//line generator/components.qtpl:229
+//line generator/components.qtpl:310
 	if data.ShouldGenRemoved {
-//line generator/components.qtpl:229
+//line generator/components.qtpl:310
 		qw422016.N().S(`type `)
-//line generator/components.qtpl:230
+//line generator/components.qtpl:311
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:230
+//line generator/components.qtpl:311
 		qw422016.N().S(`RemovedEvent struct {
     Entity Entity
     Component `)
-//line generator/components.qtpl:232
+//line generator/components.qtpl:313
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:232
+//line generator/components.qtpl:313
 		qw422016.N().S(`Component
 }
 func (w *World) On`)
-//line generator/components.qtpl:234
+//line generator/components.qtpl:315
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:234
+//line generator/components.qtpl:315
 		qw422016.N().S(`Removed(fn func(evt `)
-//line generator/components.qtpl:234
+//line generator/components.qtpl:315
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:234
+//line generator/components.qtpl:315
 		qw422016.N().S(`RemovedEvent)) UnsubscribeFunc {
     unsub := mint.On(w.eventBus, fn)
     return func() {
@@ -797,34 +1070,34 @@ func (w *World) On`)
     }
 }
 `)
-//line generator/components.qtpl:240
+//line generator/components.qtpl:321
 	}
-//line generator/components.qtpl:240
+//line generator/components.qtpl:321
 	qw422016.N().S(`
 `)
-//line generator/components.qtpl:242
+//line generator/components.qtpl:323
 	if data.ShouldGenChanged {
-//line generator/components.qtpl:242
+//line generator/components.qtpl:323
 		qw422016.N().S(`type `)
-//line generator/components.qtpl:243
+//line generator/components.qtpl:324
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:243
+//line generator/components.qtpl:324
 		qw422016.N().S(`ChangedEvent struct {
     Entity Entity
     Old, New `)
-//line generator/components.qtpl:245
+//line generator/components.qtpl:326
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:245
+//line generator/components.qtpl:326
 		qw422016.N().S(`Component
 }
 func (w *World) On`)
-//line generator/components.qtpl:247
+//line generator/components.qtpl:328
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:247
+//line generator/components.qtpl:328
 		qw422016.N().S(`Changed(fn func(evt `)
-//line generator/components.qtpl:247
+//line generator/components.qtpl:328
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:247
+//line generator/components.qtpl:328
 		qw422016.N().S(`ChangedEvent)) UnsubscribeFunc {
 	unsub := mint.On(w.eventBus, fn)
 	return func() {
@@ -832,205 +1105,320 @@ func (w *World) On`)
 	}
 }
 `)
-//line generator/components.qtpl:253
+//line generator/components.qtpl:334
 	}
-//line generator/components.qtpl:253
+//line generator/components.qtpl:334
 	qw422016.N().S(`
 // Resource methods
 `)
-//line generator/components.qtpl:256
+//line generator/components.qtpl:337
 	if data.IsOnlyOneField {
-//line generator/components.qtpl:256
+//line generator/components.qtpl:337
 		qw422016.N().S(`func (w *World) Set`)
-//line generator/components.qtpl:257
+//line generator/components.qtpl:338
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:257
+//line generator/components.qtpl:338
 		qw422016.N().S(`Resource(arg `)
-//line generator/components.qtpl:257
+//line generator/components.qtpl:338
 		qw422016.E().S(data.Fields[0].Type.Singular.Original)
-//line generator/components.qtpl:257
+//line generator/components.qtpl:338
 		qw422016.N().S(`) {
     w.Set`)
-//line generator/components.qtpl:258
+//line generator/components.qtpl:339
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:258
+//line generator/components.qtpl:339
 		qw422016.N().S(`(w.resourceEntity, arg)
 }
 `)
-//line generator/components.qtpl:260
+//line generator/components.qtpl:341
 	} else {
-//line generator/components.qtpl:260
+//line generator/components.qtpl:341
 		qw422016.N().S(`func (w *World) Set`)
-//line generator/components.qtpl:261
+//line generator/components.qtpl:342
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:261
+//line generator/components.qtpl:342
 		qw422016.N().S(`Resource(c `)
-//line generator/components.qtpl:261
+//line generator/components.qtpl:342
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:261
+//line generator/components.qtpl:342
 		qw422016.N().S(`Component) {
     w.Set`)
-//line generator/components.qtpl:262
+//line generator/components.qtpl:343
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:262
+//line generator/components.qtpl:343
 		qw422016.N().S(`(w.resourceEntity, c)
 }
 `)
-//line generator/components.qtpl:264
+//line generator/components.qtpl:345
 	}
-//line generator/components.qtpl:264
+//line generator/components.qtpl:345
 	qw422016.N().S(`
 `)
-//line generator/components.qtpl:266
+//line generator/components.qtpl:347
 	if !data.IsOnlyOneField {
-//line generator/components.qtpl:266
+//line generator/components.qtpl:347
 		qw422016.N().S(`func (w *World) Set`)
-//line generator/components.qtpl:267
+//line generator/components.qtpl:348
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:267
+//line generator/components.qtpl:348
 		qw422016.N().S(`ResourceFromValues(
 `)
-//line generator/components.qtpl:268
-		for _, f := range data.Fields {
-//line generator/components.qtpl:268
+//line generator/components.qtpl:349
+		for _, f := range data.WritableFields() {
+//line generator/components.qtpl:349
 			qw422016.N().S(`    `)
-//line generator/components.qtpl:269
+//line generator/components.qtpl:350
 			qw422016.E().S(f.Name.Singular.Camel)
-//line generator/components.qtpl:269
+//line generator/components.qtpl:350
 			qw422016.N().S(`Arg `)
-//line generator/components.qtpl:269
+//line generator/components.qtpl:350
 			qw422016.E().S(f.Type.Singular.Original)
-//line generator/components.qtpl:269
+//line generator/components.qtpl:350
 			qw422016.N().S(`,
 `)
-//line generator/components.qtpl:270
+//line generator/components.qtpl:351
 		}
-//line generator/components.qtpl:270
+//line generator/components.qtpl:351
 		qw422016.N().S(`) {
    w.Set`)
-//line generator/components.qtpl:272
+//line generator/components.qtpl:353
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:272
+//line generator/components.qtpl:353
 		qw422016.N().S(`Resource(`)
-//line generator/components.qtpl:272
+//line generator/components.qtpl:353
 		qw422016.E().S(nsp)
-//line generator/components.qtpl:272
+//line generator/components.qtpl:353
 		qw422016.N().S(`Component{
 `)
-//line generator/components.qtpl:273
+//line generator/components.qtpl:354
 		for _, f := range data.Fields {
-//line generator/components.qtpl:273
+//line generator/components.qtpl:354
 			qw422016.N().S(`        `)
-//line generator/components.qtpl:274
+//line generator/components.qtpl:355
 			qw422016.E().S(f.Name.Singular.Pascal)
-//line generator/components.qtpl:274
+//line generator/components.qtpl:355
 			qw422016.N().S(`: `)
-//line generator/components.qtpl:274
-			qw422016.E().S(f.Name.Singular.Camel)
-//line generator/components.qtpl:274
-			qw422016.N().S(`Arg,
+//line generator/components.qtpl:355
+			qw422016.N().S(f.FromValuesArg())
+//line generator/components.qtpl:355
+			qw422016.N().S(`,
 `)
-//line generator/components.qtpl:275
+//line generator/components.qtpl:356
 		}
-//line generator/components.qtpl:275
+//line generator/components.qtpl:356
 		qw422016.N().S(`    })
 }
 `)
-//line generator/components.qtpl:278
+//line generator/components.qtpl:359
 	}
-//line generator/components.qtpl:278
+//line generator/components.qtpl:359
 	qw422016.N().S(`
 func (w *World) `)
-//line generator/components.qtpl:280
+//line generator/components.qtpl:361
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:280
+//line generator/components.qtpl:361
 	qw422016.N().S(`Resource() (`)
-//line generator/components.qtpl:280
+//line generator/components.qtpl:361
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:280
+//line generator/components.qtpl:361
 	qw422016.N().S(`Component,bool) {
     return w.`)
-//line generator/components.qtpl:281
+//line generator/components.qtpl:362
 	qw422016.E().S(ss)
-//line generator/components.qtpl:281
+//line generator/components.qtpl:362
 	qw422016.N().S(`.Data(w.resourceEntity)
 }
 
 func (w *World) Must`)
-//line generator/components.qtpl:284
+//line generator/components.qtpl:365
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:284
+//line generator/components.qtpl:365
 	qw422016.N().S(`Resource() `)
-//line generator/components.qtpl:284
+//line generator/components.qtpl:365
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:284
+//line generator/components.qtpl:365
 	qw422016.N().S(`Component {
     c, ok := w.`)
-//line generator/components.qtpl:285
+//line generator/components.qtpl:366
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:285
+//line generator/components.qtpl:366
 	qw422016.N().S(`Resource()
     if !ok {
         panic("resource entity does not have `)
-//line generator/components.qtpl:287
+//line generator/components.qtpl:368
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:287
+//line generator/components.qtpl:368
 	qw422016.N().S(`")
     }
     return c
 }
 
 func (w *World) Remove`)
-//line generator/components.qtpl:292
+//line generator/components.qtpl:373
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:292
+//line generator/components.qtpl:373
 	qw422016.N().S(`Resource() {
-    w.`)
-//line generator/components.qtpl:293
-	qw422016.E().S(ss)
-//line generator/components.qtpl:293
-	qw422016.N().S(`.Remove(w.resourceEntity)
+    w.Remove`)
+//line generator/components.qtpl:374
+	qw422016.E().S(nsp)
+//line generator/components.qtpl:374
+	qw422016.N().S(`(w.resourceEntity)
 }
 
 func (w *World) Has`)
-//line generator/components.qtpl:296
+//line generator/components.qtpl:377
 	qw422016.E().S(nsp)
-//line generator/components.qtpl:296
+//line generator/components.qtpl:377
 	qw422016.N().S(`Resource() bool {
     return w.`)
-//line generator/components.qtpl:297
+//line generator/components.qtpl:378
 	qw422016.E().S(ss)
-//line generator/components.qtpl:297
+//line generator/components.qtpl:378
 	qw422016.N().S(`.Contains(w.resourceEntity)
 }
 
+// Commands
+`)
+//line generator/components.qtpl:382
+	if data.IsOnlyOneField {
+//line generator/components.qtpl:382
+		qw422016.N().S(`func (cb *CommandBuffer) Set`)
+//line generator/components.qtpl:383
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:383
+		qw422016.N().S(`(e Entity, arg `)
+//line generator/components.qtpl:383
+		qw422016.E().S(data.Fields[0].Type.Singular.Original)
+//line generator/components.qtpl:383
+		qw422016.N().S(`) {
+    cb.record(func(w *World) {
+        w.Set`)
+//line generator/components.qtpl:385
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:385
+		qw422016.N().S(`(e, arg)
+    })
+}
+`)
+//line generator/components.qtpl:388
+	} else {
+//line generator/components.qtpl:388
+		qw422016.N().S(`func (cb *CommandBuffer) Set`)
+//line generator/components.qtpl:389
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:389
+		qw422016.N().S(`(e Entity, c `)
+//line generator/components.qtpl:389
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:389
+		qw422016.N().S(`Component) {
+    cb.record(func(w *World) {
+        w.Set`)
+//line generator/components.qtpl:391
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:391
+		qw422016.N().S(`(e, c)
+    })
+}
+
+func (cb *CommandBuffer) Set`)
+//line generator/components.qtpl:395
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:395
+		qw422016.N().S(`FromValues(
+    e Entity,
+`)
+//line generator/components.qtpl:397
+		for _, f := range data.WritableFields() {
+//line generator/components.qtpl:397
+			qw422016.N().S(`    `)
+//line generator/components.qtpl:398
+			qw422016.E().S(f.Name.Singular.Camel)
+//line generator/components.qtpl:398
+			qw422016.N().S(`Arg `)
+//line generator/components.qtpl:398
+			qw422016.E().S(f.Type.Singular.Original)
+//line generator/components.qtpl:398
+			qw422016.N().S(`,
+`)
+//line generator/components.qtpl:399
+		}
+//line generator/components.qtpl:399
+		qw422016.N().S(`) {
+    cb.Set`)
+//line generator/components.qtpl:401
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:401
+		qw422016.N().S(`(e, `)
+//line generator/components.qtpl:401
+		qw422016.E().S(nsp)
+//line generator/components.qtpl:401
+		qw422016.N().S(`Component{
+`)
+//line generator/components.qtpl:402
+		for _, f := range data.Fields {
+//line generator/components.qtpl:402
+			qw422016.N().S(`        `)
+//line generator/components.qtpl:403
+			qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/components.qtpl:403
+			qw422016.N().S(`: `)
+//line generator/components.qtpl:403
+			qw422016.N().S(f.FromValuesArg())
+//line generator/components.qtpl:403
+			qw422016.N().S(`,
+`)
+//line generator/components.qtpl:404
+		}
+//line generator/components.qtpl:404
+		qw422016.N().S(`    })
+}
+`)
+//line generator/components.qtpl:407
+	}
+//line generator/components.qtpl:407
+	qw422016.N().S(`
+func (cb *CommandBuffer) Remove`)
+//line generator/components.qtpl:409
+	qw422016.E().S(nsp)
+//line generator/components.qtpl:409
+	qw422016.N().S(`(e Entity) {
+    cb.record(func(w *World) {
+        w.Remove`)
+//line generator/components.qtpl:411
+	qw422016.E().S(nsp)
+//line generator/components.qtpl:411
+	qw422016.N().S(`(e)
+    })
+}
+
 
 `)
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 }
 
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 func writecomponentTemplate(qq422016 qtio422016.Writer, data *componentTmplData) {
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 	streamcomponentTemplate(qw422016, data)
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 	qt422016.ReleaseWriter(qw422016)
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 }
 
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 func componentTemplate(data *componentTmplData) string {
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 	qb422016 := qt422016.AcquireByteBuffer()
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 	writecomponentTemplate(qb422016, data)
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 	qs422016 := string(qb422016.B)
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 	qt422016.ReleaseByteBuffer(qb422016)
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 	return qs422016
-//line generator/components.qtpl:301
+//line generator/components.qtpl:416
 }
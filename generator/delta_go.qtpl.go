@@ -0,0 +1,1319 @@
+// Code generated by qtc from "delta_go.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// package generator
+//
+
+//line generator/delta_go.qtpl:3
+package generator
+
+//line generator/delta_go.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line generator/delta_go.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line generator/delta_go.qtpl:3
+func streamdeltaTemplate(qw422016 *qt422016.Writer, data *ecsTmplData) {
+//line generator/delta_go.qtpl:3
+	qw422016.N().S(`
+package `)
+//line generator/delta_go.qtpl:4
+	qw422016.E().S(data.PackageName)
+//line generator/delta_go.qtpl:4
+	qw422016.N().S(`
+
+import (
+    "bytes"
+    "errors"
+    "fmt"
+    "slices"
+)
+
+// Snapshot is an in-memory copy of a world to diff against later, unlike
+// MarshalBinary it is meant to be taken every tick
+type Snapshot struct {
+    world          *World
+    livingEntities *SparseSet[empty]
+`)
+//line generator/delta_go.qtpl:18
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:19
+		if c.IsTag {
+//line generator/delta_go.qtpl:19
+			qw422016.N().S(`    `)
+//line generator/delta_go.qtpl:20
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:20
+			qw422016.N().S(`Tags *SparseSet[empty]
+`)
+//line generator/delta_go.qtpl:21
+		} else if c.IsRelationship {
+//line generator/delta_go.qtpl:21
+			qw422016.N().S(`    `)
+//line generator/delta_go.qtpl:22
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:22
+			qw422016.N().S(`Relationships *`)
+//line generator/delta_go.qtpl:22
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:22
+			qw422016.N().S(`Relationship
+`)
+//line generator/delta_go.qtpl:23
+		} else {
+//line generator/delta_go.qtpl:23
+			qw422016.N().S(`    `)
+//line generator/delta_go.qtpl:24
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:24
+			qw422016.N().S(`Components *SparseSet[`)
+//line generator/delta_go.qtpl:24
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:24
+			qw422016.N().S(`Component]
+`)
+//line generator/delta_go.qtpl:25
+		}
+//line generator/delta_go.qtpl:26
+	}
+//line generator/delta_go.qtpl:26
+	qw422016.N().S(`}
+
+func (w *World) Snapshot() Snapshot {
+    s := Snapshot{
+        world:          w,
+        livingEntities: w.livingEntities.clone(),
+`)
+//line generator/delta_go.qtpl:33
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:34
+		if c.IsTag {
+//line generator/delta_go.qtpl:34
+			qw422016.N().S(`        `)
+//line generator/delta_go.qtpl:35
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:35
+			qw422016.N().S(`Tags: w.`)
+//line generator/delta_go.qtpl:35
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:35
+			qw422016.N().S(`Tags.clone(),
+`)
+//line generator/delta_go.qtpl:36
+		} else if c.IsRelationship {
+//line generator/delta_go.qtpl:36
+			qw422016.N().S(`        `)
+//line generator/delta_go.qtpl:37
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:37
+			qw422016.N().S(`Relationships: w.`)
+//line generator/delta_go.qtpl:37
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:37
+			qw422016.N().S(`Relationships.clone(),
+`)
+//line generator/delta_go.qtpl:38
+		} else {
+//line generator/delta_go.qtpl:38
+			qw422016.N().S(`        `)
+//line generator/delta_go.qtpl:39
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:39
+			qw422016.N().S(`Components: w.`)
+//line generator/delta_go.qtpl:39
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:39
+			qw422016.N().S(`Components.clone(),
+`)
+//line generator/delta_go.qtpl:40
+		}
+//line generator/delta_go.qtpl:41
+	}
+//line generator/delta_go.qtpl:41
+	qw422016.N().S(`    }
+
+    // values holding slices would otherwise share them with the world
+`)
+//line generator/delta_go.qtpl:45
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:46
+		if !c.IsTag && !c.IsRelationship && c.HasSliceFields() {
+//line generator/delta_go.qtpl:46
+			qw422016.N().S(`    for i := range s.`)
+//line generator/delta_go.qtpl:47
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:47
+			qw422016.N().S(`Components.data {
+        c := &s.`)
+//line generator/delta_go.qtpl:48
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:48
+			qw422016.N().S(`Components.data[i]
+`)
+//line generator/delta_go.qtpl:49
+			for _, f := range c.Fields {
+//line generator/delta_go.qtpl:50
+				if f.IsSlice || f.Kind == "Bin" {
+//line generator/delta_go.qtpl:50
+					qw422016.N().S(`        c.`)
+//line generator/delta_go.qtpl:51
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:51
+					qw422016.N().S(` = slices.Clone(c.`)
+//line generator/delta_go.qtpl:51
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:51
+					qw422016.N().S(`)
+`)
+//line generator/delta_go.qtpl:52
+				}
+//line generator/delta_go.qtpl:53
+			}
+//line generator/delta_go.qtpl:53
+			qw422016.N().S(`    }
+`)
+//line generator/delta_go.qtpl:55
+		}
+//line generator/delta_go.qtpl:56
+	}
+//line generator/delta_go.qtpl:56
+	qw422016.N().S(`
+    return s
+}
+
+// Delta is what changed between a Snapshot and a later state of the world,
+// changes to entities that were destroyed are left out
+type Delta struct {
+    Created, Destroyed []Entity
+`)
+//line generator/delta_go.qtpl:65
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:66
+		if c.IsTag {
+//line generator/delta_go.qtpl:66
+			qw422016.N().S(`    `)
+//line generator/delta_go.qtpl:67
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:67
+			qw422016.N().S(` TagDelta
+`)
+//line generator/delta_go.qtpl:68
+		} else if c.IsRelationship {
+//line generator/delta_go.qtpl:68
+			qw422016.N().S(`    `)
+//line generator/delta_go.qtpl:69
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:69
+			qw422016.N().S(` RelationshipDelta[`)
+//line generator/delta_go.qtpl:69
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:69
+			qw422016.N().S(`RelationshipPair]
+`)
+//line generator/delta_go.qtpl:70
+		} else {
+//line generator/delta_go.qtpl:70
+			qw422016.N().S(`    `)
+//line generator/delta_go.qtpl:71
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:71
+			qw422016.N().S(` ComponentDelta[`)
+//line generator/delta_go.qtpl:71
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:71
+			qw422016.N().S(`Component]
+`)
+//line generator/delta_go.qtpl:72
+		}
+//line generator/delta_go.qtpl:73
+	}
+//line generator/delta_go.qtpl:73
+	qw422016.N().S(`}
+
+type ComponentDelta[T any] struct {
+    Added   []ComponentValue[T]
+    Changed []ComponentChange[T]
+    Removed []Entity
+}
+
+type ComponentValue[T any] struct {
+    Entity Entity
+    Value  T
+}
+
+// ComponentChange only carries the fields flagged in Fields, bit i is the i-th
+// field of the component in schema order
+type ComponentChange[T any] struct {
+    Entity Entity
+    Fields uint64
+    Value  T
+}
+
+type TagDelta struct {
+    Added, Removed []Entity
+}
+
+type RelationshipDelta[T any] struct {
+    // Linked holds new pairs and pairs whose fields changed, only From and To
+    // are set on Unlinked pairs
+    Linked, Unlinked []T
+}
+
+func (d *ComponentDelta[T]) isEmpty() bool {
+    return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+func (d *TagDelta) isEmpty() bool {
+    return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+func (d *RelationshipDelta[T]) isEmpty() bool {
+    return len(d.Linked) == 0 && len(d.Unlinked) == 0
+}
+
+func (d *Delta) IsEmpty() bool {
+    return len(d.Created) == 0 && len(d.Destroyed) == 0`)
+//line generator/delta_go.qtpl:118
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:118
+		qw422016.N().S(` &&
+        d.`)
+//line generator/delta_go.qtpl:119
+		qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:119
+		qw422016.N().S(`.isEmpty()`)
+//line generator/delta_go.qtpl:119
+	}
+//line generator/delta_go.qtpl:119
+	qw422016.N().S(`
+}
+
+// Diff compares the world to an earlier snapshot of it. Sets that haven't
+// been written to since are skipped, a snapshot of another world is compared
+// in full.
+func (w *World) Diff(prev Snapshot) Delta {
+    var d Delta
+    tracked := prev.world == w
+
+    if !tracked || prev.livingEntities.version != w.livingEntities.version {
+        for _, e := range w.livingEntities.dense {
+            if !prev.livingEntities.Contains(e) {
+                d.Created = append(d.Created, e)
+            }
+        }
+        for _, e := range prev.livingEntities.dense {
+            if !w.IsAlive(e) {
+                d.Destroyed = append(d.Destroyed, e)
+            }
+        }
+    }
+
+`)
+//line generator/delta_go.qtpl:142
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:144
+		nsc := c.Name.Singular.Camel
+		nsp := c.Name.Singular.Pascal
+
+//line generator/delta_go.qtpl:147
+		if c.IsTag {
+//line generator/delta_go.qtpl:147
+			qw422016.N().S(`    if !tracked || prev.`)
+//line generator/delta_go.qtpl:148
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:148
+			qw422016.N().S(`Tags.version != w.`)
+//line generator/delta_go.qtpl:148
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:148
+			qw422016.N().S(`Tags.version {
+        diffTags(w, &d.`)
+//line generator/delta_go.qtpl:149
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:149
+			qw422016.N().S(`, prev.`)
+//line generator/delta_go.qtpl:149
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:149
+			qw422016.N().S(`Tags, w.`)
+//line generator/delta_go.qtpl:149
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:149
+			qw422016.N().S(`Tags)
+    }
+`)
+//line generator/delta_go.qtpl:151
+		} else if c.IsRelationship {
+//line generator/delta_go.qtpl:151
+			qw422016.N().S(`    if !tracked || prev.`)
+//line generator/delta_go.qtpl:152
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:152
+			qw422016.N().S(`Relationships.version != w.`)
+//line generator/delta_go.qtpl:152
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:152
+			qw422016.N().S(`Relationships.version {
+        w.`)
+//line generator/delta_go.qtpl:153
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:153
+			qw422016.N().S(`Relationships.byFrom.Scan(func(pair `)
+//line generator/delta_go.qtpl:153
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:153
+			qw422016.N().S(`RelationshipPair) bool {
+`)
+//line generator/delta_go.qtpl:154
+			if len(c.WritableFields()) > 0 {
+//line generator/delta_go.qtpl:154
+				qw422016.N().S(`            old, ok := prev.`)
+//line generator/delta_go.qtpl:155
+				qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:155
+				qw422016.N().S(`Relationships.get(pair.From, pair.To)
+            if !ok`)
+//line generator/delta_go.qtpl:156
+				for _, f := range c.WritableFields() {
+//line generator/delta_go.qtpl:156
+					qw422016.N().S(` || !(`)
+//line generator/delta_go.qtpl:156
+					qw422016.N().S(fieldEqual(f, "old."+f.Name.Singular.Pascal, "pair."+f.Name.Singular.Pascal))
+//line generator/delta_go.qtpl:156
+					qw422016.N().S(`)`)
+//line generator/delta_go.qtpl:156
+				}
+//line generator/delta_go.qtpl:156
+				qw422016.N().S(` {
+`)
+//line generator/delta_go.qtpl:157
+			} else {
+//line generator/delta_go.qtpl:157
+				qw422016.N().S(`            if _, ok := prev.`)
+//line generator/delta_go.qtpl:158
+				qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:158
+				qw422016.N().S(`Relationships.get(pair.From, pair.To); !ok {
+`)
+//line generator/delta_go.qtpl:159
+			}
+//line generator/delta_go.qtpl:159
+			qw422016.N().S(`                d.`)
+//line generator/delta_go.qtpl:160
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:160
+			qw422016.N().S(`.Linked = append(d.`)
+//line generator/delta_go.qtpl:160
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:160
+			qw422016.N().S(`.Linked, pair)
+            }
+            return true
+        })
+        prev.`)
+//line generator/delta_go.qtpl:164
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:164
+			qw422016.N().S(`Relationships.byFrom.Scan(func(pair `)
+//line generator/delta_go.qtpl:164
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:164
+			qw422016.N().S(`RelationshipPair) bool {
+            _, ok := w.`)
+//line generator/delta_go.qtpl:165
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:165
+			qw422016.N().S(`Relationships.get(pair.From, pair.To)
+            if !ok && w.IsAlive(pair.From) && w.IsAlive(pair.To) {
+                d.`)
+//line generator/delta_go.qtpl:167
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:167
+			qw422016.N().S(`.Unlinked = append(d.`)
+//line generator/delta_go.qtpl:167
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:167
+			qw422016.N().S(`.Unlinked, `)
+//line generator/delta_go.qtpl:167
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:167
+			qw422016.N().S(`RelationshipPair{From: pair.From, To: pair.To})
+            }
+            return true
+        })
+    }
+`)
+//line generator/delta_go.qtpl:172
+		} else {
+//line generator/delta_go.qtpl:172
+			qw422016.N().S(`    if !tracked || prev.`)
+//line generator/delta_go.qtpl:173
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:173
+			qw422016.N().S(`Components.version != w.`)
+//line generator/delta_go.qtpl:173
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:173
+			qw422016.N().S(`Components.version {
+        diffComponents(w, &d.`)
+//line generator/delta_go.qtpl:174
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:174
+			qw422016.N().S(`, prev.`)
+//line generator/delta_go.qtpl:174
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:174
+			qw422016.N().S(`Components, w.`)
+//line generator/delta_go.qtpl:174
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:174
+			qw422016.N().S(`Components, `)
+//line generator/delta_go.qtpl:174
+			qw422016.E().S(nsc)
+//line generator/delta_go.qtpl:174
+			qw422016.N().S(`Changes)
+    }
+`)
+//line generator/delta_go.qtpl:176
+		}
+//line generator/delta_go.qtpl:177
+	}
+//line generator/delta_go.qtpl:177
+	qw422016.N().S(`
+    return d
+}
+
+func diffComponents[T any](w *World, d *ComponentDelta[T], prev, curr *SparseSet[T], changes func(prev, curr *T) (uint64, T)) {
+    for i, e := range curr.dense {
+        j := prev.search(e)
+        if j == -1 {
+            d.Added = append(d.Added, ComponentValue[T]{Entity: e, Value: curr.data[i]})
+            continue
+        }
+        if fields, changed := changes(&prev.data[j], &curr.data[i]); fields != 0 {
+            d.Changed = append(d.Changed, ComponentChange[T]{Entity: e, Fields: fields, Value: changed})
+        }
+    }
+    for _, e := range prev.dense {
+        if !curr.Contains(e) && w.IsAlive(e) {
+            d.Removed = append(d.Removed, e)
+        }
+    }
+}
+
+func diffTags(w *World, d *TagDelta, prev, curr *SparseSet[empty]) {
+    for _, e := range curr.dense {
+        if !prev.Contains(e) {
+            d.Added = append(d.Added, e)
+        }
+    }
+    for _, e := range prev.dense {
+        if !curr.Contains(e) && w.IsAlive(e) {
+            d.Removed = append(d.Removed, e)
+        }
+    }
+}
+
+`)
+//line generator/delta_go.qtpl:213
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:214
+		if !c.IsTag && !c.IsRelationship {
+//line generator/delta_go.qtpl:215
+			nsp := c.Name.Singular.Pascal
+
+//line generator/delta_go.qtpl:215
+			qw422016.N().S(`
+// `)
+//line generator/delta_go.qtpl:217
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:217
+			qw422016.N().S(`Changes flags the fields that differ and copies only those
+func `)
+//line generator/delta_go.qtpl:218
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:218
+			qw422016.N().S(`Changes(prev, curr *`)
+//line generator/delta_go.qtpl:218
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:218
+			qw422016.N().S(`Component) (fields uint64, changed `)
+//line generator/delta_go.qtpl:218
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:218
+			qw422016.N().S(`Component) {
+`)
+//line generator/delta_go.qtpl:219
+			for i, f := range c.Fields {
+//line generator/delta_go.qtpl:220
+				if !f.IsDeprecated {
+//line generator/delta_go.qtpl:220
+					qw422016.N().S(`    if !(`)
+//line generator/delta_go.qtpl:221
+					qw422016.N().S(fieldEqual(f, "prev."+f.Name.Singular.Pascal, "curr."+f.Name.Singular.Pascal))
+//line generator/delta_go.qtpl:221
+					qw422016.N().S(`) {
+        fields |= 1 << `)
+//line generator/delta_go.qtpl:222
+					qw422016.N().D(i)
+//line generator/delta_go.qtpl:222
+					qw422016.N().S(`
+        changed.`)
+//line generator/delta_go.qtpl:223
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:223
+					qw422016.N().S(` = curr.`)
+//line generator/delta_go.qtpl:223
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:223
+					qw422016.N().S(`
+    }
+`)
+//line generator/delta_go.qtpl:225
+				}
+//line generator/delta_go.qtpl:226
+			}
+//line generator/delta_go.qtpl:226
+			qw422016.N().S(`    return fields, changed
+}
+`)
+//line generator/delta_go.qtpl:229
+		}
+//line generator/delta_go.qtpl:230
+	}
+//line generator/delta_go.qtpl:230
+	qw422016.N().S(`
+var errDeltaMismatch = errors.New("delta doesn't match the world")
+
+// ApplyDelta replays a delta, usually on another world that was in the state
+// the delta was diffed against. Created entities keep their handles so the
+// delta's entities mean the same on both sides. A delta that doesn't match is
+// rejected before anything is applied.
+func (w *World) ApplyDelta(d Delta) error {
+    if err := w.checkDelta(d); err != nil {
+        return err
+    }
+
+    w.DestroyEntities(d.Destroyed...)
+    for _, e := range d.Created {
+        if err := w.adoptEntity(e); err != nil {
+            return err
+        }
+    }
+
+`)
+//line generator/delta_go.qtpl:250
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:252
+		nsp := c.Name.Singular.Pascal
+
+//line generator/delta_go.qtpl:254
+		if c.IsTag {
+//line generator/delta_go.qtpl:254
+			qw422016.N().S(`    w.TagWith`)
+//line generator/delta_go.qtpl:255
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:255
+			qw422016.N().S(`(d.`)
+//line generator/delta_go.qtpl:255
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:255
+			qw422016.N().S(`.Added...)
+    w.Remove`)
+//line generator/delta_go.qtpl:256
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:256
+			qw422016.N().S(`Tag(d.`)
+//line generator/delta_go.qtpl:256
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:256
+			qw422016.N().S(`.Removed...)
+`)
+//line generator/delta_go.qtpl:257
+		} else if c.IsRelationship {
+//line generator/delta_go.qtpl:257
+			qw422016.N().S(`    for _, pair := range d.`)
+//line generator/delta_go.qtpl:258
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:258
+			qw422016.N().S(`.Unlinked {
+        w.Unlink`)
+//line generator/delta_go.qtpl:259
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:259
+			qw422016.N().S(`(pair.From, pair.To)
+    }
+    for _, pair := range d.`)
+//line generator/delta_go.qtpl:261
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:261
+			qw422016.N().S(`.Linked {
+        w.Link`)
+//line generator/delta_go.qtpl:262
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:262
+			qw422016.N().S(`(pair.To, pair.From`)
+//line generator/delta_go.qtpl:262
+			for _, f := range c.WritableFields() {
+//line generator/delta_go.qtpl:262
+				qw422016.N().S(`, pair.`)
+//line generator/delta_go.qtpl:262
+				qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:262
+			}
+//line generator/delta_go.qtpl:262
+			qw422016.N().S(`)
+    }
+`)
+//line generator/delta_go.qtpl:264
+		} else {
+//line generator/delta_go.qtpl:264
+			qw422016.N().S(`    for _, v := range d.`)
+//line generator/delta_go.qtpl:265
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:265
+			qw422016.N().S(`.Added {
+`)
+//line generator/delta_go.qtpl:266
+			if c.IsOnlyOneField {
+//line generator/delta_go.qtpl:266
+				qw422016.N().S(`        w.Set`)
+//line generator/delta_go.qtpl:267
+				qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:267
+				qw422016.N().S(`(v.Entity, v.Value.`)
+//line generator/delta_go.qtpl:267
+				qw422016.E().S(c.Fields[0].Name.Singular.Pascal)
+//line generator/delta_go.qtpl:267
+				qw422016.N().S(`)
+`)
+//line generator/delta_go.qtpl:268
+			} else {
+//line generator/delta_go.qtpl:268
+				qw422016.N().S(`        w.Set`)
+//line generator/delta_go.qtpl:269
+				qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:269
+				qw422016.N().S(`(v.Entity, v.Value)
+`)
+//line generator/delta_go.qtpl:270
+			}
+//line generator/delta_go.qtpl:270
+			qw422016.N().S(`    }
+    for _, change := range d.`)
+//line generator/delta_go.qtpl:272
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:272
+			qw422016.N().S(`.Changed {
+`)
+//line generator/delta_go.qtpl:273
+			if c.IsOnlyOneField {
+//line generator/delta_go.qtpl:273
+				qw422016.N().S(`        w.Set`)
+//line generator/delta_go.qtpl:274
+				qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:274
+				qw422016.N().S(`(change.Entity, change.Value.`)
+//line generator/delta_go.qtpl:274
+				qw422016.E().S(c.Fields[0].Name.Singular.Pascal)
+//line generator/delta_go.qtpl:274
+				qw422016.N().S(`)
+`)
+//line generator/delta_go.qtpl:275
+			} else {
+//line generator/delta_go.qtpl:275
+				qw422016.N().S(`        // the entity's own value, an inherited one is never changed
+        c, _ := w.`)
+//line generator/delta_go.qtpl:277
+				qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:277
+				qw422016.N().S(`Components.Data(change.Entity)
+`)
+//line generator/delta_go.qtpl:278
+				for i, f := range c.Fields {
+//line generator/delta_go.qtpl:279
+					if !f.IsDeprecated {
+//line generator/delta_go.qtpl:279
+						qw422016.N().S(`        if change.Fields&(1<<`)
+//line generator/delta_go.qtpl:280
+						qw422016.N().D(i)
+//line generator/delta_go.qtpl:280
+						qw422016.N().S(`) != 0 {
+            c.`)
+//line generator/delta_go.qtpl:281
+						qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:281
+						qw422016.N().S(` = change.Value.`)
+//line generator/delta_go.qtpl:281
+						qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:281
+						qw422016.N().S(`
+        }
+`)
+//line generator/delta_go.qtpl:283
+					}
+//line generator/delta_go.qtpl:284
+				}
+//line generator/delta_go.qtpl:284
+				qw422016.N().S(`        w.Set`)
+//line generator/delta_go.qtpl:285
+				qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:285
+				qw422016.N().S(`(change.Entity, c)
+`)
+//line generator/delta_go.qtpl:286
+			}
+//line generator/delta_go.qtpl:286
+			qw422016.N().S(`    }
+    for _, e := range d.`)
+//line generator/delta_go.qtpl:288
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:288
+			qw422016.N().S(`.Removed {
+        w.Remove`)
+//line generator/delta_go.qtpl:289
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:289
+			qw422016.N().S(`(e)
+    }
+`)
+//line generator/delta_go.qtpl:291
+		}
+//line generator/delta_go.qtpl:292
+	}
+//line generator/delta_go.qtpl:292
+	qw422016.N().S(`
+    return nil
+}
+
+// checkDelta finds everything ApplyDelta would reject, so a mismatched delta
+// leaves the world untouched
+func (w *World) checkDelta(d Delta) error {
+    destroyed := make(map[Entity]bool, len(d.Destroyed))
+    for _, e := range d.Destroyed {
+        destroyed[e] = true
+    }
+    created := make(map[int]bool, len(d.Created))
+    for _, e := range d.Created {
+        idx := e.Index()
+        if slot := w.livingEntities.slot(idx); (slot != -1 && !destroyed[w.livingEntities.dense[slot]]) || created[idx] {
+            return fmt.Errorf("%w: entity %d is already alive", errDeltaMismatch, e)
+        }
+        created[idx] = true
+    }
+
+`)
+//line generator/delta_go.qtpl:313
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:314
+		if !c.IsTag && !c.IsRelationship {
+//line generator/delta_go.qtpl:314
+			qw422016.N().S(`    if err := checkComponentDelta(&d.`)
+//line generator/delta_go.qtpl:315
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:315
+			qw422016.N().S(`, w.`)
+//line generator/delta_go.qtpl:315
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/delta_go.qtpl:315
+			qw422016.N().S(`Components, destroyed, "`)
+//line generator/delta_go.qtpl:315
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:315
+			qw422016.N().S(`"); err != nil {
+        return err
+    }
+`)
+//line generator/delta_go.qtpl:318
+		}
+//line generator/delta_go.qtpl:319
+	}
+//line generator/delta_go.qtpl:319
+	qw422016.N().S(`    return nil
+}
+
+// checkComponentDelta makes sure every changed entity owns the component once
+// the delta's destroys and adds are applied
+func checkComponentDelta[T any](d *ComponentDelta[T], set *SparseSet[T], destroyed map[Entity]bool, name string) error {
+    if len(d.Changed) == 0 {
+        return nil
+    }
+    added := make(map[Entity]bool, len(d.Added))
+    for _, v := range d.Added {
+        added[v.Entity] = true
+    }
+    for _, change := range d.Changed {
+        if !added[change.Entity] && (destroyed[change.Entity] || !set.Contains(change.Entity)) {
+            return fmt.Errorf("%w: entity %d has no %s to change", errDeltaMismatch, change.Entity, name)
+        }
+    }
+    return nil
+}
+
+// adoptEntity makes a handle handed out by another world alive in this one
+func (w *World) adoptEntity(e Entity) error {
+    idx := e.Index()
+    if w.livingEntities.slot(idx) != -1 {
+        return fmt.Errorf("%w: entity %d is already alive", errDeltaMismatch, e)
+    }
+    for w.nextEntityID <= idx {
+        w.freeEntities.Upsert(NewEntity(w.nextEntityID, 0), empty{})
+        w.nextEntityID++
+    }
+    if slot := w.freeEntities.slot(idx); slot != -1 {
+        w.freeEntities.Remove(w.freeEntities.dense[slot])
+    }
+    w.livingEntities.Upsert(e, empty{})
+    return nil
+}
+
+var deltaMagic = []byte("GECD")
+
+// readSlice allocates a slice for a counted list, empty lists stay nil so
+// deltas compare equal after a round trip
+func readSlice[T any](r *snapshotReader) []T {
+    n := r.count()
+    if n == 0 {
+        return nil
+    }
+    return make([]T, n)
+}
+
+// Delta layout, every number is a varint
+//
+//  magic "GECD", format, schema version
+//  created entities, destroyed entities
+//  changed sets, each with its ComponentID and payload, deltas can only be
+//  applied by worlds with the same schema
+func (d *Delta) MarshalBinary() ([]byte, error) {
+    sw := &snapshotWriter{}
+    sw.buf = append(sw.buf, deltaMagic...)
+    sw.uvarint(snapshotFormat)
+    sw.uvarint(SchemaVersion)
+    sw.entities(d.Created)
+    sw.entities(d.Destroyed)
+
+    var sets []ComponentID
+`)
+//line generator/delta_go.qtpl:385
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:385
+		qw422016.N().S(`    if !d.`)
+//line generator/delta_go.qtpl:386
+		qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:386
+		qw422016.N().S(`.isEmpty() {
+        sets = append(sets, `)
+//line generator/delta_go.qtpl:387
+		qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:387
+		qw422016.N().S(`ID)
+    }
+`)
+//line generator/delta_go.qtpl:389
+	}
+//line generator/delta_go.qtpl:389
+	qw422016.N().S(`    sw.uvarint(uint64(len(sets)))
+
+`)
+//line generator/delta_go.qtpl:392
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:394
+		nsp := c.Name.Singular.Pascal
+
+//line generator/delta_go.qtpl:395
+		qw422016.N().S(`    if !d.`)
+//line generator/delta_go.qtpl:396
+		qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:396
+		qw422016.N().S(`.isEmpty() {
+        sw.uvarint(uint64(`)
+//line generator/delta_go.qtpl:397
+		qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:397
+		qw422016.N().S(`ID))
+`)
+//line generator/delta_go.qtpl:398
+		if c.IsTag {
+//line generator/delta_go.qtpl:398
+			qw422016.N().S(`        sw.entities(d.`)
+//line generator/delta_go.qtpl:399
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:399
+			qw422016.N().S(`.Added)
+        sw.entities(d.`)
+//line generator/delta_go.qtpl:400
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:400
+			qw422016.N().S(`.Removed)
+`)
+//line generator/delta_go.qtpl:401
+		} else if c.IsRelationship {
+//line generator/delta_go.qtpl:401
+			qw422016.N().S(`        sw.uvarint(uint64(len(d.`)
+//line generator/delta_go.qtpl:402
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:402
+			qw422016.N().S(`.Linked)))
+        for _, pair := range d.`)
+//line generator/delta_go.qtpl:403
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:403
+			qw422016.N().S(`.Linked {
+            sw.entity(pair.From)
+            sw.entity(pair.To)
+`)
+//line generator/delta_go.qtpl:406
+			for _, f := range c.WritableFields() {
+//line generator/delta_go.qtpl:406
+				qw422016.N().S(`            `)
+//line generator/delta_go.qtpl:407
+				streamsnapshotWriteField(qw422016, f, "pair."+f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:407
+			}
+//line generator/delta_go.qtpl:407
+			qw422016.N().S(`        }
+        sw.uvarint(uint64(len(d.`)
+//line generator/delta_go.qtpl:409
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:409
+			qw422016.N().S(`.Unlinked)))
+        for _, pair := range d.`)
+//line generator/delta_go.qtpl:410
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:410
+			qw422016.N().S(`.Unlinked {
+            sw.entity(pair.From)
+            sw.entity(pair.To)
+        }
+`)
+//line generator/delta_go.qtpl:414
+		} else {
+//line generator/delta_go.qtpl:414
+			qw422016.N().S(`        sw.uvarint(uint64(len(d.`)
+//line generator/delta_go.qtpl:415
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:415
+			qw422016.N().S(`.Added)))
+        for _, v := range d.`)
+//line generator/delta_go.qtpl:416
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:416
+			qw422016.N().S(`.Added {
+            c := &v.Value
+            sw.entity(v.Entity)
+`)
+//line generator/delta_go.qtpl:419
+			for _, f := range c.WritableFields() {
+//line generator/delta_go.qtpl:419
+				qw422016.N().S(`            `)
+//line generator/delta_go.qtpl:420
+				streamsnapshotWriteField(qw422016, f, "c."+f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:420
+			}
+//line generator/delta_go.qtpl:420
+			qw422016.N().S(`        }
+        sw.uvarint(uint64(len(d.`)
+//line generator/delta_go.qtpl:422
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:422
+			qw422016.N().S(`.Changed)))
+        for _, change := range d.`)
+//line generator/delta_go.qtpl:423
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:423
+			qw422016.N().S(`.Changed {
+            c := &change.Value
+            sw.entity(change.Entity)
+            sw.uvarint(change.Fields)
+`)
+//line generator/delta_go.qtpl:427
+			for i, f := range c.Fields {
+//line generator/delta_go.qtpl:428
+				if !f.IsDeprecated {
+//line generator/delta_go.qtpl:428
+					qw422016.N().S(`            if change.Fields&(1<<`)
+//line generator/delta_go.qtpl:429
+					qw422016.N().D(i)
+//line generator/delta_go.qtpl:429
+					qw422016.N().S(`) != 0 {
+                `)
+//line generator/delta_go.qtpl:430
+					streamsnapshotWriteField(qw422016, f, "c."+f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:430
+					qw422016.N().S(`            }
+`)
+//line generator/delta_go.qtpl:431
+				}
+//line generator/delta_go.qtpl:432
+			}
+//line generator/delta_go.qtpl:432
+			qw422016.N().S(`        }
+        sw.entities(d.`)
+//line generator/delta_go.qtpl:434
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:434
+			qw422016.N().S(`.Removed)
+`)
+//line generator/delta_go.qtpl:435
+		}
+//line generator/delta_go.qtpl:435
+		qw422016.N().S(`    }
+`)
+//line generator/delta_go.qtpl:437
+	}
+//line generator/delta_go.qtpl:437
+	qw422016.N().S(`
+    return sw.buf, nil
+}
+
+func (d *Delta) UnmarshalBinary(data []byte) error {
+    *d = Delta{}
+    if !bytes.HasPrefix(data, deltaMagic) {
+        return errors.New("not a delta")
+    }
+    r := &snapshotReader{buf: data, off: len(deltaMagic)}
+    if format := r.uvarint(); format != snapshotFormat {
+        return fmt.Errorf("unsupported delta format %d", format)
+    }
+    if version := r.uvarint(); version != SchemaVersion {
+        return fmt.Errorf("delta schema version %d doesn't match %d", version, SchemaVersion)
+    }
+    d.Created = r.entities()
+    d.Destroyed = r.entities()
+
+    for range r.count() {
+        switch id := ComponentID(r.uvarint()); id {
+`)
+//line generator/delta_go.qtpl:459
+	for _, c := range data.Components {
+//line generator/delta_go.qtpl:461
+		nsp := c.Name.Singular.Pascal
+
+//line generator/delta_go.qtpl:462
+		qw422016.N().S(`        case `)
+//line generator/delta_go.qtpl:463
+		qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:463
+		qw422016.N().S(`ID:
+`)
+//line generator/delta_go.qtpl:464
+		if c.IsTag {
+//line generator/delta_go.qtpl:464
+			qw422016.N().S(`            d.`)
+//line generator/delta_go.qtpl:465
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:465
+			qw422016.N().S(`.Added = r.entities()
+            d.`)
+//line generator/delta_go.qtpl:466
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:466
+			qw422016.N().S(`.Removed = r.entities()
+`)
+//line generator/delta_go.qtpl:467
+		} else if c.IsRelationship {
+//line generator/delta_go.qtpl:467
+			qw422016.N().S(`            d.`)
+//line generator/delta_go.qtpl:468
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:468
+			qw422016.N().S(`.Linked = readSlice[`)
+//line generator/delta_go.qtpl:468
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:468
+			qw422016.N().S(`RelationshipPair](r)
+            for i := range d.`)
+//line generator/delta_go.qtpl:469
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:469
+			qw422016.N().S(`.Linked {
+                v := &d.`)
+//line generator/delta_go.qtpl:470
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:470
+			qw422016.N().S(`.Linked[i]
+                v.From = r.entity()
+                v.To = r.entity()
+`)
+//line generator/delta_go.qtpl:473
+			for _, f := range c.WritableFields() {
+//line generator/delta_go.qtpl:473
+				qw422016.N().S(`                `)
+//line generator/delta_go.qtpl:474
+				streamsnapshotReadField(qw422016, f, "v."+f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:474
+			}
+//line generator/delta_go.qtpl:474
+			qw422016.N().S(`            }
+            d.`)
+//line generator/delta_go.qtpl:476
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:476
+			qw422016.N().S(`.Unlinked = readSlice[`)
+//line generator/delta_go.qtpl:476
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:476
+			qw422016.N().S(`RelationshipPair](r)
+            for i := range d.`)
+//line generator/delta_go.qtpl:477
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:477
+			qw422016.N().S(`.Unlinked {
+                d.`)
+//line generator/delta_go.qtpl:478
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:478
+			qw422016.N().S(`.Unlinked[i] = `)
+//line generator/delta_go.qtpl:478
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:478
+			qw422016.N().S(`RelationshipPair{From: r.entity(), To: r.entity()}
+            }
+`)
+//line generator/delta_go.qtpl:480
+		} else {
+//line generator/delta_go.qtpl:480
+			qw422016.N().S(`            d.`)
+//line generator/delta_go.qtpl:481
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:481
+			qw422016.N().S(`.Added = readSlice[ComponentValue[`)
+//line generator/delta_go.qtpl:481
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:481
+			qw422016.N().S(`Component]](r)
+            for i := range d.`)
+//line generator/delta_go.qtpl:482
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:482
+			qw422016.N().S(`.Added {
+                added := &d.`)
+//line generator/delta_go.qtpl:483
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:483
+			qw422016.N().S(`.Added[i]
+                added.Entity = r.entity()
+                added.Value = Default`)
+//line generator/delta_go.qtpl:485
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:485
+			qw422016.N().S(`Component()
+                v := &added.Value
+`)
+//line generator/delta_go.qtpl:487
+			for _, f := range c.WritableFields() {
+//line generator/delta_go.qtpl:487
+				qw422016.N().S(`                `)
+//line generator/delta_go.qtpl:488
+				streamsnapshotReadField(qw422016, f, "v."+f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:488
+			}
+//line generator/delta_go.qtpl:488
+			qw422016.N().S(`            }
+            d.`)
+//line generator/delta_go.qtpl:490
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:490
+			qw422016.N().S(`.Changed = readSlice[ComponentChange[`)
+//line generator/delta_go.qtpl:490
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:490
+			qw422016.N().S(`Component]](r)
+            for i := range d.`)
+//line generator/delta_go.qtpl:491
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:491
+			qw422016.N().S(`.Changed {
+                change := &d.`)
+//line generator/delta_go.qtpl:492
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:492
+			qw422016.N().S(`.Changed[i]
+                change.Entity = r.entity()
+                change.Fields = r.uvarint()
+                v := &change.Value
+`)
+//line generator/delta_go.qtpl:496
+			for i, f := range c.Fields {
+//line generator/delta_go.qtpl:497
+				if !f.IsDeprecated {
+//line generator/delta_go.qtpl:497
+					qw422016.N().S(`                if change.Fields&(1<<`)
+//line generator/delta_go.qtpl:498
+					qw422016.N().D(i)
+//line generator/delta_go.qtpl:498
+					qw422016.N().S(`) != 0 {
+                    `)
+//line generator/delta_go.qtpl:499
+					streamsnapshotReadField(qw422016, f, "v."+f.Name.Singular.Pascal)
+//line generator/delta_go.qtpl:499
+					qw422016.N().S(`                }
+`)
+//line generator/delta_go.qtpl:500
+				}
+//line generator/delta_go.qtpl:501
+			}
+//line generator/delta_go.qtpl:501
+			qw422016.N().S(`            }
+            d.`)
+//line generator/delta_go.qtpl:503
+			qw422016.E().S(nsp)
+//line generator/delta_go.qtpl:503
+			qw422016.N().S(`.Removed = r.entities()
+`)
+//line generator/delta_go.qtpl:504
+		}
+//line generator/delta_go.qtpl:505
+	}
+//line generator/delta_go.qtpl:505
+	qw422016.N().S(`        default:
+            return fmt.Errorf("unknown set %d in delta", id)
+        }
+    }
+    if r.err != nil {
+        return r.err
+    }
+    if r.off != len(r.buf) {
+        return errors.New("trailing data after delta")
+    }
+    return nil
+}
+
+`)
+//line generator/delta_go.qtpl:519
+}
+
+//line generator/delta_go.qtpl:519
+func writedeltaTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
+//line generator/delta_go.qtpl:519
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/delta_go.qtpl:519
+	streamdeltaTemplate(qw422016, data)
+//line generator/delta_go.qtpl:519
+	qt422016.ReleaseWriter(qw422016)
+//line generator/delta_go.qtpl:519
+}
+
+//line generator/delta_go.qtpl:519
+func deltaTemplate(data *ecsTmplData) string {
+//line generator/delta_go.qtpl:519
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/delta_go.qtpl:519
+	writedeltaTemplate(qb422016, data)
+//line generator/delta_go.qtpl:519
+	qs422016 := string(qb422016.B)
+//line generator/delta_go.qtpl:519
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/delta_go.qtpl:519
+	return qs422016
+//line generator/delta_go.qtpl:519
+}
@@ -0,0 +1,213 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/delaneyj/toolbelt"
+	"github.com/samber/lo"
+)
+
+// DiagnosticCode groups diagnostics by the kind of problem, so editors can
+// link to docs or offer fixes without parsing messages
+type DiagnosticCode string
+
+const (
+	CodeInvalid             DiagnosticCode = "invalid"
+	CodeDuplicateName       DiagnosticCode = "duplicate-name"
+	CodeReservedName        DiagnosticCode = "reserved-name"
+	CodeInflectionCollision DiagnosticCode = "inflection-collision"
+	CodeUnknownReference    DiagnosticCode = "unknown-reference"
+	CodeInvalidQuery        DiagnosticCode = "invalid-query"
+)
+
+// Diagnostic is a problem found in a config, Path locates the definition it is
+// about, e.g. bundles[1].components[3].fields[0]
+type Diagnostic struct {
+	Path    string         `json:"path"`
+	Code    DiagnosticCode `json:"code"`
+	Message string         `json:"message"`
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s", d.Path, d.Message)
+}
+
+// Diagnostics are every problem found in a config at once, they are an error
+// so BuildECS can return them as one
+type Diagnostics []Diagnostic
+
+func (ds Diagnostics) Error() string {
+	lines := lo.Map(ds, func(d Diagnostic, i int) string {
+		return d.String()
+	})
+	return strings.Join(lines, "\n")
+}
+
+func (ds *Diagnostics) add(path string, code DiagnosticCode, format string, args ...any) {
+	*ds = append(*ds, Diagnostic{
+		Path:    path,
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// bundlePath is where a bundle is in the config, the builtin bundle is always
+// prepended so user bundles keep the indices they have in the file
+func bundlePath(i int) string {
+	if i == 0 {
+		return "builtin"
+	}
+	return fmt.Sprintf("bundles[%d]", i-1)
+}
+
+// checkIdentifier reports names that cannot become the Go identifiers the
+// templates build from them
+func checkIdentifier(diags *Diagnostics, path, kind, original string, name InflectionString) bool {
+	if original == "" {
+		diags.add(path, CodeInvalid, "%s must have a name", kind)
+		return false
+	}
+	for _, form := range []string{name.Singular.Pascal, name.Plural.Pascal} {
+		if !token.IsIdentifier(form) {
+			diags.add(path, CodeReservedName, "%s '%s' does not make a valid Go identifier", kind, original)
+			return false
+		}
+	}
+	// camel cased names are used as struct fields and variables
+	for _, form := range []string{name.Singular.Camel, name.Plural.Camel} {
+		if token.IsKeyword(form) {
+			diags.add(path, CodeReservedName, "%s '%s' becomes the Go keyword '%s'", kind, original, form)
+			return false
+		}
+	}
+	return true
+}
+
+// reservedFieldNames are methods generated on every component struct
+var reservedFieldNames = map[string]bool{
+	"Clone": true,
+}
+
+// reservedTypeNames are the types every generated ECS declares
+var reservedTypeNames = map[string]bool{
+	"Access":                 true,
+	"CommandBuffer":          true,
+	"ComponentChange":        true,
+	"ComponentDelta":         true,
+	"ComponentID":            true,
+	"ComponentValue":         true,
+	"Delta":                  true,
+	"EntitiesCreatedEvent":   true,
+	"EntitiesDestroyedEvent": true,
+	"Entity":                 true,
+	"EntityBuilderOption":    true,
+	"Leftover":               true,
+	"Migration":              true,
+	"MigrationFunc":          true,
+	"RelationshipDelta":      true,
+	"ReliedOnIter":           true,
+	"Snapshot":               true,
+	"SparseSet":              true,
+	"System":                 true,
+	"SystemAccess":           true,
+	"SystemTicker":           true,
+	"TagDelta":               true,
+	"TickMode":               true,
+	"UnsubscribeFunc":        true,
+	"World":                  true,
+}
+
+// reservedWorldMethods are the methods every generated World has whatever the
+// config, component accessors are named after the component so they must not
+// collide with them
+var reservedWorldMethods = map[string]bool{
+	"AddSystems":          true,
+	"AdvanceChangeTick":   true,
+	"All":                 true,
+	"Ancestors":           true,
+	"ApplyDelta":          true,
+	"BreadthFirst":        true,
+	"ChangeTick":          true,
+	"Commands":            true,
+	"DepthFirst":          true,
+	"DestroyEntities":     true,
+	"Diff":                true,
+	"ExportJSON":          true,
+	"FlushCommands":       true,
+	"ImportJSON":          true,
+	"IsAlive":             true,
+	"IsAncestorOf":        true,
+	"LoadSnapshot":        true,
+	"Lookup":              true,
+	"LookupFrom":          true,
+	"MarshalBinary":       true,
+	"NextEntities":        true,
+	"NextEntity":          true,
+	"OnEntitiesCreated":   true,
+	"OnEntitiesDestroyed": true,
+	"ParentOf":            true,
+	"Path":                true,
+	"RegisterMigration":   true,
+	"Reparent":            true,
+	"Reset":               true,
+	"SaveSnapshot":        true,
+	"SetTickMode":         true,
+	"Snapshot":            true,
+	"Tick":                true,
+	"UnmarshalBinary":     true,
+}
+
+// accessorPrefixes start the World methods generated for every component, tag
+// and relationship, e.g. SetPosition and HasPosition
+var accessorPrefixes = []string{"", "Set", "Has", "Remove", "Must", "Mutable", "All"}
+
+// checkWorldMethods reports names whose accessors would redeclare a World
+// method, e.g. a component named Snapshot or TickMode
+func checkWorldMethods(diags *Diagnostics, path, kind, original string, name InflectionString) bool {
+	for _, form := range lo.Uniq([]string{name.Singular.Pascal, name.Plural.Pascal}) {
+		for _, prefix := range accessorPrefixes {
+			if method := prefix + form; reservedWorldMethods[method] {
+				diags.add(path, CodeReservedName, "%s '%s' would redeclare the World method '%s'", kind, original, method)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+type declaredName struct {
+	path, original string
+}
+
+// declaredNames tracks the Go identifiers already claimed by definitions, so a
+// collision is reported where the later definition is
+type declaredNames map[string]declaredName
+
+// declare claims both inflected forms of a name, two names collide when any of
+// their forms are the same even if they were spelled differently
+func (n declaredNames) declare(diags *Diagnostics, path, kind, original string, name InflectionString) bool {
+	forms := lo.Uniq([]string{name.Singular.Pascal, name.Plural.Pascal})
+	for _, form := range forms {
+		prev, ok := n[form]
+		if !ok {
+			continue
+		}
+		if toolbelt.Pascal(prev.original) == toolbelt.Pascal(original) {
+			diags.add(path, CodeDuplicateName, "%s '%s' is already declared at %s", kind, original, prev.path)
+		} else {
+			diags.add(
+				path, CodeInflectionCollision,
+				"%s '%s' collides with '%s' declared at %s, both become '%s' once inflected",
+				kind, original, prev.original, prev.path, form,
+			)
+		}
+		return false
+	}
+
+	for _, form := range forms {
+		n[form] = declaredName{path: path, original: original}
+	}
+	return true
+}
@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"testing"
+
+	geckpb "github.com/delaneyj/geck/pb/gen/geck/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func f32Field(name string) *geckpb.FieldDefinition {
+	return &geckpb.FieldDefinition{Name: name, ResetValue: &geckpb.FieldDefinition_F32{}}
+}
+
+// validOptions has two bundles so paths past the first of everything can be
+// checked
+func validOptions() *geckpb.GeneratorOptions {
+	return &geckpb.GeneratorOptions{
+		FolderPath: "ecs",
+		Bundles: []*geckpb.BundleDefinition{
+			{
+				Name: "physics",
+				Components: []*geckpb.ComponentDefinition{
+					{Name: "Position", Fields: []*geckpb.FieldDefinition{f32Field("X"), f32Field("Y")}},
+				},
+			},
+			{
+				Name: "game",
+				Enums: []*geckpb.Enum{
+					{Name: "Team", Values: []*geckpb.Enum_Value{{Name: "Red"}, {Name: "Blue", Value: 1}}},
+				},
+				Components: []*geckpb.ComponentDefinition{
+					{Name: "Frozen"},
+					{Name: "Likes", IsRelationship: true},
+					{Name: "Health", Fields: []*geckpb.FieldDefinition{f32Field("Current")}},
+					{Name: "Velocity", Fields: []*geckpb.FieldDefinition{f32Field("X"), f32Field("Y")}},
+				},
+			},
+		},
+		Queries: []*geckpb.QueryDefinition{
+			{
+				Alias: "Movable",
+				Entries: []*geckpb.QueryDefinition_ComponentOrTag{
+					{BundleName: "physics", Name: "Position", IsMutable: true},
+					{BundleName: "game", Name: "Velocity"},
+				},
+			},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	type located struct {
+		Path string
+		Code DiagnosticCode
+	}
+
+	for _, tc := range []struct {
+		name     string
+		modify   func(opts *geckpb.GeneratorOptions)
+		expected []located
+	}{
+		{
+			name:   "valid",
+			modify: func(opts *geckpb.GeneratorOptions) {},
+		},
+		{
+			name: "nested field",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Bundles[1].Components[3].Fields[0].Name = "type"
+			},
+			expected: []located{{"bundles[1].components[3].fields[0]", CodeReservedName}},
+		},
+		{
+			name: "duplicate field",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Bundles[1].Components[3].Fields[1].Name = "X"
+			},
+			expected: []located{{"bundles[1].components[3].fields[1]", CodeDuplicateName}},
+		},
+		{
+			name: "duplicate across bundles",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Bundles[1].Components[2].Name = "Position"
+			},
+			expected: []located{{"bundles[1].components[2]", CodeDuplicateName}},
+		},
+		{
+			name: "inflection collision",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Bundles[0].Components = append(opts.Bundles[0].Components,
+					&geckpb.ComponentDefinition{Name: "Data", Fields: []*geckpb.FieldDefinition{f32Field("V")}},
+				)
+				opts.Bundles[1].Components[2].Name = "Datum"
+			},
+			expected: []located{{"bundles[1].components[2]", CodeInflectionCollision}},
+		},
+		{
+			name: "unknown enum",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Bundles[1].Components[2].Fields = append(opts.Bundles[1].Components[2].Fields,
+					&geckpb.FieldDefinition{
+						Name:       "Side",
+						ResetValue: &geckpb.FieldDefinition_Enum{Enum: &geckpb.Enum_Value{Name: "Faction"}},
+					},
+				)
+			},
+			expected: []located{{"bundles[1].components[2].fields[1]", CodeUnknownReference}},
+		},
+		{
+			name: "mutable tag",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Queries[0].Entries = append(opts.Queries[0].Entries,
+					&geckpb.QueryDefinition_ComponentOrTag{BundleName: "game", Name: "Frozen", IsMutable: true},
+				)
+			},
+			expected: []located{{"queries[0].entries[2]", CodeInvalidQuery}},
+		},
+		{
+			name: "unknown query term",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Queries[0].Entries[1].Name = "Acceleration"
+			},
+			expected: []located{{"queries[0].entries[1]", CodeUnknownReference}},
+		},
+		{
+			name: "world method",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Bundles[1].Components[2].Name = "Snapshot"
+			},
+			expected: []located{{"bundles[1].components[2]", CodeReservedName}},
+		},
+		{
+			name: "prefixed world method",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Bundles[0].Components[0].Name = "TickMode"
+			},
+			expected: []located{
+				{"bundles[0].components[0]", CodeReservedName},
+				{"queries[0].entries[0]", CodeUnknownReference},
+			},
+		},
+		{
+			name: "inflected world method",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Bundles[1].Components[1].Name = "Commands"
+				opts.Bundles[1].Components[2].Name = "Diffs"
+			},
+			expected: []located{
+				{"bundles[1].components[1]", CodeReservedName},
+				{"bundles[1].components[2]", CodeReservedName},
+			},
+		},
+		{
+			name: "every problem at once",
+			modify: func(opts *geckpb.GeneratorOptions) {
+				opts.Bundles[0].Components[0].Fields[0].Name = "Clone"
+				opts.Bundles[1].Components[0].Name = "Tick"
+				opts.Queries = append(opts.Queries, &geckpb.QueryDefinition{Alias: "Empty"})
+			},
+			expected: []located{
+				{"bundles[0].components[0].fields[0]", CodeReservedName},
+				{"bundles[1].components[0]", CodeReservedName},
+				{"queries[1]", CodeInvalidQuery},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := validOptions()
+			tc.modify(opts)
+
+			var actual []located
+			for _, d := range Validate(opts) {
+				assert.NotEmpty(t, d.Message)
+				actual = append(actual, located{d.Path, d.Code})
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestValidateLeavesOptionsAlone(t *testing.T) {
+	opts := validOptions()
+	Validate(opts)
+	assert.Empty(t, opts.PackageName)
+}
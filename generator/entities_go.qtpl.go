@@ -30,6 +30,11 @@ package `)
 //line generator/entities_go.qtpl:4
 	qw422016.N().S(`
 
+import (
+    "fmt"
+    "slices"
+)
+
 const (
 	indexBits      = 20
 	generationBits = 12
@@ -43,7 +48,7 @@ var Tombstone = Entity(maxEntities)
 type Entity uint32
 
 func NewEntity(index, generation int) Entity {
-	return Entity((index << generationBits) | generation)
+	return Entity((index << generationBits) | (generation & generationMask))
 }
 
 func (e Entity) Index() int {
@@ -51,7 +56,13 @@ func (e Entity) Index() int {
 }
 
 func (e Entity) Generation() int {
-	return  int(e) & generationMask
+	return int(e) & generationMask
+}
+
+// NextGeneration returns the handle the entity's index will be reused with
+// once it has been destroyed, wrapping around after 12 bits.
+func (e Entity) NextGeneration() Entity {
+	return NewEntity(e.Index(), e.Generation()+1)
 }
 
 func EntityFromU32(u uint32) Entity {
@@ -100,6 +111,7 @@ func(w *World) NextEntities(count int, opts ...EntityBuilderOption) []Entity{
             entity = NewEntity(w.nextEntityID,0)
             w.nextEntityID++
         } else {
+            // free entities are stored with their generation already bumped
             entity = w.freeEntities.dense[0]
             w.freeEntities.Remove(entity)
         }
@@ -110,6 +122,11 @@ func(w *World) NextEntities(count int, opts ...EntityBuilderOption) []Entity{
 			opt(w, entity)
 		}
     }
+
+    if count > 0 {
+        fireEvent(w, EntitiesCreatedEvent{Entities: entities})
+    }
+
     return entities
 }
 
@@ -118,46 +135,309 @@ func (w *World) NextEntity(opts ...EntityBuilderOption) Entity {
 }
 
 func (w *World) DestroyEntities(entities ...Entity) {
-	for _, entity := range entities {
+`)
+//line generator/entities_go.qtpl:111
+	if data.HasPanicOnTargetDeleted() {
+//line generator/entities_go.qtpl:111
+		qw422016.N().S(`	// everything the batch destroys, including sources deleted with their
+	// target, is checked first so a panic never leaves it half destroyed
+	batch := map[Entity]bool{}
+	pending := slices.Clone(entities)
+	for len(pending) > 0 {
+		entity := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if batch[entity] || !w.IsAlive(entity) {
+			continue
+		}
+		batch[entity] = true
+`)
+//line generator/entities_go.qtpl:123
+		for _, c := range data.Components {
+//line generator/entities_go.qtpl:124
+			if c.IsRelationship && c.ShouldDeleteSourcesWithTarget {
+//line generator/entities_go.qtpl:124
+				qw422016.N().S(`		pending = slices.AppendSeq(pending, w.`)
+//line generator/entities_go.qtpl:125
+				qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/entities_go.qtpl:125
+				qw422016.N().S(`(entity))
+`)
+//line generator/entities_go.qtpl:126
+			}
+//line generator/entities_go.qtpl:127
+		}
+//line generator/entities_go.qtpl:127
+		qw422016.N().S(`	}
+	// sources destroyed in the same batch do not block their target
+	for entity := range batch {
+`)
+//line generator/entities_go.qtpl:131
+		for _, c := range data.Components {
+//line generator/entities_go.qtpl:132
+			if c.IsRelationship && c.ShouldPanicOnTargetDeleted {
+//line generator/entities_go.qtpl:132
+				qw422016.N().S(`		if w.`)
+//line generator/entities_go.qtpl:133
+				qw422016.E().S(c.Name.Singular.Camel)
+//line generator/entities_go.qtpl:133
+				qw422016.N().S(`Relationships.hasSourcesOutside(entity, batch) {
+			panic(fmt.Sprintf("cannot destroy entity %d, it is the target of `)
+//line generator/entities_go.qtpl:134
+				qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/entities_go.qtpl:134
+				qw422016.N().S(` relationships", entity))
+		}
+`)
+//line generator/entities_go.qtpl:136
+			}
+//line generator/entities_go.qtpl:137
+		}
+//line generator/entities_go.qtpl:137
+		qw422016.N().S(`	}
+`)
+//line generator/entities_go.qtpl:139
+	}
+//line generator/entities_go.qtpl:139
+	qw422016.N().S(`
+	w.destroyEntities(entities)
+}
+
+// destroyEntities destroys without checking, sources deleted with their target
+// were already checked as part of the batch that destroyed it. They join the
+// batch so the events fire once for everything destroyed.
+func (w *World) destroyEntities(entities []Entity) {
+	destroyed := make([]Entity, 0, len(entities))
+`)
+//line generator/entities_go.qtpl:149
+	for _, c := range data.Components {
+//line generator/entities_go.qtpl:150
+		if c.IsTag && c.ShouldGenRemoved {
+//line generator/entities_go.qtpl:150
+			qw422016.N().S(`	var `)
+//line generator/entities_go.qtpl:151
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/entities_go.qtpl:151
+			qw422016.N().S(`Removed []Entity
+`)
+//line generator/entities_go.qtpl:152
+		}
+//line generator/entities_go.qtpl:153
+	}
+//line generator/entities_go.qtpl:153
+	qw422016.N().S(`	pending := slices.Clone(entities)
+	for i := 0; i < len(pending); i++ {
+		entity := pending[i]
+		if !w.IsAlive(entity) {
+			// already destroyed or a stale handle to a recycled index
+			continue
+		}
+		destroyed = append(destroyed, entity)
+
+		// observers see every query the entity matched exit
+`)
+//line generator/entities_go.qtpl:164
+	for _, q := range data.Queries {
+//line generator/entities_go.qtpl:165
+		if q.IsObservable() {
+//line generator/entities_go.qtpl:165
+			qw422016.N().S(`		was`)
+//line generator/entities_go.qtpl:166
+			qw422016.E().S(q.Name.Singular.Pascal)
+//line generator/entities_go.qtpl:166
+			qw422016.N().S(` := w.`)
+//line generator/entities_go.qtpl:166
+			qw422016.E().S(q.Name.Singular.Camel)
+//line generator/entities_go.qtpl:166
+			qw422016.N().S(`Observed(entity)
+`)
+//line generator/entities_go.qtpl:167
+		}
+//line generator/entities_go.qtpl:168
+	}
+//line generator/entities_go.qtpl:168
+	qw422016.N().S(`
 		w.livingEntities.Remove(entity)
-		w.freeEntities.Upsert(entity,empty{})
+		w.freeEntities.Upsert(entity.NextGeneration(), empty{})
 
 `)
-//line generator/entities_go.qtpl:98
+//line generator/entities_go.qtpl:173
+	for _, q := range data.Queries {
+//line generator/entities_go.qtpl:174
+		if q.IsOwningGroup {
+//line generator/entities_go.qtpl:174
+			qw422016.N().S(`		w.`)
+//line generator/entities_go.qtpl:175
+			qw422016.E().S(q.Name.Singular.Camel)
+//line generator/entities_go.qtpl:175
+			qw422016.N().S(`GroupRemove(entity)
+`)
+//line generator/entities_go.qtpl:176
+		}
+//line generator/entities_go.qtpl:177
+	}
+//line generator/entities_go.qtpl:177
+	qw422016.N().S(`
+`)
+//line generator/entities_go.qtpl:179
 	for _, c := range data.Components {
-//line generator/entities_go.qtpl:99
-		if c.IsTag {
-//line generator/entities_go.qtpl:99
+//line generator/entities_go.qtpl:180
+		if c.IsRelationship && c.ShouldDeleteSourcesWithTarget {
+//line generator/entities_go.qtpl:180
+			qw422016.N().S(`		// sources of `)
+//line generator/entities_go.qtpl:181
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/entities_go.qtpl:181
+			qw422016.N().S(` are destroyed along with their target
+		pending = slices.AppendSeq(pending, w.`)
+//line generator/entities_go.qtpl:182
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/entities_go.qtpl:182
+			qw422016.N().S(`(entity))
+`)
+//line generator/entities_go.qtpl:183
+		}
+//line generator/entities_go.qtpl:184
+	}
+//line generator/entities_go.qtpl:184
+	qw422016.N().S(`
+`)
+//line generator/entities_go.qtpl:186
+	for _, c := range data.Components {
+//line generator/entities_go.qtpl:187
+		if c.IsTag && c.ShouldGenRemoved {
+//line generator/entities_go.qtpl:187
+			qw422016.N().S(`		if w.`)
+//line generator/entities_go.qtpl:188
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/entities_go.qtpl:188
+			qw422016.N().S(`Tags.Remove(entity) {
+			`)
+//line generator/entities_go.qtpl:189
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/entities_go.qtpl:189
+			qw422016.N().S(`Removed = append(`)
+//line generator/entities_go.qtpl:189
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/entities_go.qtpl:189
+			qw422016.N().S(`Removed, entity)
+		}
+`)
+//line generator/entities_go.qtpl:191
+		} else if c.IsTag {
+//line generator/entities_go.qtpl:191
 			qw422016.N().S(`		w.`)
-//line generator/entities_go.qtpl:100
+//line generator/entities_go.qtpl:192
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/entities_go.qtpl:100
+//line generator/entities_go.qtpl:192
 			qw422016.N().S(`Tags.Remove(entity)
 `)
-//line generator/entities_go.qtpl:101
+//line generator/entities_go.qtpl:193
+		} else if c.IsRelationship && c.ShouldGenRemoved {
+//line generator/entities_go.qtpl:193
+			qw422016.N().S(`		for _, pair := range w.`)
+//line generator/entities_go.qtpl:194
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/entities_go.qtpl:194
+			qw422016.N().S(`Relationships.removeEntity(entity) {
+			fireEvent(w, `)
+//line generator/entities_go.qtpl:195
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/entities_go.qtpl:195
+			qw422016.N().S(`UnlinkedEvent{Pair: pair})
+		}
+`)
+//line generator/entities_go.qtpl:197
 		} else if c.IsRelationship {
-//line generator/entities_go.qtpl:101
+//line generator/entities_go.qtpl:197
 			qw422016.N().S(`		w.`)
-//line generator/entities_go.qtpl:102
+//line generator/entities_go.qtpl:198
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/entities_go.qtpl:102
-			qw422016.N().S(`Relationships.Clear()
+//line generator/entities_go.qtpl:198
+			qw422016.N().S(`Relationships.removeEntity(entity)
 `)
-//line generator/entities_go.qtpl:103
+//line generator/entities_go.qtpl:199
+		} else if c.ShouldGenRemoved {
+//line generator/entities_go.qtpl:199
+			qw422016.N().S(`		if w.`)
+//line generator/entities_go.qtpl:200
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/entities_go.qtpl:200
+			qw422016.N().S(`Components.Remove(entity) {
+			fireEvent(w, `)
+//line generator/entities_go.qtpl:201
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/entities_go.qtpl:201
+			qw422016.N().S(`RemovedEvent{Entity: entity})
+		}
+`)
+//line generator/entities_go.qtpl:203
 		} else {
-//line generator/entities_go.qtpl:103
+//line generator/entities_go.qtpl:203
 			qw422016.N().S(`		w.`)
-//line generator/entities_go.qtpl:104
+//line generator/entities_go.qtpl:204
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/entities_go.qtpl:104
+//line generator/entities_go.qtpl:204
 			qw422016.N().S(`Components.Remove(entity)
 `)
-//line generator/entities_go.qtpl:105
+//line generator/entities_go.qtpl:205
+		}
+//line generator/entities_go.qtpl:206
+	}
+//line generator/entities_go.qtpl:206
+	qw422016.N().S(`
+`)
+//line generator/entities_go.qtpl:208
+	for _, q := range data.Queries {
+//line generator/entities_go.qtpl:209
+		if q.IsObservable() {
+//line generator/entities_go.qtpl:209
+			qw422016.N().S(`		w.`)
+//line generator/entities_go.qtpl:210
+			qw422016.E().S(q.Name.Singular.Camel)
+//line generator/entities_go.qtpl:210
+			qw422016.N().S(`Notify(entity, was`)
+//line generator/entities_go.qtpl:210
+			qw422016.E().S(q.Name.Singular.Pascal)
+//line generator/entities_go.qtpl:210
+			qw422016.N().S(`)
+`)
+//line generator/entities_go.qtpl:211
 		}
-//line generator/entities_go.qtpl:106
+//line generator/entities_go.qtpl:212
 	}
-//line generator/entities_go.qtpl:106
+//line generator/entities_go.qtpl:212
 	qw422016.N().S(`	}
+
+`)
+//line generator/entities_go.qtpl:215
+	for _, c := range data.Components {
+//line generator/entities_go.qtpl:216
+		if c.IsTag && c.ShouldGenRemoved {
+//line generator/entities_go.qtpl:216
+			qw422016.N().S(`	if len(`)
+//line generator/entities_go.qtpl:217
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/entities_go.qtpl:217
+			qw422016.N().S(`Removed) > 0 {
+		fireEvent(w, `)
+//line generator/entities_go.qtpl:218
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/entities_go.qtpl:218
+			qw422016.N().S(`RemovedEvent{Entities: `)
+//line generator/entities_go.qtpl:218
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/entities_go.qtpl:218
+			qw422016.N().S(`Removed})
+	}
+`)
+//line generator/entities_go.qtpl:220
+		}
+//line generator/entities_go.qtpl:221
+	}
+//line generator/entities_go.qtpl:221
+	qw422016.N().S(`	if len(destroyed) > 0 {
+		fireEvent(w, EntitiesDestroyedEvent{Entities: destroyed})
+	}
 }
 
 func (w *World) IsAlive(entity Entity) bool {
@@ -173,31 +453,31 @@ func (w *World) All(yield func(entity Entity) bool) {
 }
 
 `)
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 }
 
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 func writeentitiesTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 	streamentitiesTemplate(qw422016, data)
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 	qt422016.ReleaseWriter(qw422016)
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 }
 
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 func entitiesTemplate(data *ecsTmplData) string {
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 	qb422016 := qt422016.AcquireByteBuffer()
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 	writeentitiesTemplate(qb422016, data)
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 	qs422016 := string(qb422016.B)
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 	qt422016.ReleaseByteBuffer(qb422016)
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 	return qs422016
-//line generator/entities_go.qtpl:122
+//line generator/entities_go.qtpl:239
 }
@@ -0,0 +1,146 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// formatGo drops the unused imports of generated Go source and gofmts it,
+// without shelling out to goimports
+func formatGo(filename string, src []byte) ([]byte, error) {
+	fixed, err := fixImports(filename, src)
+	if err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source(fixed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format '%s': %w", filename, err)
+	}
+	return formatted, nil
+}
+
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// importName is the name a package is referred to by when it is not renamed
+func importName(importPath string) string {
+	name := path.Base(importPath)
+	if majorVersion.MatchString(name) {
+		name = path.Base(path.Dir(importPath))
+	}
+	return name
+}
+
+type importSpec struct {
+	name, path string
+}
+
+// fixImports drops the unused imports, templates declare every package they
+// might refer to, then rewrites the imports as a single block with the standard
+// library first
+func fixImports(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse '%s': %w", filename, err)
+	}
+
+	// generated code never shadows a package name, so any selector on an
+	// identifier named like an import uses it
+	used := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok {
+				used[x.Name] = true
+			}
+		}
+		return true
+	})
+
+	var specs []importSpec
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			return nil, fmt.Errorf("bad import in '%s': %w", filename, err)
+		}
+		spec := importSpec{path: importPath}
+		name := importName(importPath)
+		if imp.Name != nil {
+			spec.name = imp.Name.Name
+			name = imp.Name.Name
+		}
+		if name != "_" && name != "." && !used[name] {
+			continue
+		}
+		specs = append(specs, spec)
+	}
+
+	start, end := file.Name.End(), file.Name.End()
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			if start == file.Name.End() {
+				start = gen.Pos()
+			}
+			end = gen.End()
+		}
+	}
+	startOffset, endOffset := fset.Position(start).Offset, fset.Position(end).Offset
+
+	var sb strings.Builder
+	sb.Write(src[:startOffset])
+	if start == file.Name.End() {
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(importDecl(specs))
+	sb.Write(src[endOffset:])
+	return []byte(sb.String()), nil
+}
+
+func importDecl(specs []importSpec) string {
+	if len(specs) == 0 {
+		return ""
+	}
+
+	line := func(spec importSpec) string {
+		if spec.name == "" {
+			return strconv.Quote(spec.path)
+		}
+		return spec.name + " " + strconv.Quote(spec.path)
+	}
+	if len(specs) == 1 {
+		return "import " + line(specs[0])
+	}
+
+	// standard library paths have no dot in their first element
+	isStd := func(spec importSpec) bool {
+		first, _, _ := strings.Cut(spec.path, "/")
+		return !strings.Contains(first, ".")
+	}
+	slices.SortFunc(specs, func(a, b importSpec) int {
+		if isStd(a) != isStd(b) {
+			if isStd(a) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.path, b.path)
+	})
+
+	var sb strings.Builder
+	sb.WriteString("import (\n")
+	for i, spec := range specs {
+		if i > 0 && isStd(specs[i-1]) != isStd(spec) {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\t" + line(spec) + "\n")
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"go/token"
 	"log"
 	"os"
 	"os/exec"
@@ -39,7 +40,9 @@ type enumTmplData struct {
 type ecsTmplData struct {
 	PackageName string
 	FolderPath  string
+	Version     uint32
 	Enums       []*enumTmplData
+	Structs     []*structTmplData
 	Components  []*componentTmplData
 	Queries     []*queryTmplData
 }
@@ -50,6 +53,21 @@ type fieldTemplateData struct {
 	ResetValue           string
 	IsSlice, IsEntity    bool
 	IsEntityRelationship bool
+
+	// Kind is the snapshot encoding of each value, ElemType its Go type
+	Kind, ElemType string
+	IsDeprecated   bool
+	// Struct is set for fields holding a struct or mathx value
+	Struct *structTmplData
+}
+
+// FromValuesArg is what FromValues helpers set the field to, deprecated
+// fields have no argument
+func (f fieldTemplateData) FromValuesArg() string {
+	if f.IsDeprecated {
+		return f.ResetValue
+	}
+	return f.Name.Singular.Camel + "Arg"
 }
 
 type componentTmplData struct {
@@ -64,131 +82,200 @@ type componentTmplData struct {
 	IsOnlyOneField, IsFirstFieldEntity, IsFirstSlice   bool
 	ShouldGenAdded, ShouldGenRemoved, ShouldGenChanged bool
 	HasAnyEvents                                       bool
+	ShouldDeleteSourcesWithTarget                      bool
+	ShouldPanicOnTargetDeleted                         bool
 	ResetValue                                         string
 	OwnedBySet                                         *queryTmplData
+	ShouldTrackChanges                                 bool
+	// ObservedBy are the observable queries whose membership writes to this
+	// component or tag can change
+	ObservedBy []*queryTmplData
 }
 
 type queryEntryTmplData struct {
-	BundleName     toolbelt.CasedString
-	Name           InflectionString
-	IsMutable      bool
-	ComponentOrTag *componentTmplData
+	BundleName            toolbelt.CasedString
+	Name                  InflectionString
+	IsMutable             bool
+	IsWithout, IsOptional bool
+	IsOr                  bool
+	ComponentOrTag        *componentTmplData
+
+	// Source and Target are earlier terms providing the entity to match
+	// against, a nil Source matches the iterated entity
+	Source, Target          *queryEntryTmplData
+	IsTargetArg, IsWildcard bool
+
+	// IsChanged and IsAdded filter required components by their change ticks
+	IsChanged, IsAdded bool
+	// IsInherited also matches components inherited from IsA prefabs
+	IsInherited bool
+}
+
+type queryOrGroupTmplData struct {
+	Name    string
+	Entries []*queryEntryTmplData
 }
 
 type queryTmplData struct {
-	PackageName string
-	Folder      string
-	Name        InflectionString
-	Entries     []*queryEntryTmplData
+	PackageName   string
+	Folder        string
+	Name          InflectionString
+	Entries       []*queryEntryTmplData
+	Required      []*queryEntryTmplData
+	Joins         []*queryEntryTmplData
+	Inherited     []*queryEntryTmplData
+	OrGroups      []*queryOrGroupTmplData
+	IsOwningGroup bool
 }
 
-func BuildECS(ctx context.Context, opts *geckpb.GeneratorOptions) error {
+// BuildOption changes how BuildECS writes the folder
+type BuildOption func(*output)
+
+// WithForce lets BuildECS overwrite files it generates the names of even when
+// they lack GeneratedHeader, to take over folders generated before headers
+func WithForce() BuildOption {
+	return func(o *output) {
+		o.force = true
+	}
+}
+
+func BuildECS(ctx context.Context, opts *geckpb.GeneratorOptions, buildOpts ...BuildOption) error {
 	log.Printf("Building ECS in '%s'", opts.FolderPath)
 	start := time.Now()
 	defer log.Printf("Finished building ECS in '%s'", opts.FolderPath)
 
-	opts.Bundles = append(
-		[]*geckpb.BundleDefinition{builtinBundle},
-		opts.Bundles...,
-	)
+	out, err := render(opts.CloneVT())
+	if err != nil {
+		return err
+	}
+	for _, buildOpt := range buildOpts {
+		buildOpt(out)
+	}
 
-	// Create the folder
-	if err := os.RemoveAll(opts.FolderPath); err != nil {
-		return fmt.Errorf("failed to remove folder: %w", err)
+	log.Printf("Writing files")
+	if err := out.write(); err != nil {
+		return fmt.Errorf("failed to write files: %w", err)
 	}
 
-	if err := os.MkdirAll(opts.FolderPath, 0755); err != nil {
-		return fmt.Errorf("failed to create folder: %w", err)
+	if len(opts.PostGenerateCommands) > 0 {
+		log.Printf("Running post generation commands")
+		dir, err := filepath.Abs(opts.FolderPath)
+		if err != nil {
+			return fmt.Errorf("failed to get absolute path: %w", err)
+		}
+
+		for _, cmd := range opts.PostGenerateCommands {
+			parts := strings.Fields(cmd)
+			if len(parts) == 0 {
+				continue
+			}
+			log.Printf("Running: '%s' inside '%s'", cmd, dir)
+			c := exec.CommandContext(ctx, parts[0], parts[1:]...)
+			c.Dir = dir
+			c.Stdout = os.Stdout
+			c.Stderr = os.Stderr
+			if err := c.Run(); err != nil {
+				return fmt.Errorf("post generation command '%s' failed: %w", cmd, err)
+			}
+		}
 	}
 
-	log.Printf("Converting options to data")
-	data, err := optsToData(opts)
+	log.Printf("Generating ECS took %s", time.Since(start))
+	return nil
+}
+
+// Render generates every file in memory without writing anything or running
+// the post generation commands, files are keyed by their name in the folder
+func Render(opts *geckpb.GeneratorOptions) (map[string][]byte, error) {
+	out, err := render(opts.CloneVT())
 	if err != nil {
-		return fmt.Errorf("failed to convert options to data: %w", err)
+		return nil, err
+	}
+	return out.files, nil
+}
+
+// render generates every file in memory
+func render(opts *geckpb.GeneratorOptions) (*output, error) {
+	log.Printf("Converting options to data")
+	data, diags := optsToData(opts)
+	if len(diags) > 0 {
+		return nil, diags
 	}
 
+	out := newOutput(opts.FolderPath)
+
 	log.Printf("Generating universal files")
 	if err := errors.Join(
-		generateFile("world.go", data, worldTemplate),
-		generateFile("sparse_set.go", data, sparseSetTemplate),
-		generateFile("entities.go", data, entitiesTemplate),
-		generateFile("events.go", data, eventsTemplate),
-		generateFile("web.go", data, webTemplate),
-		generateFile("web_templates.templ", data, templTemplate),
+		generateFile(out, "world.go", data, worldTemplate),
+		generateFile(out, "sparse_set.go", data, sparseSetTemplate),
+		generateFile(out, "entities.go", data, entitiesTemplate),
+		generateFile(out, "prefabs.go", data, prefabsTemplate),
+		generateFile(out, "hierarchy.go", data, hierarchyTemplate),
+		generateFile(out, "events.go", data, eventsTemplate),
+		generateFile(out, "systems.go", data, systemsTemplate),
+		generateFile(out, "commands.go", data, commandsTemplate),
+		generateFile(out, "snapshot.go", data, snapshotTemplate),
+		generateFile(out, "json.go", data, jsonTemplate),
+		generateFile(out, "delta.go", data, deltaTemplate),
+		generateFile(out, "web.go", data, webTemplate),
+		generateFile(out, "web_templates.templ", data, templTemplate),
 	); err != nil {
 
-		return fmt.Errorf("failed to generate top level files: %w", err)
+		return nil, fmt.Errorf("failed to generate top level files: %w", err)
 	}
 
 	log.Printf("Generating enum files")
 	for _, enum := range data.Enums {
-		if err := generateEnum(enum); err != nil {
-			return fmt.Errorf("failed to generate enum: %w", err)
+		if err := generateEnum(out, enum); err != nil {
+			return nil, fmt.Errorf("failed to generate enum: %w", err)
+		}
+	}
+
+	log.Printf("Generating struct files")
+	for _, s := range data.Structs {
+		if err := generateStruct(out, s); err != nil {
+			return nil, fmt.Errorf("failed to generate struct: %w", err)
 		}
 	}
 
 	log.Printf("Generating component files")
 	for _, component := range data.Components {
-		if err := generateComponent(component); err != nil {
-			return fmt.Errorf("failed to generate component: %w", err)
+		if err := generateComponent(out, component); err != nil {
+			return nil, fmt.Errorf("failed to generate component: %w", err)
 		}
 	}
 
 	log.Printf("Generating query files")
 	for _, query := range data.Queries {
-		if err := generateQueries(query); err != nil {
-			return fmt.Errorf("failed to generate query: %w", err)
+		if err := generateQueries(out, query); err != nil {
+			return nil, fmt.Errorf("failed to generate query: %w", err)
 		}
 	}
 
-	log.Printf("Running post generation commands")
-	type postGenCmdSet struct {
-		subdir string
-		cmds   []string
-	}
-	postGenCmds := []postGenCmdSet{
-		{
-			subdir: "",
-			cmds: []string{
-				"go install github.com/valyala/quicktemplate/qtc@latest",
-				"templ generate",
-				"go install golang.org/x/tools/cmd/goimports@latest",
-				"goimports -w .",
-				"go mod tidy",
-			},
-		},
-	}
-	for _, set := range postGenCmds {
-		dir, err := filepath.Abs(filepath.Join(opts.FolderPath, set.subdir))
-		if err != nil {
-			return fmt.Errorf("failed to get absolute path: %w", err)
-		}
-
-		for _, cmd := range set.cmds {
-			log.Printf("Running: '%s' inside '%s'", cmd, dir)
-			parts := strings.Split(cmd, " ")
-			c := exec.Command(parts[0], parts[1:]...)
-			c.Dir = dir
-			c.Stdout = os.Stdout
-			c.Stderr = os.Stderr
-			if err := c.Run(); err != nil {
-				log.Printf("ERROR! failed to run command: %s", err)
-			}
-		}
-	}
+	return out, nil
+}
 
-	log.Printf("Generating ECS took %s", time.Since(start))
-	return nil
+// Validate checks the options the way BuildECS would without writing anything,
+// returning every problem found
+func Validate(opts *geckpb.GeneratorOptions) Diagnostics {
+	_, diags := optsToData(opts.CloneVT())
+	return diags
 }
 
-func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, err error) {
+// optsToData converts the options for the templates, every problem found is
+// returned as a located diagnostic instead of stopping at the first
+func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, diags Diagnostics) {
 	if opts.PackageName == "" {
 		opts.PackageName = filepath.Base(opts.FolderPath)
 	}
+	if !token.IsIdentifier(opts.PackageName) || token.IsKeyword(opts.PackageName) {
+		diags.add("packageName", CodeReservedName, "package name '%s' is not a valid Go identifier", opts.PackageName)
+	}
 
 	data = &ecsTmplData{
 		PackageName: opts.PackageName,
 		FolderPath:  opts.FolderPath,
+		Version:     opts.Version,
 	}
 
 	inflectionStrings := func(s string, shouldInflect bool) InflectionString {
@@ -203,14 +290,15 @@ func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, err error) {
 		}
 	}
 
-	componentByNames := map[string]map[string]*componentTmplData{}
-	for _, bundleDef := range opts.Bundles {
+	bundles := append([]*geckpb.BundleDefinition{builtinBundle}, opts.Bundles...)
+
+	// enums are converted first so fields can refer to enums of any bundle
+	enumNames := declaredNames{}
+	for bi, bundleDef := range bundles {
 		bundleName := toolbelt.ToCasedString(bundleDef.Name)
 
-		for _, ed := range bundleDef.Enums {
-			if len(ed.Values) == 0 {
-				return nil, fmt.Errorf("enum '%s' must have at least one value", ed.Name)
-			}
+		for ei, ed := range bundleDef.Enums {
+			path := fmt.Sprintf("%s.enums[%d]", bundlePath(bi), ei)
 
 			enum := &enumTmplData{
 				PackageName: data.PackageName,
@@ -218,24 +306,43 @@ func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, err error) {
 				BundleName:  bundleName,
 				Name:        inflectionStrings(ed.Name, true),
 				IsBitmask:   ed.IsBitmask,
-				Values: lo.Map(ed.Values, func(v *geckpb.Enum_Value, i int) *enumEntryTmplData {
-					return &enumEntryTmplData{
-						Name:  inflectionStrings(v.Name, true),
-						Value: int(v.Value),
-					}
-				}),
+			}
+			if !checkIdentifier(&diags, path, "enum", ed.Name, enum.Name) ||
+				!enumNames.declare(&diags, path, "enum", ed.Name, enum.Name) {
+				continue
+			}
+
+			if len(ed.Values) == 0 {
+				diags.add(path, CodeInvalid, "enum '%s' must have at least one value", ed.Name)
+				continue
+			}
+
+			valueNames := declaredNames{}
+			usedValues := map[int32]string{}
+			for vi, v := range ed.Values {
+				valuePath := fmt.Sprintf("%s.values[%d]", path, vi)
+				name := inflectionStrings(v.Name, true)
+				if !checkIdentifier(&diags, valuePath, "enum value", v.Name, name) ||
+					!valueNames.declare(&diags, valuePath, "enum value", v.Name, name) {
+					continue
+				}
+				if prev, ok := usedValues[v.Value]; ok {
+					diags.add(valuePath, CodeDuplicateName, "enum value %d is already used by '%s'", v.Value, prev)
+					continue
+				}
+				usedValues[v.Value] = v.Name
+
+				enum.Values = append(enum.Values, &enumEntryTmplData{
+					Name:  name,
+					Value: int(v.Value),
+				})
 			}
 			slices.SortFunc(enum.Values, func(a, b *enumEntryTmplData) int {
 				return a.Value - b.Value
 			})
 
-			if len(enum.Values) != len(lo.UniqBy(enum.Values, func(e *enumEntryTmplData) int {
-				return e.Value
-			})) {
-				return nil, fmt.Errorf("enum values must be unique")
-			}
-
-			if enum.Values[0].Value != 0 {
+			// kept even with bad values so fields using it are not reported too
+			if len(enum.Values) > 0 && enum.Values[0].Value != 0 {
 				enum.Values = append([]*enumEntryTmplData{
 					{
 						Name:  inflectionStrings("Unknown", false),
@@ -246,9 +353,256 @@ func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, err error) {
 
 			data.Enums = append(data.Enums, enum)
 		}
+	}
+
+	// resolveStruct converts a struct the first time a field refers to it, so
+	// structs can be used before they are declared
+	var resolveStruct func(path, name string) (*structTmplData, bool)
+
+	// fieldData converts a single field, struct fields only hold plain values
+	// so structs copy and compare whole
+	fieldData := func(path string, f *geckpb.FieldDefinition, inStruct bool) (ftd fieldTemplateData, ok bool) {
+		ftd = fieldTemplateData{
+			Name:         inflectionStrings(f.Name, false),
+			Description:  f.Description,
+			IsSlice:      f.HasMultiple,
+			IsDeprecated: f.IsDeprecated,
+		}
+
+		var typ string
+		switch f.ResetValue.(type) {
+		case *geckpb.FieldDefinition_U8:
+			typ = "uint8"
+			ftd.Kind = "U8"
+			ftd.ResetValue = fmt.Sprintf("%d", f.GetU8())
+		case *geckpb.FieldDefinition_U16:
+			typ = "uint16"
+			ftd.Kind = "U16"
+			ftd.ResetValue = fmt.Sprintf("%d", f.GetU16())
+		case *geckpb.FieldDefinition_U32:
+			typ = "uint32"
+			ftd.Kind = "U32"
+			ftd.ResetValue = fmt.Sprintf("%d", f.GetU32())
+		case *geckpb.FieldDefinition_U64:
+			typ = "uint64"
+			ftd.Kind = "U64"
+			ftd.ResetValue = fmt.Sprintf("%d", f.GetU64())
+		case *geckpb.FieldDefinition_I8:
+			typ = "int8"
+			ftd.Kind = "I8"
+			ftd.ResetValue = fmt.Sprintf("%d", f.GetI8())
+		case *geckpb.FieldDefinition_I16:
+			typ = "int16"
+			ftd.Kind = "I16"
+			ftd.ResetValue = fmt.Sprintf("%d", f.GetI16())
+		case *geckpb.FieldDefinition_I32:
+			typ = "int32"
+			ftd.Kind = "I32"
+			ftd.ResetValue = fmt.Sprintf("%d", f.GetI32())
+		case *geckpb.FieldDefinition_I64:
+			typ = "int64"
+			ftd.Kind = "I64"
+			ftd.ResetValue = fmt.Sprintf("%d", f.GetI64())
+		case *geckpb.FieldDefinition_F32:
+			typ = "float32"
+			ftd.Kind = "F32"
+			ftd.ResetValue = fmt.Sprintf("%f", f.GetF32())
+		case *geckpb.FieldDefinition_F64:
+			typ = "float64"
+			ftd.Kind = "F64"
+			ftd.ResetValue = fmt.Sprintf("%f", f.GetF64())
+		case *geckpb.FieldDefinition_Txt:
+			typ = "string"
+			ftd.Kind = "Txt"
+			ftd.ResetValue = fmt.Sprintf(`"%s"`, f.GetTxt())
+		case *geckpb.FieldDefinition_Bin:
+			typ = "[]byte"
+			ftd.Kind = "Bin"
+			ftd.ResetValue = fmt.Sprintf("[]byte(%v)", f.GetBin())
+		case *geckpb.FieldDefinition_Entity:
+			typ = "Entity"
+			ftd.Kind = "Entity"
+			ftd.ResetValue = "EntityFromU32(0)"
+			ftd.IsEntity = true
+		case *geckpb.FieldDefinition_Enum:
+			e := f.GetEnum()
+			typ = e.Name
+
+			var enum *enumTmplData
+			for _, e := range data.Enums {
+				if e.Name.Singular.Original == typ {
+					enum = e
+					break
+				}
+			}
+			if enum == nil {
+				diags.add(path, CodeUnknownReference, "field '%s' refers to enum '%s' which is not declared", f.Name, e.Name)
+				return ftd, false
+			}
+			typ = "Enum" + typ
+			ftd.Kind = "Enum"
+			ftd.ResetValue = fmt.Sprintf("%s(%d)", typ, e.Value)
+		case *geckpb.FieldDefinition_Struct:
+			s, ok := resolveStruct(path, f.GetStruct())
+			if !ok {
+				return ftd, false
+			}
+			ftd.Struct = s
+			ftd.ResetValue = "Default" + s.Name.Singular.Pascal + "()"
+		case *geckpb.FieldDefinition_Vector3:
+			ftd.Struct = mathxVector3
+			ftd.ResetValue = vector3Literal(f.GetVector3())
+		case *geckpb.FieldDefinition_Quaternion:
+			ftd.Struct = mathxQuaternion
+			ftd.ResetValue = quaternionLiteral(f.GetQuaternion())
+		case *geckpb.FieldDefinition_Box3:
+			ftd.Struct = mathxBox3
+			ftd.ResetValue = box3Literal(f.GetBox3())
+		default:
+			diags.add(path, CodeInvalid, "field '%s' has an unknown type %T", f.Name, f.ResetValue)
+			return ftd, false
+		}
+		if ftd.Struct != nil {
+			typ = ftd.Struct.GoType()
+			ftd.Kind = "Struct"
+		}
+
+		if inStruct && (ftd.IsEntity || ftd.Kind == "Bin" || f.HasMultiple) {
+			diags.add(path, CodeInvalid, "struct field '%s' cannot hold entities, bytes or multiple values", f.Name)
+			return ftd, false
+		}
+
+		ftd.ElemType = typ
+		if f.HasMultiple {
+			typ = "[]" + typ
+			ftd.ResetValue = "nil"
+		}
+
+		ftd.Type = inflectionStrings(typ, false)
+		return ftd, true
+	}
+
+	fieldsData := func(path string, fields []*geckpb.FieldDefinition, inStruct bool) []fieldTemplateData {
+		var ftds []fieldTemplateData
+		fieldPaths := map[string]string{}
+		for fi, f := range fields {
+			fieldPath := fmt.Sprintf("%s.fields[%d]", path, fi)
+			name := inflectionStrings(f.Name, false)
+
+			if !checkIdentifier(&diags, fieldPath, "field", f.Name, name) {
+				continue
+			}
+			if !inStruct && reservedFieldNames[name.Singular.Pascal] {
+				diags.add(fieldPath, CodeReservedName, "field '%s' is the name of a generated method", f.Name)
+				continue
+			}
+			if prev, ok := fieldPaths[name.Singular.Pascal]; ok {
+				diags.add(fieldPath, CodeDuplicateName, "field '%s' is already declared at %s", f.Name, prev)
+				continue
+			}
+			fieldPaths[name.Singular.Pascal] = fieldPath
+
+			if ftd, ok := fieldData(fieldPath, f, inStruct); ok {
+				ftds = append(ftds, ftd)
+			}
+		}
+		return ftds
+	}
+
+	type structDef struct {
+		path       string
+		bundleName toolbelt.CasedString
+		def        *geckpb.StructDefinition
+	}
+	var structOrder []string
+	structDefs := map[string]structDef{}
+	structNames := declaredNames{}
+	for bi, bundleDef := range bundles {
+		for si, sd := range bundleDef.Structs {
+			path := fmt.Sprintf("%s.structs[%d]", bundlePath(bi), si)
+			name := inflectionStrings(sd.Name, false)
+			if !checkIdentifier(&diags, path, "struct", sd.Name, name) {
+				continue
+			}
+			if reservedTypeNames[name.Singular.Pascal] {
+				diags.add(path, CodeReservedName, "struct '%s' is the name of a generated type", sd.Name)
+				continue
+			}
+			if !structNames.declare(&diags, path, "struct", sd.Name, name) {
+				continue
+			}
+			structOrder = append(structOrder, sd.Name)
+			structDefs[sd.Name] = structDef{
+				path:       path,
+				bundleName: toolbelt.ToCasedString(bundleDef.Name),
+				def:        sd,
+			}
+		}
+	}
+
+	resolvedStructs := map[string]*structTmplData{}
+	resolvingStructs := map[string]bool{}
+	resolveStruct = func(path, name string) (*structTmplData, bool) {
+		if s, ok := resolvedStructs[name]; ok {
+			// nil when the struct had problems, they are already reported
+			return s, s != nil
+		}
+		sd, ok := structDefs[name]
+		if !ok {
+			diags.add(path, CodeUnknownReference, "struct '%s' is not declared", name)
+			return nil, false
+		}
+		if resolvingStructs[name] {
+			diags.add(path, CodeInvalid, "struct '%s' cannot contain itself", name)
+			return nil, false
+		}
+
+		resolvingStructs[name] = true
+		before := len(diags)
+		s := &structTmplData{
+			PackageName: data.PackageName,
+			BundleName:  sd.bundleName,
+			Name:        inflectionStrings(sd.def.Name, false),
+			Description: sd.def.Description,
+			Fields:      fieldsData(sd.path, sd.def.Fields, true),
+		}
+		delete(resolvingStructs, name)
+
+		if len(sd.def.Fields) == 0 {
+			diags.add(sd.path, CodeInvalid, "struct '%s' must have at least one field", name)
+		}
+		if len(diags) > before {
+			resolvedStructs[name] = nil
+			return nil, false
+		}
+		resolvedStructs[name] = s
+		data.Structs = append(data.Structs, s)
+		return s, true
+	}
+	for _, name := range structOrder {
+		resolveStruct(structDefs[name].path, name)
+	}
+
+	componentNames := declaredNames{}
+	bundlePaths := map[string]string{}
+	componentByNames := map[string]map[string]*componentTmplData{}
+	for bi, bundleDef := range bundles {
+		bundleName := toolbelt.ToCasedString(bundleDef.Name)
+		bp := bundlePath(bi)
+
+		if bundleDef.Name == "" {
+			diags.add(bp, CodeInvalid, "bundle must have a name")
+			continue
+		}
+		if prev, ok := bundlePaths[bundleName.Pascal]; ok {
+			diags.add(bp, CodeDuplicateName, "bundle '%s' is already declared at %s", bundleDef.Name, prev)
+			continue
+		}
+		bundlePaths[bundleName.Pascal] = bp
 
 		bundleComponentNames := map[string]*componentTmplData{}
-		for _, cd := range bundleDef.Components {
+		for ci, cd := range bundleDef.Components {
+			path := fmt.Sprintf("%s.components[%d]", bp, ci)
 			isTag := len(cd.Fields) == 0 && !cd.IsRelationship
 
 			component := &componentTmplData{
@@ -262,15 +616,50 @@ func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, err error) {
 				ShouldGenAdded:   cd.ShouldGenerateAddedEvent,
 				ShouldGenRemoved: cd.ShouldGenerateRemovedEvent,
 				ShouldGenChanged: cd.ShouldGenerateChangedEvent,
+
+				ShouldTrackChanges: cd.ShouldTrackChanges,
+			}
+
+			kind := "component"
+			switch {
+			case isTag:
+				kind = "tag"
+			case cd.IsRelationship:
+				kind = "relationship"
+			}
+			// every bundle shares the World, so names must be unique across them
+			if checkIdentifier(&diags, path, kind, cd.Name, component.Name) &&
+				checkWorldMethods(&diags, path, kind, cd.Name, component.Name) {
+				componentNames.declare(&diags, path, kind, cd.Name, component.Name)
 			}
 
 			if component.ShouldGenAdded || component.ShouldGenRemoved || component.ShouldGenChanged {
 				component.HasAnyEvents = true
 			}
 
-			bundleComponentNames[cd.Name] = component
+			if cd.OnTargetDeleted != geckpb.ComponentDefinition_ON_TARGET_DELETED_UNSPECIFIED && !cd.IsRelationship {
+				diags.add(path, CodeInvalid, "component '%s' sets on target deleted but is not a relationship", cd.Name)
+			}
+			switch cd.OnTargetDeleted {
+			case geckpb.ComponentDefinition_ON_TARGET_DELETED_DELETE:
+				component.ShouldDeleteSourcesWithTarget = true
+			case geckpb.ComponentDefinition_ON_TARGET_DELETED_PANIC:
+				component.ShouldPanicOnTargetDeleted = true
+			}
+
+			if _, ok := bundleComponentNames[cd.Name]; !ok {
+				bundleComponentNames[cd.Name] = component
+			}
+
+			if len(cd.Fields) > 64 {
+				// deltas flag changed fields in a uint64
+				diags.add(path, CodeInvalid, "component '%s' has more than 64 fields", cd.Name)
+			}
 
 			if len(cd.Fields) == 1 {
+				if cd.Fields[0].IsDeprecated {
+					diags.add(path+".fields[0]", CodeInvalid, "component '%s' cannot deprecate its only field", cd.Name)
+				}
 				component.IsOnlyOneField = true
 				if cd.Fields[0].HasMultiple {
 					component.IsFirstSlice = true
@@ -281,83 +670,7 @@ func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, err error) {
 				}
 			}
 
-			for _, f := range cd.Fields {
-				ftd := fieldTemplateData{
-					Name:        inflectionStrings(f.Name, false),
-					Description: f.Description,
-					IsSlice:     f.HasMultiple,
-				}
-
-				var typ string
-				switch f.ResetValue.(type) {
-				case *geckpb.FieldDefinition_U8:
-					typ = "uint8"
-					ftd.ResetValue = fmt.Sprintf("%d", f.GetU8())
-				case *geckpb.FieldDefinition_U16:
-					typ = "uint16"
-					ftd.ResetValue = fmt.Sprintf("%d", f.GetU16())
-				case *geckpb.FieldDefinition_U32:
-					typ = "uint32"
-					ftd.ResetValue = fmt.Sprintf("%d", f.GetU32())
-				case *geckpb.FieldDefinition_U64:
-					typ = "uint64"
-					ftd.ResetValue = fmt.Sprintf("%d", f.GetU64())
-				case *geckpb.FieldDefinition_I8:
-					typ = "int8"
-					ftd.ResetValue = fmt.Sprintf("%d", f.GetI8())
-				case *geckpb.FieldDefinition_I16:
-					typ = "int16"
-					ftd.ResetValue = fmt.Sprintf("%d", f.GetI16())
-				case *geckpb.FieldDefinition_I32:
-					typ = "int32"
-					ftd.ResetValue = fmt.Sprintf("%d", f.GetI32())
-				case *geckpb.FieldDefinition_I64:
-					typ = "int64"
-					ftd.ResetValue = fmt.Sprintf("%d", f.GetI64())
-				case *geckpb.FieldDefinition_F32:
-					typ = "float32"
-					ftd.ResetValue = fmt.Sprintf("%f", f.GetF32())
-				case *geckpb.FieldDefinition_F64:
-					typ = "float64"
-					ftd.ResetValue = fmt.Sprintf("%f", f.GetF64())
-				case *geckpb.FieldDefinition_Txt:
-					typ = "string"
-					ftd.ResetValue = fmt.Sprintf(`"%s"`, f.GetTxt())
-				case *geckpb.FieldDefinition_Bin:
-					typ = "[]byte"
-					ftd.ResetValue = fmt.Sprintf("[]byte(%v)", f.GetBin())
-				case *geckpb.FieldDefinition_Entity:
-					typ = "Entity"
-					ftd.ResetValue = "EntityFromU32(0)"
-					ftd.IsEntity = true
-				case *geckpb.FieldDefinition_Enum:
-					e := f.GetEnum()
-					typ = e.Name
-
-					var enum *enumTmplData
-					for _, e := range data.Enums {
-						if e.Name.Singular.Original == typ {
-							enum = e
-							break
-						}
-					}
-					if enum == nil {
-						return nil, fmt.Errorf("enum not found: %s", f.Name)
-					}
-					typ = "Enum" + typ
-					ftd.ResetValue = fmt.Sprintf("%s(%d)", typ, e.Value)
-				default:
-					return nil, fmt.Errorf("unknown field type: %s %T", f.Name, f.ResetValue)
-				}
-
-				if f.HasMultiple {
-					typ = "[]" + typ
-					ftd.ResetValue = "nil"
-				}
-
-				ftd.Type = inflectionStrings(typ, cd.ShouldNotInflect)
-				component.Fields = append(component.Fields, ftd)
-			}
+			component.Fields = fieldsData(path, cd.Fields, false)
 
 			fieldCount := len(component.Fields)
 			if fieldCount > 0 {
@@ -388,16 +701,18 @@ func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, err error) {
 		componentByNames[bundleName.Pascal] = bundleComponentNames
 	}
 
-	log.Printf("%+v", componentByNames)
-
-	for _, queryDef := range opts.Queries {
+	queryNames := declaredNames{}
+	for qi, queryDef := range opts.Queries {
+		path := fmt.Sprintf("queries[%d]", qi)
 		if len(queryDef.Entries) == 0 {
-			return nil, fmt.Errorf("query must have at least one component or tag")
+			diags.add(path, CodeInvalidQuery, "query must have at least one component or tag")
+			continue
 		}
 
 		query := &queryTmplData{
-			PackageName: opts.PackageName,
-			Folder:      opts.FolderPath,
+			PackageName:   opts.PackageName,
+			Folder:        opts.FolderPath,
+			IsOwningGroup: queryDef.IsOwningGroup,
 		}
 
 		type Name struct {
@@ -405,20 +720,53 @@ func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, err error) {
 			Name   string
 		}
 		names := []Name{}
-		componentOrTagEntry := func(cd *geckpb.QueryDefinition_ComponentOrTag) (*queryEntryTmplData, error) {
+		findTerm := func(name string) (*queryEntryTmplData, bool) {
+			return lo.Find(query.Entries, func(entry *queryEntryTmplData) bool {
+				return entry.ComponentOrTag.Name.Singular.Original == name
+			})
+		}
+		componentOrTagEntry := func(path string, cd *geckpb.QueryDefinition_ComponentOrTag) (*queryEntryTmplData, bool) {
+			fail := func(code DiagnosticCode, format string, args ...any) (*queryEntryTmplData, bool) {
+				diags.add(path, code, format, args...)
+				return nil, false
+			}
+			// entityTerm finds an earlier term that can be used as a source or target
+			entityTerm := func(name string) (*queryEntryTmplData, bool) {
+				entry, ok := findTerm(name)
+				if !ok {
+					return fail(CodeUnknownReference, "term '%s' must appear earlier in the query", name)
+				}
+				if entry.IsWithout || entry.IsOptional || entry.IsOr {
+					return fail(CodeInvalidQuery, "term '%s' is not always matched and cannot provide an entity", name)
+				}
+				c := entry.ComponentOrTag
+				if !c.IsRelationship && !(c.IsOnlyOneField && c.IsFirstFieldEntity) {
+					return fail(CodeInvalidQuery, "term '%s' must be a relationship or hold a single entity field", name)
+				}
+				return entry, true
+			}
+
 			bundleName := toolbelt.ToCasedString(cd.BundleName)
 			bundleNames := componentByNames[bundleName.Pascal]
 			if bundleNames == nil {
-				return nil, fmt.Errorf("bundle not found: %s", cd.BundleName)
+				return fail(CodeUnknownReference, "bundle '%s' is not declared", cd.BundleName)
 			}
 
 			c, ok := bundleNames[cd.Name]
 			if !ok {
-				return nil, fmt.Errorf("component not found: %s", cd.Name)
+				return fail(CodeUnknownReference, "bundle '%s' has no component, tag or relationship '%s'", cd.BundleName, cd.Name)
 			}
 
 			if cd.IsMutable && c.IsTag {
-				return nil, fmt.Errorf("tags cannot be mutable")
+				return fail(CodeInvalidQuery, "tag '%s' has no data and cannot be mutable", cd.Name)
+			}
+
+			if cd.OrGroup != "" && cd.Operator != geckpb.QueryDefinition_OPERATOR_OR {
+				return fail(CodeInvalidQuery, "'%s' has an or group but is not an or term", cd.Name)
+			}
+
+			if _, ok := findTerm(cd.Name); ok {
+				return fail(CodeInvalidQuery, "'%s' appears more than once", cd.Name)
 			}
 
 			names = append(names, Name{
@@ -432,15 +780,120 @@ func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, err error) {
 				ComponentOrTag: c,
 			}
 
-			return ce, nil
+			switch cd.Operator {
+			case geckpb.QueryDefinition_OPERATOR_WITHOUT:
+				if cd.IsMutable {
+					return fail(CodeInvalidQuery, "'%s' is excluded and cannot be mutable", cd.Name)
+				}
+				ce.IsWithout = true
+			case geckpb.QueryDefinition_OPERATOR_OPTIONAL:
+				ce.IsOptional = true
+			case geckpb.QueryDefinition_OPERATOR_OR:
+				ce.IsOr = true
+			case geckpb.QueryDefinition_OPERATOR_CHANGED, geckpb.QueryDefinition_OPERATOR_ADDED:
+				if c.IsTag || c.IsRelationship {
+					return fail(CodeInvalidQuery, "'%s' can only filter changes of components", cd.Name)
+				}
+				if cd.Source != "" {
+					return fail(CodeInvalidQuery, "'%s' filters changes and cannot have a source", cd.Name)
+				}
+				ce.IsChanged = cd.Operator == geckpb.QueryDefinition_OPERATOR_CHANGED
+				ce.IsAdded = !ce.IsChanged
+				c.ShouldTrackChanges = true
+			}
+
+			if cd.IsInherited {
+				if !c.IsInheritable() {
+					return fail(CodeInvalidQuery, "'%s' cannot be inherited from prefabs", cd.Name)
+				}
+				if cd.IsMutable {
+					return fail(CodeInvalidQuery, "'%s' is inherited and cannot be mutable", cd.Name)
+				}
+				if cd.Source != "" {
+					return fail(CodeInvalidQuery, "'%s' is inherited and cannot have a source", cd.Name)
+				}
+				switch cd.Operator {
+				case geckpb.QueryDefinition_OPERATOR_UNSPECIFIED, geckpb.QueryDefinition_OPERATOR_WITH:
+				default:
+					return fail(CodeInvalidQuery, "'%s' is inherited and can only be a with term", cd.Name)
+				}
+				ce.IsInherited = true
+			}
+
+			if c.IsRelationship {
+				if cd.IsMutable {
+					return fail(CodeInvalidQuery, "relationship '%s' cannot be mutable", cd.Name)
+				}
+				if ce.IsOptional || ce.IsOr {
+					return fail(CodeInvalidQuery, "relationship '%s' can only be a with or without term", cd.Name)
+				}
+
+				switch cd.Target {
+				case "":
+					ce.IsWildcard = true
+				case "$":
+					ce.IsTargetArg = true
+				default:
+					target, ok := entityTerm(cd.Target)
+					if !ok {
+						return nil, false
+					}
+					ce.Target = target
+				}
+			} else if cd.Target != "" {
+				return fail(CodeInvalidQuery, "'%s' has a target but is not a relationship", cd.Name)
+			}
+
+			if cd.Source != "" {
+				if ce.IsOptional || ce.IsOr {
+					return fail(CodeInvalidQuery, "'%s' is optional and cannot have a source", cd.Name)
+				}
+				source, ok := entityTerm(cd.Source)
+				if !ok {
+					return nil, false
+				}
+				ce.Source = source
+			}
+
+			return ce, true
 		}
 
-		for _, def := range queryDef.Entries {
-			componentEntry, err := componentOrTagEntry(def)
-			if err != nil {
-				return nil, err
+		isValid := true
+		for ei, def := range queryDef.Entries {
+			componentEntry, ok := componentOrTagEntry(fmt.Sprintf("%s.entries[%d]", path, ei), def)
+			if !ok {
+				isValid = false
+				continue
 			}
 			query.Entries = append(query.Entries, componentEntry)
+
+			switch {
+			case componentEntry.IsOr:
+				groupName := def.OrGroup
+				group, ok := lo.Find(query.OrGroups, func(g *queryOrGroupTmplData) bool {
+					return g.Name == groupName
+				})
+				if !ok {
+					group = &queryOrGroupTmplData{Name: groupName}
+					query.OrGroups = append(query.OrGroups, group)
+				}
+				group.Entries = append(group.Entries, componentEntry)
+			case componentEntry.IsWithout || componentEntry.IsOptional:
+			case componentEntry.IsInherited:
+				query.Inherited = append(query.Inherited, componentEntry)
+			case componentEntry.Source != nil || componentEntry.ComponentOrTag.IsRelationship:
+				query.Joins = append(query.Joins, componentEntry)
+			default:
+				query.Required = append(query.Required, componentEntry)
+			}
+		}
+
+		if !isValid {
+			continue
+		}
+		if len(query.Required) == 0 {
+			diags.add(path, CodeInvalidQuery, "query must have at least one required component or tag")
+			continue
 		}
 
 		if queryDef.Alias != "" {
@@ -475,44 +928,417 @@ func optsToData(opts *geckpb.GeneratorOptions) (data *ecsTmplData, err error) {
 			name := nameBuilder.String()
 			query.Name = inflectionStrings(name, true)
 		}
+		if !checkIdentifier(&diags, path, "query", query.Name.Singular.Original, query.Name) ||
+			!queryNames.declare(&diags, path, "query", query.Name.Singular.Original, query.Name) {
+			continue
+		}
+
+		if query.IsOwningGroup {
+			for _, entry := range query.Required {
+				c := entry.ComponentOrTag
+				if c.OwnedBySet != nil && c.OwnedBySet != query {
+					diags.add(
+						path, CodeInvalidQuery,
+						"'%s' is already owned by query '%s', cannot be owned by '%s'",
+						c.Name.Singular.Original,
+						c.OwnedBySet.Name.Singular.Pascal,
+						query.Name.Singular.Pascal,
+					)
+					continue
+				}
+				c.OwnedBySet = query
+			}
+		}
+
+		if query.IsObservable() {
+			for _, entry := range query.Entries {
+				c := entry.ComponentOrTag
+				if !entry.IsOptional && !slices.Contains(c.ObservedBy, query) {
+					c.ObservedBy = append(c.ObservedBy, query)
+				}
+			}
+		}
 
 		data.Queries = append(data.Queries, query)
 	}
 
+	if len(diags) > 0 {
+		return nil, diags
+	}
 	return data, nil
 }
 
-func generateFile(templateName string, data *ecsTmplData, templates func(data *ecsTmplData) string) error {
-	fn := fmt.Sprintf("ecs_%s", templateName)
-	fp := filepath.Join(data.FolderPath, fn)
+// IsObservable is true when membership only depends on the iterated entity's
+// own components and tags, so writes to them can fire enter and exit events
+func (q *queryTmplData) IsObservable() bool {
+	return !q.HasParams() && !q.HasJoins() && !q.HasInherited()
+}
+
+// MatchCall is the condition for e matching an observable query
+func (q *queryTmplData) MatchCall() string {
+	checks := []string{"w.IsAlive(e)"}
+	for _, entry := range q.Required {
+		checks = append(checks, hasTermCall(entry))
+	}
+	for _, entry := range q.Entries {
+		if entry.IsWithout {
+			checks = append(checks, "!"+hasTermCall(entry))
+		}
+	}
+	for _, group := range q.OrGroups {
+		terms := lo.Map(group.Entries, func(entry *queryEntryTmplData, i int) string {
+			return hasTermCall(entry)
+		})
+		checks = append(checks, "("+strings.Join(terms, " || ")+")")
+	}
+	return strings.Join(checks, " &&\n\t\t")
+}
+
+// HasTermChecks is true when matching needs more than the required sets
+func (q *queryTmplData) HasTermChecks() bool {
+	return len(q.Entries) != len(q.Required)+len(q.Joins)
+}
+
+// HasInherited is true when a term can be matched through IsA prefabs
+func (q *queryTmplData) HasInherited() bool {
+	return len(q.Inherited) > 0
+}
+
+// HasChangeFilters is true when the query takes the change tick to compare
+// against
+func (q *queryTmplData) HasChangeFilters() bool {
+	return lo.ContainsBy(q.Required, func(entry *queryEntryTmplData) bool {
+		return entry.IsChanged || entry.IsAdded
+	})
+}
 
-	contents := templates(data)
+// HasParams is true when the query is called with arguments and returns the
+// iterator instead of being one
+func (q *queryTmplData) HasParams() bool {
+	return q.HasChangeFilters() || len(q.TargetArgs()) > 0
+}
 
-	if err := os.WriteFile(fp, []byte(contents), 0644); err != nil {
-		return fmt.Errorf("failed to write to file: %w", err)
+// Params are the query arguments, the change tick comes first
+func (q *queryTmplData) Params() string {
+	params := lo.Map(q.TargetArgs(), func(entry *queryEntryTmplData, i int) string {
+		return entry.TargetExpr() + " Entity"
+	})
+	if q.HasChangeFilters() {
+		params = append([]string{"since uint64"}, params...)
 	}
+	return strings.Join(params, ", ")
+}
 
-	return nil
+func (q *queryTmplData) ParamNames() string {
+	names := lo.Map(q.TargetArgs(), func(entry *queryEntryTmplData, i int) string {
+		return entry.TargetExpr()
+	})
+	if q.HasChangeFilters() {
+		names = append([]string{"since"}, names...)
+	}
+	return strings.Join(names, ", ")
 }
 
-func generateEnum(enum *enumTmplData) error {
-	fp := filepath.Join(
-		enum.Folder,
-		fmt.Sprintf(
-			"%s_enums_%s.go",
-			enum.BundleName.Snake,
-			enum.Name.Plural.Snake,
-		),
-	)
-	contents := enumTemplate(enum)
-	if err := os.WriteFile(fp, []byte(contents), 0644); err != nil {
-		return fmt.Errorf("failed to write to file: %w", err)
+// HasJoins is true when terms match other entities than the iterated one, so
+// matching needs the filled args
+func (q *queryTmplData) HasJoins() bool {
+	return lo.ContainsBy(q.Entries, func(entry *queryEntryTmplData) bool {
+		return entry.Source != nil || entry.ComponentOrTag.IsRelationship
+	})
+}
+
+// JoinsNeedOk is true when a joined or inherited term is fetched with a comma
+// ok lookup, the target driver is iterated instead
+func (q *queryTmplData) JoinsNeedOk() bool {
+	driver := q.TargetDriver()
+	return q.HasInherited() || lo.ContainsBy(q.Joins, func(entry *queryEntryTmplData) bool {
+		return entry != driver && !entry.IsWildcard && !entry.ComponentOrTag.IsTag && !entry.IsMutable
+	})
+}
+
+// TargetDriver is the relationship term of the iterated entity whose target is
+// passed in, only sources in the target's index can match so they drive the
+// query instead of the required sets
+func (q *queryTmplData) TargetDriver() *queryEntryTmplData {
+	if q.IsOwningGroup {
+		return nil
 	}
+	driver, _ := lo.Find(q.Joins, func(entry *queryEntryTmplData) bool {
+		return entry.IsTargetArg && entry.Source == nil
+	})
+	return driver
+}
 
-	return nil
+// RequiredNeedOk is true when a required component is fetched with a comma ok
+// lookup, mutable ones are only checked for until the entity matched
+func (q *queryTmplData) RequiredNeedOk() bool {
+	return lo.ContainsBy(q.Required, func(entry *queryEntryTmplData) bool {
+		return !entry.ComponentOrTag.IsTag && !entry.IsMutable
+	})
+}
+
+// HasMutable is true when the query hands out pointers
+func (q *queryTmplData) HasMutable() bool {
+	return lo.ContainsBy(q.Entries, func(entry *queryEntryTmplData) bool {
+		return entry.IsMutable
+	})
 }
 
-func generateComponent(component *componentTmplData) error {
+func (q *queryTmplData) HasWildcards() bool {
+	return lo.ContainsBy(q.Joins, func(entry *queryEntryTmplData) bool {
+		return entry.IsWildcard
+	})
+}
+
+// TargetArgs are the entries whose relationship target is passed to the query
+func (q *queryTmplData) TargetArgs() []*queryEntryTmplData {
+	return lo.Filter(q.Entries, func(entry *queryEntryTmplData, i int) bool {
+		return entry.IsTargetArg
+	})
+}
+
+// entityExpr is the entity a source or target term provides
+func (entry *queryEntryTmplData) entityExpr() string {
+	c := entry.ComponentOrTag
+	if c.IsRelationship {
+		return "args." + entry.Name.Singular.Pascal + ".To"
+	}
+	return "args." + entry.Name.Singular.Pascal + "." + c.Fields[0].Name.Singular.Pascal
+}
+
+func (entry *queryEntryTmplData) SourceExpr() string {
+	if entry.Source == nil {
+		return "e"
+	}
+	return entry.Source.entityExpr()
+}
+
+func (entry *queryEntryTmplData) TargetExpr() string {
+	if entry.IsTargetArg {
+		return entry.Name.Singular.Camel + "Target"
+	}
+	return entry.Target.entityExpr()
+}
+
+// hasTermCall checks if the entity being matched has the component, tag or
+// relationship
+func hasTermCall(entry *queryEntryTmplData) string {
+	name, src := entry.Name.Singular.Pascal, entry.SourceExpr()
+	switch {
+	case entry.ComponentOrTag.IsTag:
+		return "w.Has" + name + "Tag(" + src + ")"
+	case entry.ComponentOrTag.IsRelationship && entry.IsWildcard:
+		return "w." + entry.Name.Singular.Camel + "Relationships.hasPairsFrom(" + src + ")"
+	case entry.ComponentOrTag.IsRelationship:
+		return "w." + name + "IsLinked(" + src + ", " + entry.TargetExpr() + ")"
+	default:
+		return "w.Has" + name + "(" + src + ")"
+	}
+}
+
+// changeFilterCall checks the change tick a Changed or Added term filters on
+func changeFilterCall(entry *queryEntryTmplData) string {
+	set := "w." + sparseSetName(entry.ComponentOrTag)
+	if entry.IsAdded {
+		return set + ".addedSince(e, since)"
+	}
+	return set + ".changedSince(e, since)"
+}
+
+// orGroupMissing is true when the entity has none of the group's terms
+func orGroupMissing(group *queryOrGroupTmplData) string {
+	checks := lo.Map(group.Entries, func(entry *queryEntryTmplData, i int) string {
+		return "!" + hasTermCall(entry)
+	})
+	return strings.Join(checks, " && ")
+}
+
+// WritableFields are the fields FromValues helpers take arguments for
+func (c *componentTmplData) WritableFields() []fieldTemplateData {
+	return lo.Filter(c.Fields, func(f fieldTemplateData, i int) bool {
+		return !f.IsDeprecated
+	})
+}
+
+// IsInheritable is true for components instances can read from their IsA
+// prefabs, names stay unique to each entity
+func (c *componentTmplData) IsInheritable() bool {
+	return !c.IsTag && !c.IsRelationship && !c.IsName()
+}
+
+// ObservedReads are the sets writing this one reads, to check whether the
+// entity still matches the queries observing it
+func (c *componentTmplData) ObservedReads() []*componentTmplData {
+	var reads []*componentTmplData
+	for _, q := range c.ObservedBy {
+		for _, entry := range q.Entries {
+			if !entry.IsOptional && !slices.Contains(reads, entry.ComponentOrTag) {
+				reads = append(reads, entry.ComponentOrTag)
+			}
+		}
+	}
+	return reads
+}
+
+// SnapshotName identifies the set in snapshots and JSON exports, it only
+// changes when the bundle or component is renamed
+func (c *componentTmplData) SnapshotName() string {
+	return c.BundleName.Pascal + "." + c.Name.Singular.Pascal
+}
+
+// snapshotWrite is the statement encoding a single value of the field
+func snapshotWrite(f fieldTemplateData, expr string) string {
+	switch f.Kind {
+	case "U8", "U16", "U32", "U64", "Enum":
+		return "sw.uvarint(uint64(" + expr + "))"
+	case "I8", "I16", "I32", "I64":
+		return "sw.varint(int64(" + expr + "))"
+	case "F32":
+		return "sw.f32(" + expr + ")"
+	case "F64":
+		return "sw.f64(" + expr + ")"
+	case "Txt":
+		return "sw.str(" + expr + ")"
+	case "Bin":
+		return "sw.bytes(" + expr + ")"
+	case "Struct":
+		return "sw." + f.Struct.SnapshotFunc() + "(" + expr + ")"
+	default:
+		return "sw.entity(" + expr + ")"
+	}
+}
+
+// snapshotRead is the expression decoding a single value of the field
+func snapshotRead(f fieldTemplateData) string {
+	switch f.Kind {
+	case "U8", "U16", "U32", "U64", "Enum":
+		return f.ElemType + "(r.uvarint())"
+	case "I8", "I16", "I32", "I64":
+		return f.ElemType + "(r.varint())"
+	case "F32":
+		return "r.f32()"
+	case "F64":
+		return "r.f64()"
+	case "Txt":
+		return "r.str()"
+	case "Bin":
+		return "r.bytes()"
+	case "Struct":
+		return "r." + f.Struct.SnapshotFunc() + "()"
+	default:
+		return "r.entity()"
+	}
+}
+
+// HasSliceFields is true when copying a value would share memory
+func (c *componentTmplData) HasSliceFields() bool {
+	return slices.ContainsFunc(c.Fields, func(f fieldTemplateData) bool {
+		return f.IsSlice || f.Kind == "Bin"
+	})
+}
+
+// HasPanicOnTargetDeleted is true when destroying an entity can be refused
+func (data *ecsTmplData) HasPanicOnTargetDeleted() bool {
+	return slices.ContainsFunc(data.Components, func(c *componentTmplData) bool {
+		return c.IsRelationship && c.ShouldPanicOnTargetDeleted
+	})
+}
+
+// IsName is true for the builtin Name component, JSON exports write it as the
+// entity's name instead of a component
+func (c *componentTmplData) IsName() bool {
+	return c.BundleName.Pascal == builtinBundle.Name && c.Name.Singular.Original == "Name"
+}
+
+// JSONType is the type a field is exported as, entities become references
+func (f fieldTemplateData) JSONType() string {
+	switch {
+	case f.IsEntity && f.IsSlice:
+		return "[]jsonRef"
+	case f.IsEntity:
+		return "jsonRef"
+	default:
+		return f.Type.Singular.Original
+	}
+}
+
+// jsonExport is the expression exporting the field read from expr
+func jsonExport(f fieldTemplateData, expr string) string {
+	switch {
+	case f.IsEntity && f.IsSlice:
+		return "ex.refs(" + expr + ")"
+	case f.IsEntity:
+		return "ex.ref(" + expr + ")"
+	default:
+		return expr
+	}
+}
+
+// jsonImport is the expression importing the field read from expr, unset
+// references fall back to the reset value
+func jsonImport(f fieldTemplateData, expr string) string {
+	switch {
+	case f.IsEntity && f.IsSlice:
+		return "im.entities(" + expr + ")"
+	case f.IsEntity:
+		return "im.entity(" + expr + ", " + f.ResetValue + ")"
+	default:
+		return expr
+	}
+}
+
+// fieldEqual is the expression comparing two values of the field
+func fieldEqual(f fieldTemplateData, a, b string) string {
+	switch {
+	case f.IsSlice && f.Kind == "Bin":
+		return "slices.EqualFunc(" + a + ", " + b + ", bytes.Equal)"
+	case f.IsSlice || f.Kind == "Bin":
+		return "slices.Equal(" + a + ", " + b + ")"
+	default:
+		return a + " == " + b
+	}
+}
+
+// cloneField is the expression copying the field read from expr without
+// sharing memory with it
+func cloneField(f fieldTemplateData, expr string) string {
+	if f.IsSlice || f.Kind == "Bin" {
+		return "slices.Clone(" + expr + ")"
+	}
+	return expr
+}
+
+// sparseSetName is the World field holding the sparse set of a component or tag
+func sparseSetName(c *componentTmplData) string {
+	if c.IsTag {
+		return c.Name.Singular.Camel + "Tags"
+	}
+	return c.Name.Singular.Camel + "Components"
+}
+
+func generateFile(out *output, templateName string, data *ecsTmplData, templates func(data *ecsTmplData) string) error {
+	return out.add(fmt.Sprintf("ecs_%s", templateName), templates(data))
+}
+
+func generateEnum(out *output, enum *enumTmplData) error {
+	name := fmt.Sprintf(
+		"%s_enums_%s.go",
+		enum.BundleName.Snake,
+		enum.Name.Plural.Snake,
+	)
+	return out.add(name, enumTemplate(enum))
+}
+
+func generateStruct(out *output, s *structTmplData) error {
+	name := fmt.Sprintf(
+		"%s_structs_%s.go",
+		s.BundleName.Snake,
+		s.Name.Plural.Snake,
+	)
+	return out.add(name, structTemplate(s))
+}
+
+func generateComponent(out *output, component *componentTmplData) error {
 
 	var prefix, contents string
 	switch {
@@ -527,38 +1353,21 @@ func generateComponent(component *componentTmplData) error {
 		contents = componentTemplate(component)
 	}
 
-	fp := filepath.Join(
-		component.Folder,
-		fmt.Sprintf(
-			"%s_%s_%s.go",
-			component.BundleName.Snake,
-			prefix,
-			component.Name.Plural.Snake,
-		),
+	name := fmt.Sprintf(
+		"%s_%s_%s.go",
+		component.BundleName.Snake,
+		prefix,
+		component.Name.Plural.Snake,
 	)
-
-	if err := os.WriteFile(fp, []byte(contents), 0644); err != nil {
-		return fmt.Errorf("failed to write to file: %w", err)
-	}
-
-	return nil
+	return out.add(name, contents)
 }
 
-func generateQueries(query *queryTmplData) error {
-	fp := filepath.Join(
-		query.Folder,
-		fmt.Sprintf(
-			"queries_%s.go",
-			query.Name.Plural.Snake,
-		),
+func generateQueries(out *output, query *queryTmplData) error {
+	name := fmt.Sprintf(
+		"queries_%s.go",
+		query.Name.Plural.Snake,
 	)
-
-	contents := queryTemplate(query)
-	if err := os.WriteFile(fp, []byte(contents), 0644); err != nil {
-		return fmt.Errorf("failed to write to file: %w", err)
-	}
-
-	return nil
+	return out.add(name, queryTemplate(query))
 }
 
 var builtinBundle = &geckpb.BundleDefinition{
@@ -579,6 +1388,7 @@ var builtinBundle = &geckpb.BundleDefinition{
 			Name:             "ChildOf",
 			ShouldNotInflect: true,
 			IsRelationship:   true,
+			OnTargetDeleted:  geckpb.ComponentDefinition_ON_TARGET_DELETED_DELETE,
 		},
 		{
 			Name:             "IsA",
@@ -0,0 +1,235 @@
+// Code generated by qtc from "hierarchy_go.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// package generator
+//
+
+//line generator/hierarchy_go.qtpl:3
+package generator
+
+//line generator/hierarchy_go.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line generator/hierarchy_go.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line generator/hierarchy_go.qtpl:3
+func streamhierarchyTemplate(qw422016 *qt422016.Writer, data *ecsTmplData) {
+//line generator/hierarchy_go.qtpl:3
+	qw422016.N().S(`
+package `)
+//line generator/hierarchy_go.qtpl:4
+	qw422016.E().S(data.PackageName)
+//line generator/hierarchy_go.qtpl:4
+	qw422016.N().S(`
+
+import (
+    "errors"
+    "fmt"
+    "slices"
+    "strings"
+)
+
+// ErrHierarchyCycle is returned when reparenting would make an entity its own
+// ancestor
+var ErrHierarchyCycle = errors.New("reparenting would create a ChildOf cycle")
+
+// PathSeparator splits the names of a path given to Lookup
+const PathSeparator = "/"
+
+// ParentOf is the first target e is a ChildOf
+func (w *World) ParentOf(e Entity) (parent Entity, ok bool) {
+    w.childOfRelationships.pairsFrom(e, func(pair ChildOfRelationshipPair) bool {
+        parent, ok = pair.To, true
+        return false
+    })
+    return parent, ok
+}
+
+// Ancestors yields the parent of e, then its parent and so on up to the root
+func (w *World) Ancestors(e Entity) func(yield func(ancestor Entity) bool) {
+    return func(yield func(ancestor Entity) bool) {
+        // a cycle linked without Reparent cannot be longer than every entity
+        for range w.livingEntities.Len() {
+            parent, ok := w.ParentOf(e)
+            if !ok || !yield(parent) {
+                return
+            }
+            e = parent
+        }
+    }
+}
+
+// IsAncestorOf is true when ancestor is found walking up from e
+func (w *World) IsAncestorOf(ancestor, e Entity) bool {
+    for a := range w.Ancestors(e) {
+        if a == ancestor {
+            return true
+        }
+    }
+    return false
+}
+
+// DepthFirst yields every descendant of root, each child's subtree before its
+// next sibling
+func (w *World) DepthFirst(root Entity) func(yield func(e Entity) bool) {
+    return func(yield func(e Entity) bool) {
+        visited := map[Entity]struct{}{root: {}}
+        stack := w.children(root, nil)
+        for len(stack) > 0 {
+            e := stack[len(stack)-1]
+            stack = stack[:len(stack)-1]
+            if _, ok := visited[e]; ok {
+                continue
+            }
+            visited[e] = struct{}{}
+            if !yield(e) {
+                return
+            }
+            stack = w.children(e, stack)
+        }
+    }
+}
+
+// BreadthFirst yields every descendant of root, all children of a level before
+// the next level
+func (w *World) BreadthFirst(root Entity) func(yield func(e Entity) bool) {
+    return func(yield func(e Entity) bool) {
+        visited := map[Entity]struct{}{root: {}}
+        queue := slices.Collect(w.ChildOf(root))
+        for len(queue) > 0 {
+            e := queue[0]
+            queue = queue[1:]
+            if _, ok := visited[e]; ok {
+                continue
+            }
+            visited[e] = struct{}{}
+            if !yield(e) {
+                return
+            }
+            for child := range w.ChildOf(e) {
+                queue = append(queue, child)
+            }
+        }
+    }
+}
+
+// children appends the children of e in reverse order, so popping them off a
+// stack visits them in order
+func (w *World) children(e Entity, stack []Entity) []Entity {
+    start := len(stack)
+    for child := range w.ChildOf(e) {
+        stack = append(stack, child)
+    }
+    slices.Reverse(stack[start:])
+    return stack
+}
+
+// Reparent moves child under parent, dropping every parent it had before
+func (w *World) Reparent(child, parent Entity) error {
+    if !w.IsAlive(child) || !w.IsAlive(parent) {
+        return fmt.Errorf("cannot reparent %d under %d, both must be alive", child, parent)
+    }
+    if child == parent || w.IsAncestorOf(child, parent) {
+        return fmt.Errorf("%w: %d is an ancestor of %d", ErrHierarchyCycle, child, parent)
+    }
+
+    for _, oldParent := range slices.Collect(w.ChildOfTargets(child)) {
+        w.UnlinkChildOf(child, oldParent)
+    }
+    w.LinkChildOf(parent, child)
+    return nil
+}
+
+// Lookup finds an entity by the Names along its path, starting at a root
+// without a parent, e.g. "ship/engine/left"
+func (w *World) Lookup(path string) (e Entity, ok bool) {
+    names := strings.Split(path, PathSeparator)
+    for candidate, name := range w.nameComponents.All {
+        if name.Value != names[0] {
+            continue
+        }
+        if _, hasParent := w.ParentOf(candidate); hasParent {
+            continue
+        }
+        if e, ok = w.lookupFrom(candidate, names[1:]); ok {
+            return e, ok
+        }
+    }
+    return e, false
+}
+
+// LookupFrom finds a descendant of parent by the Names along the path below it
+func (w *World) LookupFrom(parent Entity, path string) (e Entity, ok bool) {
+    return w.lookupFrom(parent, strings.Split(path, PathSeparator))
+}
+
+func (w *World) lookupFrom(parent Entity, names []string) (e Entity, ok bool) {
+    if len(names) == 0 {
+        return parent, true
+    }
+    for child := range w.ChildOf(parent) {
+        if name, hasName := w.nameComponents.Data(child); !hasName || name.Value != names[0] {
+            continue
+        }
+        if e, ok = w.lookupFrom(child, names[1:]); ok {
+            return e, ok
+        }
+    }
+    return e, false
+}
+
+// Path joins the Names from the root down to e, ok is false when an entity
+// along the way has no Name
+func (w *World) Path(e Entity) (path string, ok bool) {
+    name, ok := w.nameComponents.Data(e)
+    if !ok {
+        return "", false
+    }
+    names := []string{name.Value}
+    for ancestor := range w.Ancestors(e) {
+        if name, ok = w.nameComponents.Data(ancestor); !ok {
+            return "", false
+        }
+        names = append(names, name.Value)
+    }
+    slices.Reverse(names)
+    return strings.Join(names, PathSeparator), true
+}
+
+`)
+//line generator/hierarchy_go.qtpl:180
+}
+
+//line generator/hierarchy_go.qtpl:180
+func writehierarchyTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
+//line generator/hierarchy_go.qtpl:180
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/hierarchy_go.qtpl:180
+	streamhierarchyTemplate(qw422016, data)
+//line generator/hierarchy_go.qtpl:180
+	qt422016.ReleaseWriter(qw422016)
+//line generator/hierarchy_go.qtpl:180
+}
+
+//line generator/hierarchy_go.qtpl:180
+func hierarchyTemplate(data *ecsTmplData) string {
+//line generator/hierarchy_go.qtpl:180
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/hierarchy_go.qtpl:180
+	writehierarchyTemplate(qb422016, data)
+//line generator/hierarchy_go.qtpl:180
+	qs422016 := string(qb422016.B)
+//line generator/hierarchy_go.qtpl:180
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/hierarchy_go.qtpl:180
+	return qs422016
+//line generator/hierarchy_go.qtpl:180
+}
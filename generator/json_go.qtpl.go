@@ -0,0 +1,902 @@
+// Code generated by qtc from "json_go.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// package generator
+//
+
+//line generator/json_go.qtpl:3
+package generator
+
+//line generator/json_go.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line generator/json_go.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line generator/json_go.qtpl:3
+func streamjsonTemplate(qw422016 *qt422016.Writer, data *ecsTmplData) {
+//line generator/json_go.qtpl:3
+	qw422016.N().S(`
+package `)
+//line generator/json_go.qtpl:4
+	qw422016.E().S(data.PackageName)
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`
+
+import (
+    "bytes"
+    "encoding/json"
+    "errors"
+    "fmt"
+    "io"
+    "slices"
+
+    "github.com/delaneyj/geck/components/mathx"
+)
+
+// worldJSON is the layout of ExportJSON, components and relationships are keyed
+// by "Bundle.Component" and pairs are listed on their From entity
+type worldJSON struct {
+    SchemaVersion uint32        `)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`json:"schemaVersion"`)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`
+    Resources     *entityJSON   `)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`json:"resources,omitempty"`)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`
+    Entities      []*entityJSON `)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`json:"entities"`)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`
+}
+
+type entityJSON struct {
+    // ID only identifies the entity within the document, imported entities
+    // get new handles
+    ID            *Entity                      `)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`json:"id,omitempty"`)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`
+    Name          string                       `)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`json:"name,omitempty"`)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`
+    Components    map[string]json.RawMessage   `)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`json:"components,omitempty"`)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`
+    Tags          []string                     `)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`json:"tags,omitempty"`)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`
+    Relationships map[string][]json.RawMessage `)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`json:"relationships,omitempty"`)
+//line generator/json_go.qtpl:4
+	qw422016.N().S("`")
+//line generator/json_go.qtpl:4
+	qw422016.N().S(`
+}
+
+// jsonRef is an entity field or pair target, written as the entity's name when
+// that is unique in the document and as its id otherwise
+type jsonRef struct {
+    id    Entity
+    name  string
+    isSet bool
+}
+
+func (r jsonRef) MarshalJSON() ([]byte, error) {
+    if r.name != "" {
+        return json.Marshal(r.name)
+    }
+    return json.Marshal(uint32(r.id))
+}
+
+func (r *jsonRef) UnmarshalJSON(data []byte) error {
+    r.isSet = true
+    if len(data) > 0 && data[0] == '"' {
+        return json.Unmarshal(data, &r.name)
+    }
+    return json.Unmarshal(data, (*uint32)(&r.id))
+}
+
+`)
+//line generator/json_go.qtpl:58
+	for _, c := range data.Components {
+//line generator/json_go.qtpl:59
+		if !c.IsTag && !c.IsName() && len(c.Fields) > 0 {
+//line generator/json_go.qtpl:60
+			nsc := c.Name.Singular.Camel
+
+//line generator/json_go.qtpl:61
+			if c.IsRelationship {
+//line generator/json_go.qtpl:61
+				qw422016.N().S(`
+type `)
+//line generator/json_go.qtpl:63
+				qw422016.E().S(nsc)
+//line generator/json_go.qtpl:63
+				qw422016.N().S(`PairJSON struct {
+    To jsonRef `)
+//line generator/json_go.qtpl:63
+				qw422016.N().S("`")
+//line generator/json_go.qtpl:63
+				qw422016.N().S(`json:"to"`)
+//line generator/json_go.qtpl:63
+				qw422016.N().S("`")
+//line generator/json_go.qtpl:63
+				qw422016.N().S(`
+`)
+//line generator/json_go.qtpl:65
+			} else {
+//line generator/json_go.qtpl:65
+				qw422016.N().S(`
+type `)
+//line generator/json_go.qtpl:67
+				qw422016.E().S(nsc)
+//line generator/json_go.qtpl:67
+				qw422016.N().S(`JSON struct {
+`)
+//line generator/json_go.qtpl:68
+			}
+//line generator/json_go.qtpl:69
+			for _, f := range c.WritableFields() {
+//line generator/json_go.qtpl:69
+				qw422016.N().S(`    `)
+//line generator/json_go.qtpl:70
+				qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/json_go.qtpl:70
+				qw422016.N().S(` `)
+//line generator/json_go.qtpl:70
+				qw422016.E().S(f.JSONType())
+//line generator/json_go.qtpl:70
+				qw422016.N().S(` `)
+//line generator/json_go.qtpl:70
+				qw422016.N().S("`")
+//line generator/json_go.qtpl:70
+				qw422016.N().S(`json:"`)
+//line generator/json_go.qtpl:70
+				qw422016.E().S(f.Name.Singular.Camel)
+//line generator/json_go.qtpl:70
+				qw422016.N().S(`"`)
+//line generator/json_go.qtpl:70
+				qw422016.N().S("`")
+//line generator/json_go.qtpl:70
+				qw422016.N().S(`
+`)
+//line generator/json_go.qtpl:71
+			}
+//line generator/json_go.qtpl:71
+			qw422016.N().S(`}
+`)
+//line generator/json_go.qtpl:73
+		}
+//line generator/json_go.qtpl:74
+	}
+//line generator/json_go.qtpl:74
+	qw422016.N().S(`
+// ExportJSON writes every living entity with its name, components, tags and
+// relationships, meant for debugging and fixtures rather than persistence
+func (w *World) ExportJSON(writer io.Writer) error {
+    ex := &jsonExporter{
+        names:    map[Entity]string{},
+        entities: map[Entity]*entityJSON{},
+    }
+
+    counts := map[string]int{}
+    for _, c := range w.AllNames {
+        counts[c.Value]++
+    }
+    for e, c := range w.AllNames {
+        if counts[c.Value] == 1 {
+            ex.names[e] = c.Value
+        }
+    }
+
+    doc := worldJSON{SchemaVersion: SchemaVersion}
+    for _, e := range slices.Sorted(w.livingEntities.AllEntities) {
+        id := e
+        ej := &entityJSON{ID: &id}
+        if c, ok := w.Name(e); ok {
+            ej.Name = c.Value
+        }
+        ex.entities[e] = ej
+
+        if e == w.resourceEntity {
+            doc.Resources = ej
+        } else {
+            doc.Entities = append(doc.Entities, ej)
+        }
+    }
+
+`)
+//line generator/json_go.qtpl:110
+	for _, c := range data.Components {
+//line generator/json_go.qtpl:111
+		if !c.IsName() {
+//line generator/json_go.qtpl:112
+			nsc := c.Name.Singular.Camel
+
+//line generator/json_go.qtpl:113
+			if c.IsTag {
+//line generator/json_go.qtpl:113
+				qw422016.N().S(`    for _, e := range w.`)
+//line generator/json_go.qtpl:114
+				qw422016.E().S(nsc)
+//line generator/json_go.qtpl:114
+				qw422016.N().S(`Tags.dense {
+        ex.tag(e, "`)
+//line generator/json_go.qtpl:115
+				qw422016.E().S(c.SnapshotName())
+//line generator/json_go.qtpl:115
+				qw422016.N().S(`")
+    }
+`)
+//line generator/json_go.qtpl:117
+			} else if c.IsRelationship {
+//line generator/json_go.qtpl:117
+				qw422016.N().S(`    w.`)
+//line generator/json_go.qtpl:118
+				qw422016.E().S(nsc)
+//line generator/json_go.qtpl:118
+				qw422016.N().S(`Relationships.byFrom.Scan(func(pair `)
+//line generator/json_go.qtpl:118
+				qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/json_go.qtpl:118
+				qw422016.N().S(`RelationshipPair) bool {
+`)
+//line generator/json_go.qtpl:119
+				if len(c.Fields) > 0 {
+//line generator/json_go.qtpl:119
+					qw422016.N().S(`        ex.pair(pair.From, "`)
+//line generator/json_go.qtpl:120
+					qw422016.E().S(c.SnapshotName())
+//line generator/json_go.qtpl:120
+					qw422016.N().S(`", `)
+//line generator/json_go.qtpl:120
+					qw422016.E().S(nsc)
+//line generator/json_go.qtpl:120
+					qw422016.N().S(`PairJSON{
+            To: ex.ref(pair.To),
+`)
+//line generator/json_go.qtpl:122
+					for _, f := range c.WritableFields() {
+//line generator/json_go.qtpl:122
+						qw422016.N().S(`            `)
+//line generator/json_go.qtpl:123
+						qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/json_go.qtpl:123
+						qw422016.N().S(`: `)
+//line generator/json_go.qtpl:123
+						qw422016.N().S(jsonExport(f, "pair."+f.Name.Singular.Pascal))
+//line generator/json_go.qtpl:123
+						qw422016.N().S(`,
+`)
+//line generator/json_go.qtpl:124
+					}
+//line generator/json_go.qtpl:124
+					qw422016.N().S(`        })
+`)
+//line generator/json_go.qtpl:126
+				} else {
+//line generator/json_go.qtpl:126
+					qw422016.N().S(`        ex.pair(pair.From, "`)
+//line generator/json_go.qtpl:127
+					qw422016.E().S(c.SnapshotName())
+//line generator/json_go.qtpl:127
+					qw422016.N().S(`", ex.ref(pair.To))
+`)
+//line generator/json_go.qtpl:128
+				}
+//line generator/json_go.qtpl:128
+				qw422016.N().S(`        return true
+    })
+`)
+//line generator/json_go.qtpl:131
+			} else {
+//line generator/json_go.qtpl:131
+				qw422016.N().S(`    for i, e := range w.`)
+//line generator/json_go.qtpl:132
+				qw422016.E().S(nsc)
+//line generator/json_go.qtpl:132
+				qw422016.N().S(`Components.dense {
+        c := &w.`)
+//line generator/json_go.qtpl:133
+				qw422016.E().S(nsc)
+//line generator/json_go.qtpl:133
+				qw422016.N().S(`Components.data[i]
+        ex.component(e, "`)
+//line generator/json_go.qtpl:134
+				qw422016.E().S(c.SnapshotName())
+//line generator/json_go.qtpl:134
+				qw422016.N().S(`", `)
+//line generator/json_go.qtpl:134
+				qw422016.E().S(nsc)
+//line generator/json_go.qtpl:134
+				qw422016.N().S(`JSON{
+`)
+//line generator/json_go.qtpl:135
+				for _, f := range c.WritableFields() {
+//line generator/json_go.qtpl:135
+					qw422016.N().S(`            `)
+//line generator/json_go.qtpl:136
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/json_go.qtpl:136
+					qw422016.N().S(`: `)
+//line generator/json_go.qtpl:136
+					qw422016.N().S(jsonExport(f, "c."+f.Name.Singular.Pascal))
+//line generator/json_go.qtpl:136
+					qw422016.N().S(`,
+`)
+//line generator/json_go.qtpl:137
+				}
+//line generator/json_go.qtpl:137
+				qw422016.N().S(`        })
+    }
+`)
+//line generator/json_go.qtpl:140
+			}
+//line generator/json_go.qtpl:141
+		}
+//line generator/json_go.qtpl:142
+	}
+//line generator/json_go.qtpl:142
+	qw422016.N().S(`
+    if ex.err != nil {
+        return ex.err
+    }
+
+    encoder := json.NewEncoder(writer)
+    encoder.SetIndent("", "  ")
+    return encoder.Encode(doc)
+}
+
+type jsonExporter struct {
+    names    map[Entity]string
+    entities map[Entity]*entityJSON
+    err      error
+}
+
+func (ex *jsonExporter) ref(e Entity) jsonRef {
+    return jsonRef{id: e, name: ex.names[e]}
+}
+
+func (ex *jsonExporter) refs(entities []Entity) []jsonRef {
+    if entities == nil {
+        return nil
+    }
+    refs := make([]jsonRef, len(entities))
+    for i, e := range entities {
+        refs[i] = ex.ref(e)
+    }
+    return refs
+}
+
+func (ex *jsonExporter) marshal(v any) json.RawMessage {
+    data, err := json.Marshal(v)
+    if err != nil && ex.err == nil {
+        ex.err = err
+    }
+    return data
+}
+
+func (ex *jsonExporter) component(e Entity, name string, v any) {
+    ej := ex.entities[e]
+    if ej.Components == nil {
+        ej.Components = map[string]json.RawMessage{}
+    }
+    ej.Components[name] = ex.marshal(v)
+}
+
+func (ex *jsonExporter) tag(e Entity, name string) {
+    ej := ex.entities[e]
+    ej.Tags = append(ej.Tags, name)
+}
+
+// pair adds a relationship to its From entity, pairs without fields are
+// written as just their target
+func (ex *jsonExporter) pair(from Entity, name string, v any) {
+    ej := ex.entities[from]
+    if ej.Relationships == nil {
+        ej.Relationships = map[string][]json.RawMessage{}
+    }
+    ej.Relationships[name] = append(ej.Relationships[name], ex.marshal(v))
+}
+
+// ImportJSON creates an entity for every entity in a document written by
+// ExportJSON or by hand, returning them in document order. Resources are set
+// on the world's resource entity. Ids and names in the document are only used
+// to resolve references, unknown components, tags or fields are an error. The
+// whole document is checked before anything is written, so on error only the
+// created entities are destroyed again and resources are left alone.
+func (w *World) ImportJSON(reader io.Reader) ([]Entity, error) {
+    var doc worldJSON
+    decoder := json.NewDecoder(reader)
+    decoder.DisallowUnknownFields()
+    if err := decoder.Decode(&doc); err != nil {
+        return nil, fmt.Errorf("failed to decode world: %w", err)
+    }
+    if doc.SchemaVersion > SchemaVersion {
+        return nil, fmt.Errorf("document schema version %d is newer than %d", doc.SchemaVersion, SchemaVersion)
+    }
+
+    im := &jsonImporter{
+        w:     w,
+        ids:   map[Entity]Entity{},
+        names: map[string]Entity{},
+    }
+    entities := w.NextEntities(len(doc.Entities))
+    if err := im.load(doc, entities); err != nil {
+        w.DestroyEntities(entities...)
+        return nil, err
+    }
+    return entities, nil
+}
+
+type jsonImporter struct {
+    w     *World
+    ids   map[Entity]Entity
+    names map[string]Entity
+    err   error
+    // writes are held back until the whole document decoded
+    writes []func()
+}
+
+// register makes the entity referable by its document id and name, names used
+// more than once can't be referenced
+func (im *jsonImporter) register(e Entity, ej *entityJSON) error {
+    if ej.ID != nil {
+        if _, ok := im.ids[*ej.ID]; ok {
+            return fmt.Errorf("duplicate id %d", *ej.ID)
+        }
+        im.ids[*ej.ID] = e
+    }
+    if ej.Name != "" {
+        if _, ok := im.names[ej.Name]; ok {
+            e = Tombstone
+        }
+        im.names[ej.Name] = e
+    }
+    return nil
+}
+
+func (im *jsonImporter) load(doc worldJSON, entities []Entity) error {
+    for i, ej := range doc.Entities {
+        if ej == nil {
+            return fmt.Errorf("entity %d is null", i)
+        }
+        if err := im.register(entities[i], ej); err != nil {
+            return fmt.Errorf("entity %d: %w", i, err)
+        }
+    }
+    if doc.Resources != nil {
+        if err := im.register(im.w.resourceEntity, doc.Resources); err != nil {
+            return fmt.Errorf("resources: %w", err)
+        }
+        if err := im.fill(im.w.resourceEntity, doc.Resources); err != nil {
+            return fmt.Errorf("resources: %w", err)
+        }
+    }
+    for i, ej := range doc.Entities {
+        if err := im.fill(entities[i], ej); err != nil {
+            return fmt.Errorf("entity %d: %w", i, err)
+        }
+    }
+
+    for _, write := range im.writes {
+        write()
+    }
+    return nil
+}
+
+func (im *jsonImporter) write(fn func()) {
+    im.writes = append(im.writes, fn)
+}
+
+func (im *jsonImporter) fail(err error) {
+    if im.err == nil {
+        im.err = err
+    }
+}
+
+// entity resolves a reference, unset ones fall back to the reset value
+func (im *jsonImporter) entity(ref jsonRef, fallback Entity) Entity {
+    if !ref.isSet {
+        return fallback
+    }
+    if ref.name != "" {
+        e, ok := im.names[ref.name]
+        switch {
+        case !ok:
+            im.fail(fmt.Errorf("no entity named %q", ref.name))
+        case e == Tombstone:
+            im.fail(fmt.Errorf("more than one entity is named %q", ref.name))
+        }
+        return e
+    }
+    e, ok := im.ids[ref.id]
+    if !ok {
+        im.fail(fmt.Errorf("no entity with id %d", ref.id))
+    }
+    return e
+}
+
+func (im *jsonImporter) entities(refs []jsonRef) []Entity {
+    if refs == nil {
+        return nil
+    }
+    entities := make([]Entity, len(refs))
+    for i, ref := range refs {
+        entities[i] = im.entity(ref, Tombstone)
+    }
+    return entities
+}
+
+func (im *jsonImporter) unmarshal(data json.RawMessage, v any) {
+    decoder := json.NewDecoder(bytes.NewReader(data))
+    decoder.DisallowUnknownFields()
+    if err := decoder.Decode(v); err != nil {
+        im.fail(err)
+    }
+}
+
+func (im *jsonImporter) target(ref jsonRef) Entity {
+    if !ref.isSet {
+        im.fail(errors.New("pair has no target"))
+    }
+    return im.entity(ref, Tombstone)
+}
+
+func (im *jsonImporter) fill(e Entity, ej *entityJSON) error {
+    w := im.w
+    if ej.Name != "" {
+        im.write(func() { w.SetName(e, ej.Name) })
+    }
+
+    for name, data := range ej.Components {
+        switch name {
+`)
+//line generator/json_go.qtpl:357
+	for _, c := range data.Components {
+//line generator/json_go.qtpl:358
+		if !c.IsTag && !c.IsRelationship && !c.IsName() {
+//line generator/json_go.qtpl:359
+			nsc := c.Name.Singular.Camel
+
+//line generator/json_go.qtpl:359
+			qw422016.N().S(`        case "`)
+//line generator/json_go.qtpl:360
+			qw422016.E().S(c.SnapshotName())
+//line generator/json_go.qtpl:360
+			qw422016.N().S(`":
+            v := `)
+//line generator/json_go.qtpl:361
+			qw422016.E().S(nsc)
+//line generator/json_go.qtpl:361
+			qw422016.N().S(`JSON{
+`)
+//line generator/json_go.qtpl:362
+			for _, f := range c.WritableFields() {
+//line generator/json_go.qtpl:363
+				if !f.IsEntity {
+//line generator/json_go.qtpl:363
+					qw422016.N().S(`                `)
+//line generator/json_go.qtpl:364
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/json_go.qtpl:364
+					qw422016.N().S(`: `)
+//line generator/json_go.qtpl:364
+					qw422016.N().S(f.ResetValue)
+//line generator/json_go.qtpl:364
+					qw422016.N().S(`,
+`)
+//line generator/json_go.qtpl:365
+				}
+//line generator/json_go.qtpl:366
+			}
+//line generator/json_go.qtpl:366
+			qw422016.N().S(`            }
+            im.unmarshal(data, &v)
+            c := `)
+//line generator/json_go.qtpl:369
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/json_go.qtpl:369
+			qw422016.N().S(`Component{
+`)
+//line generator/json_go.qtpl:370
+			for _, f := range c.Fields {
+//line generator/json_go.qtpl:371
+				if f.IsDeprecated {
+//line generator/json_go.qtpl:371
+					qw422016.N().S(`                `)
+//line generator/json_go.qtpl:372
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/json_go.qtpl:372
+					qw422016.N().S(`: `)
+//line generator/json_go.qtpl:372
+					qw422016.N().S(f.ResetValue)
+//line generator/json_go.qtpl:372
+					qw422016.N().S(`,
+`)
+//line generator/json_go.qtpl:373
+				} else {
+//line generator/json_go.qtpl:373
+					qw422016.N().S(`                `)
+//line generator/json_go.qtpl:374
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/json_go.qtpl:374
+					qw422016.N().S(`: `)
+//line generator/json_go.qtpl:374
+					qw422016.N().S(jsonImport(f, "v."+f.Name.Singular.Pascal))
+//line generator/json_go.qtpl:374
+					qw422016.N().S(`,
+`)
+//line generator/json_go.qtpl:375
+				}
+//line generator/json_go.qtpl:376
+			}
+//line generator/json_go.qtpl:376
+			qw422016.N().S(`            }
+`)
+//line generator/json_go.qtpl:378
+			if c.IsOnlyOneField {
+//line generator/json_go.qtpl:378
+				qw422016.N().S(`            im.write(func() { w.Set`)
+//line generator/json_go.qtpl:379
+				qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/json_go.qtpl:379
+				qw422016.N().S(`(e, c.`)
+//line generator/json_go.qtpl:379
+				qw422016.E().S(c.Fields[0].Name.Singular.Pascal)
+//line generator/json_go.qtpl:379
+				qw422016.N().S(`) })
+`)
+//line generator/json_go.qtpl:380
+			} else {
+//line generator/json_go.qtpl:380
+				qw422016.N().S(`            im.write(func() { w.Set`)
+//line generator/json_go.qtpl:381
+				qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/json_go.qtpl:381
+				qw422016.N().S(`(e, c) })
+`)
+//line generator/json_go.qtpl:382
+			}
+//line generator/json_go.qtpl:383
+		}
+//line generator/json_go.qtpl:384
+	}
+//line generator/json_go.qtpl:384
+	qw422016.N().S(`        default:
+            return fmt.Errorf("unknown component %q", name)
+        }
+        if im.err != nil {
+            return fmt.Errorf("component %q: %w", name, im.err)
+        }
+    }
+
+    for _, name := range ej.Tags {
+        switch name {
+`)
+//line generator/json_go.qtpl:395
+	for _, c := range data.Components {
+//line generator/json_go.qtpl:396
+		if c.IsTag {
+//line generator/json_go.qtpl:396
+			qw422016.N().S(`        case "`)
+//line generator/json_go.qtpl:397
+			qw422016.E().S(c.SnapshotName())
+//line generator/json_go.qtpl:397
+			qw422016.N().S(`":
+            im.write(func() { w.TagWith`)
+//line generator/json_go.qtpl:398
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/json_go.qtpl:398
+			qw422016.N().S(`(e) })
+`)
+//line generator/json_go.qtpl:399
+		}
+//line generator/json_go.qtpl:400
+	}
+//line generator/json_go.qtpl:400
+	qw422016.N().S(`        default:
+            return fmt.Errorf("unknown tag %q", name)
+        }
+    }
+
+    for name, pairs := range ej.Relationships {
+        for _, data := range pairs {
+            switch name {
+`)
+//line generator/json_go.qtpl:409
+	for _, c := range data.Components {
+//line generator/json_go.qtpl:410
+		if c.IsRelationship {
+//line generator/json_go.qtpl:411
+			nsc := c.Name.Singular.Camel
+
+//line generator/json_go.qtpl:411
+			qw422016.N().S(`            case "`)
+//line generator/json_go.qtpl:412
+			qw422016.E().S(c.SnapshotName())
+//line generator/json_go.qtpl:412
+			qw422016.N().S(`":
+`)
+//line generator/json_go.qtpl:413
+			if len(c.Fields) > 0 {
+//line generator/json_go.qtpl:413
+				qw422016.N().S(`                v := `)
+//line generator/json_go.qtpl:414
+				qw422016.E().S(nsc)
+//line generator/json_go.qtpl:414
+				qw422016.N().S(`PairJSON{
+`)
+//line generator/json_go.qtpl:415
+				for _, f := range c.WritableFields() {
+//line generator/json_go.qtpl:416
+					if !f.IsEntity {
+//line generator/json_go.qtpl:416
+						qw422016.N().S(`                    `)
+//line generator/json_go.qtpl:417
+						qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/json_go.qtpl:417
+						qw422016.N().S(`: `)
+//line generator/json_go.qtpl:417
+						qw422016.N().S(f.ResetValue)
+//line generator/json_go.qtpl:417
+						qw422016.N().S(`,
+`)
+//line generator/json_go.qtpl:418
+					}
+//line generator/json_go.qtpl:419
+				}
+//line generator/json_go.qtpl:419
+				qw422016.N().S(`                }
+                im.unmarshal(data, &v)
+                to := im.target(v.To)
+`)
+//line generator/json_go.qtpl:423
+				for _, f := range c.WritableFields() {
+//line generator/json_go.qtpl:423
+					qw422016.N().S(`                field`)
+//line generator/json_go.qtpl:424
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/json_go.qtpl:424
+					qw422016.N().S(` := `)
+//line generator/json_go.qtpl:424
+					qw422016.N().S(jsonImport(f, "v."+f.Name.Singular.Pascal))
+//line generator/json_go.qtpl:424
+					qw422016.N().S(`
+`)
+//line generator/json_go.qtpl:425
+				}
+//line generator/json_go.qtpl:425
+				qw422016.N().S(`                im.write(func() {
+                    w.Link`)
+//line generator/json_go.qtpl:427
+				qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/json_go.qtpl:427
+				qw422016.N().S(`(
+                        to, e,
+`)
+//line generator/json_go.qtpl:429
+				for _, f := range c.WritableFields() {
+//line generator/json_go.qtpl:429
+					qw422016.N().S(`                        field`)
+//line generator/json_go.qtpl:430
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/json_go.qtpl:430
+					qw422016.N().S(`,
+`)
+//line generator/json_go.qtpl:431
+				}
+//line generator/json_go.qtpl:431
+				qw422016.N().S(`                    )
+                })
+`)
+//line generator/json_go.qtpl:434
+			} else {
+//line generator/json_go.qtpl:434
+				qw422016.N().S(`                var ref jsonRef
+                im.unmarshal(data, &ref)
+                to := im.target(ref)
+                im.write(func() { w.Link`)
+//line generator/json_go.qtpl:438
+				qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/json_go.qtpl:438
+				qw422016.N().S(`(to, e) })
+`)
+//line generator/json_go.qtpl:439
+			}
+//line generator/json_go.qtpl:440
+		}
+//line generator/json_go.qtpl:441
+	}
+//line generator/json_go.qtpl:441
+	qw422016.N().S(`            default:
+                return fmt.Errorf("unknown relationship %q", name)
+            }
+            if im.err != nil {
+                return fmt.Errorf("relationship %q: %w", name, im.err)
+            }
+        }
+    }
+
+    return nil
+}
+
+`)
+//line generator/json_go.qtpl:454
+}
+
+//line generator/json_go.qtpl:454
+func writejsonTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
+//line generator/json_go.qtpl:454
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/json_go.qtpl:454
+	streamjsonTemplate(qw422016, data)
+//line generator/json_go.qtpl:454
+	qt422016.ReleaseWriter(qw422016)
+//line generator/json_go.qtpl:454
+}
+
+//line generator/json_go.qtpl:454
+func jsonTemplate(data *ecsTmplData) string {
+//line generator/json_go.qtpl:454
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/json_go.qtpl:454
+	writejsonTemplate(qb422016, data)
+//line generator/json_go.qtpl:454
+	qs422016 := string(qb422016.B)
+//line generator/json_go.qtpl:454
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/json_go.qtpl:454
+	return qs422016
+//line generator/json_go.qtpl:454
+}
@@ -0,0 +1,207 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/a-h/templ"
+	templgenerator "github.com/a-h/templ/generator"
+	"github.com/a-h/templ/parser/v2"
+)
+
+// GeneratedHeader starts every file geckgen writes, only files carrying it are
+// ever overwritten or removed
+const GeneratedHeader = "// Code generated by geckgen. DO NOT EDIT."
+
+// ManifestName is the file in the output folder listing what the last run
+// generated, so files it no longer generates can be removed
+const ManifestName = ".geckgen-manifest.json"
+
+type manifest struct {
+	Files []string `json:"files"`
+}
+
+// ReadManifest lists the files the last run generated into folder, it is empty
+// when the folder was never generated into
+func ReadManifest(folder string) ([]string, error) {
+	b, err := os.ReadFile(filepath.Join(folder, ManifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	m := manifest{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return m.Files, nil
+}
+
+// IsGenerated is true when the contents start with GeneratedHeader, templ
+// output puts its own header first
+func IsGenerated(contents []byte) bool {
+	head := contents[:min(len(contents), 256)]
+	return bytes.Contains(head, []byte(GeneratedHeader))
+}
+
+// output collects every generated file so a run only writes what changed
+type output struct {
+	folder string
+	files  map[string][]byte
+	// force overwrites files without GeneratedHeader, for folders generated
+	// before geckgen headed its files
+	force bool
+}
+
+func newOutput(folder string) *output {
+	return &output{folder: folder, files: map[string][]byte{}}
+}
+
+// add headers and formats Go files, templ files are added along with the Go
+// code templ would generate for them
+func (o *output) add(name, contents string) error {
+	if _, ok := o.files[name]; ok {
+		return fmt.Errorf("'%s' is generated twice", name)
+	}
+	if !IsGenerated([]byte(contents)) {
+		contents = GeneratedHeader + "\n\n" + strings.TrimLeft(contents, "\n")
+	}
+
+	b := []byte(contents)
+	switch filepath.Ext(name) {
+	case ".go":
+		formatted, err := formatGo(name, b)
+		if err != nil {
+			return err
+		}
+		b = formatted
+	case ".templ":
+		generated, err := generateTempl(name, contents)
+		if err != nil {
+			return err
+		}
+		if err := o.add(strings.TrimSuffix(name, ".templ")+"_templ.go", generated); err != nil {
+			return err
+		}
+	}
+
+	o.files[name] = b
+	return nil
+}
+
+// write leaves unchanged files alone so their mtimes stay stable, then removes
+// generated files the previous run wrote that are no longer generated. Every
+// file is checked before any is written, so a refused file leaves the folder
+// as it was.
+func (o *output) write() error {
+	if err := os.MkdirAll(o.folder, 0755); err != nil {
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+
+	previous, err := ReadManifest(o.folder)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(o.files))
+	for name := range o.files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var changed, refused []string
+	for _, name := range names {
+		fp := filepath.Join(o.folder, name)
+		existing, err := os.ReadFile(fp)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return fmt.Errorf("failed to read '%s': %w", fp, err)
+		case bytes.Equal(existing, o.files[name]):
+			continue
+		case !IsGenerated(existing) && !o.force:
+			refused = append(refused, fp)
+			continue
+		}
+		changed = append(changed, name)
+	}
+	if len(refused) > 0 {
+		return fmt.Errorf(
+			"not generated by geckgen, move them out of the way or generate with -force to take them over: '%s'",
+			strings.Join(refused, "', '"),
+		)
+	}
+
+	for _, name := range changed {
+		fp := filepath.Join(o.folder, name)
+		if err := os.WriteFile(fp, o.files[name], 0644); err != nil {
+			return fmt.Errorf("failed to write to file: %w", err)
+		}
+	}
+	written := len(changed)
+
+	removed := 0
+	for _, name := range previous {
+		if _, ok := o.files[name]; ok {
+			continue
+		}
+		fp := filepath.Join(o.folder, name)
+		existing, err := os.ReadFile(fp)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read '%s': %w", fp, err)
+		}
+		if !IsGenerated(existing) {
+			// taken over by hand since, leave it be
+			continue
+		}
+		if err := os.Remove(fp); err != nil {
+			return fmt.Errorf("failed to remove stale file: %w", err)
+		}
+		removed++
+	}
+
+	if !slices.Equal(previous, names) {
+		b, err := json.MarshalIndent(manifest{Files: names}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal manifest: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(o.folder, ManifestName), append(b, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write manifest: %w", err)
+		}
+	}
+
+	log.Printf("Wrote %d of %d files, removed %d stale files", written, len(names), removed)
+	return nil
+}
+
+// generateTempl is what templ generate writes for the template
+func generateTempl(filename, contents string) (string, error) {
+	t, err := parser.ParseString(contents)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse '%s': %w", filename, err)
+	}
+	t.Filepath = filename
+
+	var sb strings.Builder
+	if _, err := templgenerator.Generate(
+		t, &sb,
+		templgenerator.WithVersion(templ.Version()),
+		templgenerator.WithFileName(filename),
+	); err != nil {
+		return "", fmt.Errorf("failed to generate '%s': %w", filename, err)
+	}
+	return sb.String(), nil
+}
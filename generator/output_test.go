@@ -0,0 +1,274 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeOutput generates files into folder the way BuildECS would
+func writeOutput(t *testing.T, folder string, files map[string]string, force bool) error {
+	t.Helper()
+	out := newOutput(folder)
+	out.force = force
+	for name, contents := range files {
+		require.NoError(t, out.add(name, contents))
+	}
+	return out.write()
+}
+
+func goFile(body string) string {
+	return "package ecs\n\n" + body
+}
+
+func TestOutputWrite(t *testing.T) {
+	folder := filepath.Join(t.TempDir(), "ecs")
+	files := map[string]string{
+		"a.go": goFile("type A struct{}\n"),
+		"b.go": goFile("type B struct{}\n"),
+	}
+
+	// first run creates the folder, headers every file and lists them
+	require.NoError(t, writeOutput(t, folder, files, false))
+	for name := range files {
+		b, err := os.ReadFile(filepath.Join(folder, name))
+		require.NoError(t, err)
+		assert.True(t, IsGenerated(b), name)
+	}
+	manifest, err := ReadManifest(folder)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "b.go"}, manifest)
+
+	// an unchanged run writes nothing, so mtimes stay put
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, name := range []string{"a.go", "b.go", ManifestName} {
+		require.NoError(t, os.Chtimes(filepath.Join(folder, name), old, old))
+	}
+	require.NoError(t, writeOutput(t, folder, files, false))
+	for _, name := range []string{"a.go", "b.go", ManifestName} {
+		info, err := os.Stat(filepath.Join(folder, name))
+		require.NoError(t, err)
+		assert.True(t, info.ModTime().Equal(old), name)
+	}
+
+	// hand written files next to generated ones are left alone
+	handWritten := filepath.Join(folder, "helpers.go")
+	require.NoError(t, os.WriteFile(handWritten, []byte(goFile("func help() {}\n")), 0644))
+
+	// files no longer generated are pruned
+	delete(files, "b.go")
+	require.NoError(t, writeOutput(t, folder, files, false))
+	assert.NoFileExists(t, filepath.Join(folder, "b.go"))
+	assert.FileExists(t, handWritten)
+	manifest, err = ReadManifest(folder)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go"}, manifest)
+}
+
+func TestOutputKeepsTakenOverFiles(t *testing.T) {
+	folder := t.TempDir()
+	require.NoError(t, writeOutput(t, folder, map[string]string{
+		"a.go": goFile("type A struct{}\n"),
+		"b.go": goFile("type B struct{}\n"),
+	}, false))
+
+	// b.go lost its header, it was taken over by hand and is never pruned
+	takenOver := []byte(goFile("type B int\n"))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "b.go"), takenOver, 0644))
+	require.NoError(t, writeOutput(t, folder, map[string]string{
+		"a.go": goFile("type A struct{}\n"),
+	}, false))
+
+	b, err := os.ReadFile(filepath.Join(folder, "b.go"))
+	require.NoError(t, err)
+	assert.Equal(t, takenOver, b)
+}
+
+func TestOutputRefusesHeaderlessFiles(t *testing.T) {
+	for _, withManifest := range []bool{false, true} {
+		name := "legacy"
+		if withManifest {
+			name = "manifest"
+		}
+		t.Run(name, func(t *testing.T) {
+			folder := t.TempDir()
+			if withManifest {
+				require.NoError(t, writeOutput(t, folder, map[string]string{
+					"a.go": goFile("type A struct{}\n"),
+				}, false))
+			}
+			handWritten := []byte(goFile("type B int\n"))
+			require.NoError(t, os.WriteFile(filepath.Join(folder, "b.go"), handWritten, 0644))
+
+			files := map[string]string{
+				"a.go": goFile("type A struct{ X int }\n"),
+				"b.go": goFile("type B struct{}\n"),
+				"c.go": goFile("type C struct{}\n"),
+			}
+			err := writeOutput(t, folder, files, false)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), filepath.Join(folder, "b.go"))
+
+			// nothing is written when any file is refused
+			b, err := os.ReadFile(filepath.Join(folder, "b.go"))
+			require.NoError(t, err)
+			assert.Equal(t, handWritten, b)
+			assert.NoFileExists(t, filepath.Join(folder, "c.go"))
+			manifest, err := ReadManifest(folder)
+			require.NoError(t, err)
+			if withManifest {
+				assert.Equal(t, []string{"a.go"}, manifest)
+				a, err := os.ReadFile(filepath.Join(folder, "a.go"))
+				require.NoError(t, err)
+				assert.NotContains(t, string(a), "X int")
+			} else {
+				assert.Empty(t, manifest)
+				assert.NoFileExists(t, filepath.Join(folder, "a.go"))
+			}
+
+			// forcing takes the file over
+			require.NoError(t, writeOutput(t, folder, files, true))
+			b, err = os.ReadFile(filepath.Join(folder, "b.go"))
+			require.NoError(t, err)
+			assert.True(t, IsGenerated(b))
+			assert.FileExists(t, filepath.Join(folder, "c.go"))
+		})
+	}
+}
+
+func TestIsGenerated(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		contents string
+		expected bool
+	}{
+		{"header", GeneratedHeader + "\n\npackage ecs\n", true},
+		{"templ header", "// Code generated by templ - DO NOT EDIT.\n\n" + GeneratedHeader + "\n\npackage ecs\n", true},
+		{"empty", "", false},
+		{"hand written", "package ecs\n", false},
+		{"other generator", "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage ecs\n", false},
+		{"header too late", "package ecs\n\n" + string(make([]byte, 256)) + GeneratedHeader, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsGenerated([]byte(tc.contents)))
+		})
+	}
+}
+
+func TestFormatGo(t *testing.T) {
+	for _, tc := range []struct {
+		name, src, expected string
+	}{
+		{
+			name: "unused imports are dropped",
+			src: `package ecs
+import (
+	"github.com/btvoidx/mint"
+	"fmt"
+	"slices"
+	"strings"
+)
+func f(s []int) string { return fmt.Sprint(slices.Max(s)) }
+`,
+			expected: `package ecs
+
+import (
+	"fmt"
+	"slices"
+)
+
+func f(s []int) string { return fmt.Sprint(slices.Max(s)) }
+`,
+		},
+		{
+			name: "standard library comes first",
+			src: `package ecs
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/btvoidx/mint"
+	"context"
+)
+func f(ctx context.Context, r chi.Router, m *mint.Emitter) {}
+`,
+			expected: `package ecs
+
+import (
+	"context"
+
+	"github.com/btvoidx/mint"
+	"github.com/go-chi/chi/v5"
+)
+
+func f(ctx context.Context, r chi.Router, m *mint.Emitter) {}
+`,
+		},
+		{
+			name: "a single import needs no block",
+			src: `package ecs
+import (
+	"github.com/delaneyj/geck/components/mathx"
+	"slices"
+)
+type T struct{ V mathx.Vector3[float32] }
+`,
+			expected: `package ecs
+
+import "github.com/delaneyj/geck/components/mathx"
+
+type T struct{ V mathx.Vector3[float32] }
+`,
+		},
+		{
+			name: "renamed and blank imports",
+			src: `package ecs
+import (
+	_ "embed"
+	m "math"
+	"strings"
+)
+var x = m.Pi
+`,
+			expected: `package ecs
+
+import (
+	_ "embed"
+	m "math"
+)
+
+var x = m.Pi
+`,
+		},
+		{
+			name: "no imports left",
+			src: `package ecs
+import "fmt"
+type T struct{}
+`,
+			expected: `package ecs
+
+type T struct{}
+`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			formatted, err := formatGo("test.go", []byte(tc.src))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(formatted))
+		})
+	}
+
+	_, err := formatGo("broken.go", []byte("package ecs\nfunc {"))
+	assert.ErrorContains(t, err, "broken.go")
+}
+
+func TestBuildECSLeavesOptionsAlone(t *testing.T) {
+	opts := validOptions()
+	opts.FolderPath = filepath.Join(t.TempDir(), "ecs")
+	require.NoError(t, BuildECS(t.Context(), opts))
+	assert.Empty(t, opts.PackageName)
+	assert.FileExists(t, filepath.Join(opts.FolderPath, "ecs_world.go"))
+}
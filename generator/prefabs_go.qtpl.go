@@ -0,0 +1,95 @@
+// Code generated by qtc from "prefabs_go.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// package generator
+//
+
+//line generator/prefabs_go.qtpl:3
+package generator
+
+//line generator/prefabs_go.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line generator/prefabs_go.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line generator/prefabs_go.qtpl:3
+func streamprefabsTemplate(qw422016 *qt422016.Writer, data *ecsTmplData) {
+//line generator/prefabs_go.qtpl:3
+	qw422016.N().S(`
+package `)
+//line generator/prefabs_go.qtpl:4
+	qw422016.E().S(data.PackageName)
+//line generator/prefabs_go.qtpl:4
+	qw422016.N().S(`
+
+// maxPrefabDepth bounds how far IsA chains are followed, so a cycle of
+// prefabs cannot recurse forever
+const maxPrefabDepth = 16
+
+// WithPrefab links the entity IsA prefab, components it does not own are read
+// from the prefab until written to
+func WithPrefab(prefab Entity) EntityBuilderOption {
+    return func(w *World, e Entity) {
+        w.LinkIsA(prefab, e)
+    }
+}
+
+// inherit finds the component on one of e's prefabs, depth first in the order
+// the prefabs are linked. Prefabs can inherit from prefabs of their own
+func inherit[T any](w *World, set *SparseSet[T], e Entity) (c T, ok bool) {
+    if w.isARelationships.Len() == 0 {
+        return c, false
+    }
+    return inheritDepth(w, set, e, 0)
+}
+
+func inheritDepth[T any](w *World, set *SparseSet[T], e Entity, depth int) (c T, ok bool) {
+    if depth == maxPrefabDepth {
+        return c, false
+    }
+    w.isARelationships.pairsFrom(e, func(pair IsARelationshipPair) bool {
+        if c, ok = set.Data(pair.To); !ok {
+            c, ok = inheritDepth(w, set, pair.To, depth+1)
+        }
+        return !ok
+    })
+    return c, ok
+}
+
+`)
+//line generator/prefabs_go.qtpl:40
+}
+
+//line generator/prefabs_go.qtpl:40
+func writeprefabsTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
+//line generator/prefabs_go.qtpl:40
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/prefabs_go.qtpl:40
+	streamprefabsTemplate(qw422016, data)
+//line generator/prefabs_go.qtpl:40
+	qt422016.ReleaseWriter(qw422016)
+//line generator/prefabs_go.qtpl:40
+}
+
+//line generator/prefabs_go.qtpl:40
+func prefabsTemplate(data *ecsTmplData) string {
+//line generator/prefabs_go.qtpl:40
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/prefabs_go.qtpl:40
+	writeprefabsTemplate(qb422016, data)
+//line generator/prefabs_go.qtpl:40
+	qs422016 := string(qb422016.B)
+//line generator/prefabs_go.qtpl:40
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/prefabs_go.qtpl:40
+	return qs422016
+//line generator/prefabs_go.qtpl:40
+}
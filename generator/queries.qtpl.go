@@ -31,284 +31,1856 @@ package `)
 //line generator/queries.qtpl:5
 	qw422016.N().S(`
 
-import "sort"
+import (
+    "slices"
+    "sort"
+    "sync"
+`)
+//line generator/queries.qtpl:11
+	if data.IsObservable() {
+//line generator/queries.qtpl:11
+		qw422016.N().S(`    "github.com/btvoidx/mint"
+`)
+//line generator/queries.qtpl:13
+	}
+//line generator/queries.qtpl:13
+	qw422016.N().S(`)
 
 `)
-//line generator/queries.qtpl:10
+//line generator/queries.qtpl:17
 	argsName := "Query" + data.Name.Plural.Pascal + "Args"
 	iterName := "query" + data.Name.Plural.Pascal + "Iter"
-	first := data.Entries[0]
-	rest := data.Entries[1:]
+	first := data.Required[0]
+	rest := data.Required[1:]
 	firstIterName := "All"
 	if first.IsMutable {
 		firstIterName += "Mutable"
 	}
 	firstIterName += first.Name.Plural.Pascal
 
-	firstSparseSetName := first.Name.Plural.Camel
-	if first.ComponentOrTag.IsTag {
-		firstSparseSetName += "Tags"
-	} else {
-		firstSparseSetName += "Components"
-	}
+	hasParams := data.HasParams()
+	driver := data.TargetDriver()
 
-//line generator/queries.qtpl:27
+	groupName := data.Name.Singular.Camel
+	groupLen := groupName + "GroupLen"
+
+//line generator/queries.qtpl:33
 	qw422016.N().S(`
 type `)
-//line generator/queries.qtpl:28
+//line generator/queries.qtpl:34
 	qw422016.E().S(argsName)
-//line generator/queries.qtpl:28
+//line generator/queries.qtpl:34
 	qw422016.N().S(` struct {
     `)
-//line generator/queries.qtpl:29
+//line generator/queries.qtpl:35
 	for _, arg := range data.Entries {
-//line generator/queries.qtpl:29
+//line generator/queries.qtpl:35
 		qw422016.N().S(`    `)
-//line generator/queries.qtpl:30
-		if !arg.ComponentOrTag.IsTag {
-//line generator/queries.qtpl:30
-			qw422016.N().S(`
-    `)
-//line generator/queries.qtpl:31
-			qw422016.E().S(arg.Name.Singular.Pascal)
-//line generator/queries.qtpl:31
-			qw422016.N().S(` `)
-//line generator/queries.qtpl:31
-			if arg.IsMutable {
-//line generator/queries.qtpl:31
-				qw422016.N().S(`*`)
-//line generator/queries.qtpl:31
-			}
-//line generator/queries.qtpl:31
-			qw422016.E().S(arg.Name.Singular.Pascal)
-//line generator/queries.qtpl:31
-			qw422016.N().S(`Component
-    `)
-//line generator/queries.qtpl:32
-		}
-//line generator/queries.qtpl:32
-		qw422016.N().S(`
-    `)
-//line generator/queries.qtpl:33
-	}
-//line generator/queries.qtpl:33
-	qw422016.N().S(`
-}
-
-type `)
-//line generator/queries.qtpl:36
-	qw422016.E().S(iterName)
-//line generator/queries.qtpl:36
-	qw422016.N().S(`  func(e Entity, args `)
 //line generator/queries.qtpl:36
-	qw422016.E().S(argsName)
+		if arg.IsOptional || arg.IsOr {
 //line generator/queries.qtpl:36
-	qw422016.N().S(`) bool
-
-
-func(w *World) Query`)
-//line generator/queries.qtpl:39
-	qw422016.E().S(data.Name.Singular.Pascal)
-//line generator/queries.qtpl:39
-	qw422016.N().S(`(yield `)
+			qw422016.N().S(`
+        `)
+//line generator/queries.qtpl:37
+			if arg.ComponentOrTag.IsTag {
+//line generator/queries.qtpl:37
+				qw422016.N().S(`
+    Has`)
+//line generator/queries.qtpl:38
+				qw422016.E().S(arg.Name.Singular.Pascal)
+//line generator/queries.qtpl:38
+				qw422016.N().S(` bool
+        `)
 //line generator/queries.qtpl:39
-	qw422016.E().S(iterName)
+			} else {
 //line generator/queries.qtpl:39
-	qw422016.N().S(`) {
-    args := `)
+				qw422016.N().S(`
+    // `)
 //line generator/queries.qtpl:40
-	qw422016.E().S(argsName)
+				qw422016.E().S(arg.Name.Singular.Pascal)
 //line generator/queries.qtpl:40
-	qw422016.N().S(`{}
-
+				qw422016.N().S(` is nil when Has`)
+//line generator/queries.qtpl:40
+				qw422016.E().S(arg.Name.Singular.Pascal)
+//line generator/queries.qtpl:40
+				qw422016.N().S(` is false
     `)
+//line generator/queries.qtpl:41
+				qw422016.E().S(arg.Name.Singular.Pascal)
+//line generator/queries.qtpl:41
+				qw422016.N().S(` *`)
+//line generator/queries.qtpl:41
+				qw422016.E().S(arg.Name.Singular.Pascal)
+//line generator/queries.qtpl:41
+				qw422016.N().S(`Component
+    Has`)
 //line generator/queries.qtpl:42
-	if len(rest) > 0 {
+				qw422016.E().S(arg.Name.Singular.Pascal)
 //line generator/queries.qtpl:42
-		qw422016.N().S(`
-    var ok bool
+				qw422016.N().S(` bool
+            `)
+//line generator/queries.qtpl:43
+				if !arg.IsMutable {
+//line generator/queries.qtpl:43
+					qw422016.N().S(`
     `)
 //line generator/queries.qtpl:44
-	}
+					qw422016.E().S(arg.Name.Singular.Camel)
 //line generator/queries.qtpl:44
-	qw422016.N().S(`
-
-`)
+					qw422016.N().S(` `)
+//line generator/queries.qtpl:44
+					qw422016.E().S(arg.Name.Singular.Pascal)
+//line generator/queries.qtpl:44
+					qw422016.N().S(`Component
+            `)
+//line generator/queries.qtpl:45
+				}
+//line generator/queries.qtpl:45
+				qw422016.N().S(`
+        `)
 //line generator/queries.qtpl:46
-	if first.ComponentOrTag.IsTag {
+			}
 //line generator/queries.qtpl:46
-		qw422016.N().S(`    for e := range w.`)
+			qw422016.N().S(`
+    `)
 //line generator/queries.qtpl:47
-		qw422016.E().S(firstIterName)
+		} else if arg.ComponentOrTag.IsRelationship {
 //line generator/queries.qtpl:47
-		qw422016.N().S(`Entities {
-`)
+			qw422016.N().S(`
+        `)
 //line generator/queries.qtpl:48
-	} else {
+			if !arg.IsWithout {
 //line generator/queries.qtpl:48
-		qw422016.N().S(`    for e, first := range w.`)
+				qw422016.N().S(`
+    `)
 //line generator/queries.qtpl:49
-		qw422016.E().S(firstIterName)
+				qw422016.E().S(arg.Name.Singular.Pascal)
 //line generator/queries.qtpl:49
-		qw422016.N().S(` {
-        args.`)
+				qw422016.N().S(` `)
+//line generator/queries.qtpl:49
+				qw422016.E().S(arg.Name.Singular.Pascal)
+//line generator/queries.qtpl:49
+				qw422016.N().S(`RelationshipPair
+        `)
 //line generator/queries.qtpl:50
-		qw422016.E().S(first.Name.Singular.Pascal)
+			}
 //line generator/queries.qtpl:50
-		qw422016.N().S(` = first
-`)
+			qw422016.N().S(`
+    `)
 //line generator/queries.qtpl:51
-	}
+		} else if !arg.IsWithout && !arg.ComponentOrTag.IsTag {
 //line generator/queries.qtpl:51
-	qw422016.N().S(`        ok  = true
-
-`)
+			qw422016.N().S(`
+    `)
+//line generator/queries.qtpl:52
+			qw422016.E().S(arg.Name.Singular.Pascal)
+//line generator/queries.qtpl:52
+			qw422016.N().S(` `)
+//line generator/queries.qtpl:52
+			if arg.IsMutable {
+//line generator/queries.qtpl:52
+				qw422016.N().S(`*`)
+//line generator/queries.qtpl:52
+			}
+//line generator/queries.qtpl:52
+			qw422016.E().S(arg.Name.Singular.Pascal)
+//line generator/queries.qtpl:52
+			qw422016.N().S(`Component
+    `)
+//line generator/queries.qtpl:53
+		}
+//line generator/queries.qtpl:53
+		qw422016.N().S(`
+    `)
 //line generator/queries.qtpl:54
-	for _, e := range rest {
+	}
 //line generator/queries.qtpl:54
-		qw422016.N().S(`            `)
-//line generator/queries.qtpl:55
-		if e.ComponentOrTag.IsTag {
-//line generator/queries.qtpl:55
-			qw422016.N().S(`
-            if !w.Has`)
-//line generator/queries.qtpl:56
-			qw422016.E().S(e.Name.Singular.Pascal)
-//line generator/queries.qtpl:56
-			qw422016.N().S(`Tag(e) {
-                continue
-            }
-            `)
-//line generator/queries.qtpl:59
-		} else {
-//line generator/queries.qtpl:59
-			qw422016.N().S(`
-                `)
-//line generator/queries.qtpl:60
-			if e.IsMutable {
+	qw422016.N().S(`
+}
+
+// Query`)
+//line generator/queries.qtpl:57
+	qw422016.E().S(data.Name.Singular.Pascal)
+//line generator/queries.qtpl:57
+	qw422016.N().S(`Access is what the query touches, for systems declaring their access
+var Query`)
+//line generator/queries.qtpl:58
+	qw422016.E().S(data.Name.Singular.Pascal)
+//line generator/queries.qtpl:58
+	qw422016.N().S(`Access = Access{
+    Reads: []ComponentID{
+`)
 //line generator/queries.qtpl:60
-				qw422016.N().S(`
-            args.`)
-//line generator/queries.qtpl:61
-				qw422016.E().S(e.Name.Singular.Pascal)
-//line generator/queries.qtpl:61
-				qw422016.N().S(`, ok = w.Mutable`)
+	for _, entry := range data.Entries {
 //line generator/queries.qtpl:61
-				qw422016.E().S(e.Name.Singular.Pascal)
+		if !entry.IsMutable {
 //line generator/queries.qtpl:61
-				qw422016.N().S(`(e)
-                `)
+			qw422016.N().S(`        `)
 //line generator/queries.qtpl:62
-			} else {
+			qw422016.E().S(entry.Name.Singular.Pascal)
 //line generator/queries.qtpl:62
-				qw422016.N().S(`
-            args.`)
-//line generator/queries.qtpl:63
-				qw422016.E().S(e.Name.Singular.Pascal)
-//line generator/queries.qtpl:63
-				qw422016.N().S(`, ok = w.`)
-//line generator/queries.qtpl:63
-				qw422016.E().S(e.Name.Singular.Pascal)
+			qw422016.N().S(`ID,
+`)
 //line generator/queries.qtpl:63
-				qw422016.N().S(`(e)
-                `)
+		}
 //line generator/queries.qtpl:64
-			}
+	}
 //line generator/queries.qtpl:64
-			qw422016.N().S(`
-            `)
-//line generator/queries.qtpl:65
-		}
-//line generator/queries.qtpl:65
-		qw422016.N().S(`
-            if !ok {
-                continue
-            }
+	qw422016.N().S(`    },
+    Writes: []ComponentID{
 `)
+//line generator/queries.qtpl:67
+	for _, entry := range data.Entries {
+//line generator/queries.qtpl:68
+		if entry.IsMutable {
+//line generator/queries.qtpl:68
+			qw422016.N().S(`        `)
 //line generator/queries.qtpl:69
-	}
+			qw422016.E().S(entry.Name.Singular.Pascal)
 //line generator/queries.qtpl:69
-	qw422016.N().S(`
-
-        if !yield(e, args) {
-            break
-        }
-    }
+			qw422016.N().S(`ID,
+`)
+//line generator/queries.qtpl:70
+		}
+//line generator/queries.qtpl:71
+	}
+//line generator/queries.qtpl:71
+	qw422016.N().S(`    },
 }
 
-func(w *World) Query`)
+type `)
+//line generator/queries.qtpl:75
+	qw422016.E().S(iterName)
+//line generator/queries.qtpl:75
+	qw422016.N().S(`  func(e Entity, args `)
+//line generator/queries.qtpl:75
+	qw422016.E().S(argsName)
+//line generator/queries.qtpl:75
+	qw422016.N().S(`) bool
+
+`)
+//line generator/queries.qtpl:77
+	if hasParams {
+//line generator/queries.qtpl:77
+		qw422016.N().S(`// Query`)
 //line generator/queries.qtpl:78
-	qw422016.E().S(data.Name.Singular.Pascal)
+		qw422016.E().S(data.Name.Singular.Pascal)
+//line generator/queries.qtpl:78
+		qw422016.N().S(` yields the matches for the given `)
+//line generator/queries.qtpl:78
+		if data.HasChangeFilters() {
+//line generator/queries.qtpl:78
+			qw422016.N().S(`change tick`)
+//line generator/queries.qtpl:78
+			if len(data.TargetArgs()) > 0 {
+//line generator/queries.qtpl:78
+				qw422016.N().S(` and `)
+//line generator/queries.qtpl:78
+			}
+//line generator/queries.qtpl:78
+		}
+//line generator/queries.qtpl:78
+		if len(data.TargetArgs()) > 0 {
+//line generator/queries.qtpl:78
+			qw422016.N().S(`relationship targets`)
+//line generator/queries.qtpl:78
+		}
 //line generator/queries.qtpl:78
-	qw422016.N().S(`Entities(yield func(e Entity) bool) {
-    for e := range w.`)
+		qw422016.N().S(`
+func (w *World) Query`)
 //line generator/queries.qtpl:79
-	qw422016.E().S(firstIterName)
+		qw422016.E().S(data.Name.Singular.Pascal)
 //line generator/queries.qtpl:79
-	qw422016.N().S(`Entities {
-`)
+		qw422016.N().S(`(`)
+//line generator/queries.qtpl:79
+		qw422016.E().S(data.Params())
+//line generator/queries.qtpl:79
+		qw422016.N().S(`) func(yield `)
+//line generator/queries.qtpl:79
+		qw422016.E().S(iterName)
+//line generator/queries.qtpl:79
+		qw422016.N().S(`) {
+    return func(yield `)
+//line generator/queries.qtpl:80
+		qw422016.E().S(iterName)
 //line generator/queries.qtpl:80
-	for _, e := range rest {
+		qw422016.N().S(`) {
+        w.query`)
 //line generator/queries.qtpl:81
-		if e.ComponentOrTag.IsTag {
+		qw422016.E().S(data.Name.Singular.Pascal)
 //line generator/queries.qtpl:81
-			qw422016.N().S(`            if !w.Has`)
-//line generator/queries.qtpl:82
-			qw422016.E().S(e.Name.Singular.Pascal)
-//line generator/queries.qtpl:82
-			qw422016.N().S(`Tag(e) {
-                continue
-            }
-`)
+		qw422016.N().S(`(`)
+//line generator/queries.qtpl:81
+		qw422016.E().S(data.ParamNames())
+//line generator/queries.qtpl:81
+		qw422016.N().S(`, yield)
+    }
+}
+
+func (w *World) query`)
 //line generator/queries.qtpl:85
-		} else {
+		qw422016.E().S(data.Name.Singular.Pascal)
 //line generator/queries.qtpl:85
-			qw422016.N().S(`            if !w.Has`)
+		qw422016.N().S(`(`)
+//line generator/queries.qtpl:85
+		qw422016.E().S(data.Params())
+//line generator/queries.qtpl:85
+		qw422016.N().S(`, yield `)
+//line generator/queries.qtpl:85
+		qw422016.E().S(iterName)
+//line generator/queries.qtpl:85
+		qw422016.N().S(`) {
+`)
 //line generator/queries.qtpl:86
-			qw422016.E().S(e.Name.Singular.Pascal)
+	} else {
 //line generator/queries.qtpl:86
-			qw422016.N().S(`(e) {
-                continue
-            }
+		qw422016.N().S(`func(w *World) Query`)
+//line generator/queries.qtpl:87
+		qw422016.E().S(data.Name.Singular.Pascal)
+//line generator/queries.qtpl:87
+		qw422016.N().S(`(yield `)
+//line generator/queries.qtpl:87
+		qw422016.E().S(iterName)
+//line generator/queries.qtpl:87
+		qw422016.N().S(`) {
 `)
-//line generator/queries.qtpl:89
-		}
-//line generator/queries.qtpl:90
+//line generator/queries.qtpl:88
 	}
-//line generator/queries.qtpl:90
-	qw422016.N().S(`
-        if !yield(e) {
-            break
-        }
-    }
-}
+//line generator/queries.qtpl:88
+	qw422016.N().S(`    args := `)
+//line generator/queries.qtpl:89
+	qw422016.E().S(argsName)
+//line generator/queries.qtpl:89
+	qw422016.N().S(`{}
 
 `)
+//line generator/queries.qtpl:91
+	if data.IsOwningGroup {
+//line generator/queries.qtpl:92
+		if data.JoinsNeedOk() {
+//line generator/queries.qtpl:92
+			qw422016.N().S(`    var ok bool
+`)
+//line generator/queries.qtpl:94
+		}
+//line generator/queries.qtpl:94
+		qw422016.N().S(`    // owned sets share the same dense order, members are the first GroupLen slots
+    for i := 0; i < w.`)
+//line generator/queries.qtpl:96
+		qw422016.E().S(groupLen)
+//line generator/queries.qtpl:96
+		qw422016.N().S(`; i++ {
+        e := w.`)
+//line generator/queries.qtpl:97
+		qw422016.E().S(sparseSetName(first.ComponentOrTag))
+//line generator/queries.qtpl:97
+		qw422016.N().S(`.dense[i]
+`)
 //line generator/queries.qtpl:98
-}
-
-//line generator/queries.qtpl:98
-func writequeryTemplate(qq422016 qtio422016.Writer, data *queryTmplData) {
-//line generator/queries.qtpl:98
-	qw422016 := qt422016.AcquireWriter(qq422016)
-//line generator/queries.qtpl:98
-	streamqueryTemplate(qw422016, data)
-//line generator/queries.qtpl:98
-	qt422016.ReleaseWriter(qw422016)
+		if data.HasChangeFilters() {
 //line generator/queries.qtpl:98
-}
+			qw422016.N().S(`        `)
+//line generator/queries.qtpl:99
+			streamqueryChangeFilters(qw422016, data)
+//line generator/queries.qtpl:99
+			qw422016.N().S(`
+`)
+//line generator/queries.qtpl:100
+		}
+//line generator/queries.qtpl:101
+		for _, entry := range data.Required {
+//line generator/queries.qtpl:102
+			if !entry.ComponentOrTag.IsTag && !entry.IsMutable {
+//line generator/queries.qtpl:102
+				qw422016.N().S(`        args.`)
+//line generator/queries.qtpl:103
+				qw422016.E().S(entry.Name.Singular.Pascal)
+//line generator/queries.qtpl:103
+				qw422016.N().S(` = w.`)
+//line generator/queries.qtpl:103
+				qw422016.E().S(sparseSetName(entry.ComponentOrTag))
+//line generator/queries.qtpl:103
+				qw422016.N().S(`.data[i]
+`)
+//line generator/queries.qtpl:104
+			}
+//line generator/queries.qtpl:105
+		}
+//line generator/queries.qtpl:106
+	} else if driver != nil {
+//line generator/queries.qtpl:108
+		pairName := driver.Name.Singular.Pascal + "RelationshipPair"
 
-//line generator/queries.qtpl:98
-func queryTemplate(data *queryTmplData) string {
-//line generator/queries.qtpl:98
-	qb422016 := qt422016.AcquireByteBuffer()
-//line generator/queries.qtpl:98
-	writequeryTemplate(qb422016, data)
-//line generator/queries.qtpl:98
-	qs422016 := string(qb422016.B)
-//line generator/queries.qtpl:98
-	qt422016.ReleaseByteBuffer(qb422016)
-//line generator/queries.qtpl:98
-	return qs422016
-//line generator/queries.qtpl:98
+//line generator/queries.qtpl:109
+		qw422016.N().S(`    // only sources linked to the target can match, collected first so the
+    // loop can change the relationship
+    var pairs []`)
+//line generator/queries.qtpl:112
+		qw422016.E().S(pairName)
+//line generator/queries.qtpl:112
+		qw422016.N().S(`
+    w.`)
+//line generator/queries.qtpl:113
+		qw422016.E().S(driver.Name.Singular.Camel)
+//line generator/queries.qtpl:113
+		qw422016.N().S(`Relationships.pairsTo(`)
+//line generator/queries.qtpl:113
+		qw422016.N().S(driver.TargetExpr())
+//line generator/queries.qtpl:113
+		qw422016.N().S(`, func(pair `)
+//line generator/queries.qtpl:113
+		qw422016.E().S(pairName)
+//line generator/queries.qtpl:113
+		qw422016.N().S(`) bool {
+        pairs = append(pairs, pair)
+        return true
+    })
+
+`)
+//line generator/queries.qtpl:118
+		if data.JoinsNeedOk() || data.RequiredNeedOk() {
+//line generator/queries.qtpl:118
+			qw422016.N().S(`    var ok bool
+`)
+//line generator/queries.qtpl:120
+		}
+//line generator/queries.qtpl:120
+		qw422016.N().S(`    for _, pair := range pairs {
+        e := pair.From
+        args.`)
+//line generator/queries.qtpl:123
+		qw422016.E().S(driver.Name.Singular.Pascal)
+//line generator/queries.qtpl:123
+		qw422016.N().S(` = pair
+`)
+//line generator/queries.qtpl:124
+		if data.HasChangeFilters() {
+//line generator/queries.qtpl:124
+			qw422016.N().S(`        `)
+//line generator/queries.qtpl:125
+			streamqueryChangeFilters(qw422016, data)
+//line generator/queries.qtpl:125
+			qw422016.N().S(`
+`)
+//line generator/queries.qtpl:126
+		}
+//line generator/queries.qtpl:126
+		qw422016.N().S(`        `)
+//line generator/queries.qtpl:127
+		streamqueryRequired(qw422016, data)
+//line generator/queries.qtpl:127
+		qw422016.N().S(`
+`)
+//line generator/queries.qtpl:128
+	} else {
+//line generator/queries.qtpl:129
+		if len(rest) == 0 && !data.HasChangeFilters() {
+//line generator/queries.qtpl:130
+			if data.JoinsNeedOk() {
+//line generator/queries.qtpl:130
+				qw422016.N().S(`    var ok bool
+`)
+//line generator/queries.qtpl:132
+			}
+//line generator/queries.qtpl:133
+			if first.ComponentOrTag.IsTag || first.IsMutable {
+//line generator/queries.qtpl:133
+				qw422016.N().S(`    for e := range w.`)
+//line generator/queries.qtpl:134
+				qw422016.E().S(firstIterName)
+//line generator/queries.qtpl:134
+				qw422016.N().S(`Entities {
+`)
+//line generator/queries.qtpl:135
+			} else {
+//line generator/queries.qtpl:135
+				qw422016.N().S(`    for e, first := range w.`)
+//line generator/queries.qtpl:136
+				qw422016.E().S(firstIterName)
+//line generator/queries.qtpl:136
+				qw422016.N().S(` {
+        args.`)
+//line generator/queries.qtpl:137
+				qw422016.E().S(first.Name.Singular.Pascal)
+//line generator/queries.qtpl:137
+				qw422016.N().S(` = first
+`)
+//line generator/queries.qtpl:138
+			}
+//line generator/queries.qtpl:139
+		} else {
+//line generator/queries.qtpl:139
+			qw422016.N().S(`    `)
+//line generator/queries.qtpl:140
+			streamqueryDriver(qw422016, data)
+//line generator/queries.qtpl:140
+			qw422016.N().S(`
+
+`)
+//line generator/queries.qtpl:142
+			if data.JoinsNeedOk() || data.RequiredNeedOk() {
+//line generator/queries.qtpl:142
+				qw422016.N().S(`    var ok bool
+`)
+//line generator/queries.qtpl:144
+			}
+//line generator/queries.qtpl:144
+			qw422016.N().S(`    for _, e := range driver {
+`)
+//line generator/queries.qtpl:146
+			if data.HasChangeFilters() {
+//line generator/queries.qtpl:146
+				qw422016.N().S(`        `)
+//line generator/queries.qtpl:147
+				streamqueryChangeFilters(qw422016, data)
+//line generator/queries.qtpl:147
+				qw422016.N().S(`
+`)
+//line generator/queries.qtpl:148
+			}
+//line generator/queries.qtpl:148
+			qw422016.N().S(`        `)
+//line generator/queries.qtpl:149
+			streamqueryRequired(qw422016, data)
+//line generator/queries.qtpl:149
+			qw422016.N().S(`
+`)
+//line generator/queries.qtpl:150
+		}
+//line generator/queries.qtpl:151
+	}
+//line generator/queries.qtpl:152
+	if len(data.Joins) > 0 {
+//line generator/queries.qtpl:152
+		qw422016.N().S(`        `)
+//line generator/queries.qtpl:153
+		streamqueryJoins(qw422016, data)
+//line generator/queries.qtpl:153
+		qw422016.N().S(`
+`)
+//line generator/queries.qtpl:154
+	}
+//line generator/queries.qtpl:155
+	if data.HasTermChecks() {
+//line generator/queries.qtpl:155
+		qw422016.N().S(`        `)
+//line generator/queries.qtpl:156
+		streamqueryTermChecks(qw422016, data, true)
+//line generator/queries.qtpl:156
+		qw422016.N().S(`
+`)
+//line generator/queries.qtpl:157
+	}
+//line generator/queries.qtpl:158
+	if data.HasMutable() {
+//line generator/queries.qtpl:158
+		qw422016.N().S(`        `)
+//line generator/queries.qtpl:159
+		streamqueryMutable(qw422016, data)
+//line generator/queries.qtpl:159
+		qw422016.N().S(`
+`)
+//line generator/queries.qtpl:160
+	}
+//line generator/queries.qtpl:160
+	qw422016.N().S(`
+`)
+//line generator/queries.qtpl:162
+	if data.HasWildcards() {
+//line generator/queries.qtpl:162
+		qw422016.N().S(`        if !yield(e, args) {
+            return
+        }
+`)
+//line generator/queries.qtpl:166
+		for _, entry := range data.Joins {
+//line generator/queries.qtpl:167
+			if entry.IsWildcard {
+//line generator/queries.qtpl:167
+				qw422016.N().S(`        }
+`)
+//line generator/queries.qtpl:169
+			}
+//line generator/queries.qtpl:170
+		}
+//line generator/queries.qtpl:171
+	} else {
+//line generator/queries.qtpl:171
+		qw422016.N().S(`        if !yield(e, args) {
+            break
+        }
+`)
+//line generator/queries.qtpl:175
+	}
+//line generator/queries.qtpl:175
+	qw422016.N().S(`    }
+}
+
+`)
+//line generator/queries.qtpl:179
+	if hasParams {
+//line generator/queries.qtpl:179
+		qw422016.N().S(`func (w *World) Query`)
+//line generator/queries.qtpl:180
+		qw422016.E().S(data.Name.Singular.Pascal)
+//line generator/queries.qtpl:180
+		qw422016.N().S(`Entities(`)
+//line generator/queries.qtpl:180
+		qw422016.E().S(data.Params())
+//line generator/queries.qtpl:180
+		qw422016.N().S(`) func(yield func(e Entity) bool) {
+    return func(yield func(e Entity) bool) {
+        w.query`)
+//line generator/queries.qtpl:182
+		qw422016.E().S(data.Name.Singular.Pascal)
+//line generator/queries.qtpl:182
+		qw422016.N().S(`Entities(`)
+//line generator/queries.qtpl:182
+		qw422016.E().S(data.ParamNames())
+//line generator/queries.qtpl:182
+		qw422016.N().S(`, yield)
+    }
+}
+
+func (w *World) query`)
+//line generator/queries.qtpl:186
+		qw422016.E().S(data.Name.Singular.Pascal)
+//line generator/queries.qtpl:186
+		qw422016.N().S(`Entities(`)
+//line generator/queries.qtpl:186
+		qw422016.E().S(data.Params())
+//line generator/queries.qtpl:186
+		qw422016.N().S(`, yield func(e Entity) bool) {
+`)
+//line generator/queries.qtpl:187
+	} else {
+//line generator/queries.qtpl:187
+		qw422016.N().S(`func(w *World) Query`)
+//line generator/queries.qtpl:188
+		qw422016.E().S(data.Name.Singular.Pascal)
+//line generator/queries.qtpl:188
+		qw422016.N().S(`Entities(yield func(e Entity) bool) {
+`)
+//line generator/queries.qtpl:189
+	}
+//line generator/queries.qtpl:190
+	if data.HasJoins() {
+//line generator/queries.qtpl:190
+		qw422016.N().S(`    // joined terms need the matched args, and wildcard pairs can match the
+    // same entity several times in a row
+    var last Entity
+    hasLast := false
+`)
+//line generator/queries.qtpl:195
+		if hasParams {
+//line generator/queries.qtpl:195
+			qw422016.N().S(`    w.query`)
+//line generator/queries.qtpl:196
+			qw422016.E().S(data.Name.Singular.Pascal)
+//line generator/queries.qtpl:196
+			qw422016.N().S(`(`)
+//line generator/queries.qtpl:196
+			qw422016.E().S(data.ParamNames())
+//line generator/queries.qtpl:196
+			qw422016.N().S(`, func(e Entity, _ `)
+//line generator/queries.qtpl:196
+			qw422016.E().S(argsName)
+//line generator/queries.qtpl:196
+			qw422016.N().S(`) bool {
+`)
+//line generator/queries.qtpl:197
+		} else {
+//line generator/queries.qtpl:197
+			qw422016.N().S(`    w.Query`)
+//line generator/queries.qtpl:198
+			qw422016.E().S(data.Name.Singular.Pascal)
+//line generator/queries.qtpl:198
+			qw422016.N().S(`(func(e Entity, _ `)
+//line generator/queries.qtpl:198
+			qw422016.E().S(argsName)
+//line generator/queries.qtpl:198
+			qw422016.N().S(`) bool {
+`)
+//line generator/queries.qtpl:199
+		}
+//line generator/queries.qtpl:199
+		qw422016.N().S(`        if hasLast && e == last {
+            return true
+        }
+        last, hasLast = e, true
+        return yield(e)
+    })
+}
+`)
+//line generator/queries.qtpl:207
+	} else if data.IsOwningGroup {
+//line generator/queries.qtpl:207
+		qw422016.N().S(`    for i := 0; i < w.`)
+//line generator/queries.qtpl:208
+		qw422016.E().S(groupLen)
+//line generator/queries.qtpl:208
+		qw422016.N().S(`; i++ {
+`)
+//line generator/queries.qtpl:209
+		if data.HasTermChecks() || data.HasChangeFilters() {
+//line generator/queries.qtpl:209
+			qw422016.N().S(`        e := w.`)
+//line generator/queries.qtpl:210
+			qw422016.E().S(sparseSetName(first.ComponentOrTag))
+//line generator/queries.qtpl:210
+			qw422016.N().S(`.dense[i]
+`)
+//line generator/queries.qtpl:211
+			if data.HasChangeFilters() {
+//line generator/queries.qtpl:211
+				qw422016.N().S(`        `)
+//line generator/queries.qtpl:212
+				streamqueryChangeFilters(qw422016, data)
+//line generator/queries.qtpl:212
+				qw422016.N().S(`
+`)
+//line generator/queries.qtpl:213
+			}
+//line generator/queries.qtpl:213
+			qw422016.N().S(`        `)
+//line generator/queries.qtpl:214
+			streamqueryTermChecks(qw422016, data, false)
+//line generator/queries.qtpl:214
+			qw422016.N().S(`
+        if !yield(e) {
+`)
+//line generator/queries.qtpl:216
+		} else {
+//line generator/queries.qtpl:216
+			qw422016.N().S(`        if !yield(w.`)
+//line generator/queries.qtpl:217
+			qw422016.E().S(sparseSetName(first.ComponentOrTag))
+//line generator/queries.qtpl:217
+			qw422016.N().S(`.dense[i]) {
+`)
+//line generator/queries.qtpl:218
+		}
+//line generator/queries.qtpl:218
+		qw422016.N().S(`            break
+        }
+    }
+}
+`)
+//line generator/queries.qtpl:223
+	} else {
+//line generator/queries.qtpl:224
+		if len(rest) == 0 && !data.HasChangeFilters() {
+//line generator/queries.qtpl:224
+			qw422016.N().S(`    for e := range w.`)
+//line generator/queries.qtpl:225
+			qw422016.E().S(firstIterName)
+//line generator/queries.qtpl:225
+			qw422016.N().S(`Entities {
+`)
+//line generator/queries.qtpl:226
+		} else {
+//line generator/queries.qtpl:226
+			qw422016.N().S(`    `)
+//line generator/queries.qtpl:227
+			streamqueryDriver(qw422016, data)
+//line generator/queries.qtpl:227
+			qw422016.N().S(`
+    for _, e := range driver {
+`)
+//line generator/queries.qtpl:229
+			if data.HasChangeFilters() {
+//line generator/queries.qtpl:229
+				qw422016.N().S(`        `)
+//line generator/queries.qtpl:230
+				streamqueryChangeFilters(qw422016, data)
+//line generator/queries.qtpl:230
+				qw422016.N().S(`
+`)
+//line generator/queries.qtpl:231
+			}
+//line generator/queries.qtpl:232
+			for _, e := range data.Required {
+//line generator/queries.qtpl:233
+				if e.ComponentOrTag.IsTag {
+//line generator/queries.qtpl:233
+					qw422016.N().S(`            if !w.Has`)
+//line generator/queries.qtpl:234
+					qw422016.E().S(e.Name.Singular.Pascal)
+//line generator/queries.qtpl:234
+					qw422016.N().S(`Tag(e) {
+                continue
+            }
+`)
+//line generator/queries.qtpl:237
+				} else {
+//line generator/queries.qtpl:237
+					qw422016.N().S(`            if !w.Has`)
+//line generator/queries.qtpl:238
+					qw422016.E().S(e.Name.Singular.Pascal)
+//line generator/queries.qtpl:238
+					qw422016.N().S(`(e) {
+                continue
+            }
+`)
+//line generator/queries.qtpl:241
+				}
+//line generator/queries.qtpl:242
+			}
+//line generator/queries.qtpl:243
+		}
+//line generator/queries.qtpl:244
+		if data.HasTermChecks() {
+//line generator/queries.qtpl:244
+			qw422016.N().S(`        `)
+//line generator/queries.qtpl:245
+			streamqueryTermChecks(qw422016, data, false)
+//line generator/queries.qtpl:245
+			qw422016.N().S(`
+`)
+//line generator/queries.qtpl:246
+		}
+//line generator/queries.qtpl:246
+		qw422016.N().S(`
+        if !yield(e) {
+            break
+        }
+    }
+}
+`)
+//line generator/queries.qtpl:253
+	}
+//line generator/queries.qtpl:253
+	qw422016.N().S(`
+`)
+//line generator/queries.qtpl:255
+	if data.IsOwningGroup {
+//line generator/queries.qtpl:255
+		qw422016.N().S(`// `)
+//line generator/queries.qtpl:256
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:256
+		qw422016.N().S(`GroupAdd moves e into the group once it has every owned set
+func (w *World) `)
+//line generator/queries.qtpl:257
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:257
+		qw422016.N().S(`GroupAdd(e Entity) {
+`)
+//line generator/queries.qtpl:258
+		for _, entry := range data.Required {
+//line generator/queries.qtpl:258
+			qw422016.N().S(`    if !w.`)
+//line generator/queries.qtpl:259
+			qw422016.E().S(sparseSetName(entry.ComponentOrTag))
+//line generator/queries.qtpl:259
+			qw422016.N().S(`.Contains(e) {
+        return
+    }
+`)
+//line generator/queries.qtpl:262
+		}
+//line generator/queries.qtpl:262
+		qw422016.N().S(`
+    if w.`)
+//line generator/queries.qtpl:264
+		qw422016.E().S(sparseSetName(first.ComponentOrTag))
+//line generator/queries.qtpl:264
+		qw422016.N().S(`.search(e) < w.`)
+//line generator/queries.qtpl:264
+		qw422016.E().S(groupLen)
+//line generator/queries.qtpl:264
+		qw422016.N().S(` {
+        // already a member
+        return
+    }
+
+`)
+//line generator/queries.qtpl:269
+		for _, entry := range data.Required {
+//line generator/queries.qtpl:269
+			qw422016.N().S(`    w.`)
+//line generator/queries.qtpl:270
+			qw422016.E().S(sparseSetName(entry.ComponentOrTag))
+//line generator/queries.qtpl:270
+			qw422016.N().S(`.swap(w.`)
+//line generator/queries.qtpl:270
+			qw422016.E().S(sparseSetName(entry.ComponentOrTag))
+//line generator/queries.qtpl:270
+			qw422016.N().S(`.search(e), w.`)
+//line generator/queries.qtpl:270
+			qw422016.E().S(groupLen)
+//line generator/queries.qtpl:270
+			qw422016.N().S(`)
+`)
+//line generator/queries.qtpl:271
+		}
+//line generator/queries.qtpl:271
+		qw422016.N().S(`    w.`)
+//line generator/queries.qtpl:272
+		qw422016.E().S(groupLen)
+//line generator/queries.qtpl:272
+		qw422016.N().S(`++
+}
+
+// `)
+//line generator/queries.qtpl:275
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:275
+		qw422016.N().S(`GroupRemove moves e out of the group, it must be called
+// before any owned set removes e
+func (w *World) `)
+//line generator/queries.qtpl:277
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:277
+		qw422016.N().S(`GroupRemove(e Entity) {
+    idx := w.`)
+//line generator/queries.qtpl:278
+		qw422016.E().S(sparseSetName(first.ComponentOrTag))
+//line generator/queries.qtpl:278
+		qw422016.N().S(`.search(e)
+    if idx == -1 || idx >= w.`)
+//line generator/queries.qtpl:279
+		qw422016.E().S(groupLen)
+//line generator/queries.qtpl:279
+		qw422016.N().S(` {
+        return
+    }
+
+    last := w.`)
+//line generator/queries.qtpl:283
+		qw422016.E().S(groupLen)
+//line generator/queries.qtpl:283
+		qw422016.N().S(` - 1
+`)
+//line generator/queries.qtpl:284
+		for _, entry := range data.Required {
+//line generator/queries.qtpl:284
+			qw422016.N().S(`    w.`)
+//line generator/queries.qtpl:285
+			qw422016.E().S(sparseSetName(entry.ComponentOrTag))
+//line generator/queries.qtpl:285
+			qw422016.N().S(`.swap(w.`)
+//line generator/queries.qtpl:285
+			qw422016.E().S(sparseSetName(entry.ComponentOrTag))
+//line generator/queries.qtpl:285
+			qw422016.N().S(`.search(e), last)
+`)
+//line generator/queries.qtpl:286
+		}
+//line generator/queries.qtpl:286
+		qw422016.N().S(`    w.`)
+//line generator/queries.qtpl:287
+		qw422016.E().S(groupLen)
+//line generator/queries.qtpl:287
+		qw422016.N().S(`--
+}
+`)
+//line generator/queries.qtpl:289
+	}
+//line generator/queries.qtpl:289
+	qw422016.N().S(`
+`)
+//line generator/queries.qtpl:291
+	if data.IsObservable() {
+//line generator/queries.qtpl:293
+		eventName := "Query" + data.Name.Singular.Pascal
+
+//line generator/queries.qtpl:294
+		qw422016.N().S(`// Membership events
+type `)
+//line generator/queries.qtpl:296
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:296
+		qw422016.N().S(`EnterEvent struct {
+    Entity Entity
+}
+
+type `)
+//line generator/queries.qtpl:300
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:300
+		qw422016.N().S(`ExitEvent struct {
+    Entity Entity
+}
+
+// On`)
+//line generator/queries.qtpl:304
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:304
+		qw422016.N().S(`Enter fires once an entity starts matching the query
+// after one of its components or tags was set or removed
+func (w *World) On`)
+//line generator/queries.qtpl:306
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:306
+		qw422016.N().S(`Enter(fn func(evt `)
+//line generator/queries.qtpl:306
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:306
+		qw422016.N().S(`EnterEvent)) UnsubscribeFunc {
+    return w.observe`)
+//line generator/queries.qtpl:307
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:307
+		qw422016.N().S(`(mint.On(w.eventBus, fn))
+}
+
+// On`)
+//line generator/queries.qtpl:310
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:310
+		qw422016.N().S(`Exit fires once an entity stops matching the query,
+// including when it is destroyed
+func (w *World) On`)
+//line generator/queries.qtpl:312
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:312
+		qw422016.N().S(`Exit(fn func(evt `)
+//line generator/queries.qtpl:312
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:312
+		qw422016.N().S(`ExitEvent)) UnsubscribeFunc {
+    return w.observe`)
+//line generator/queries.qtpl:313
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:313
+		qw422016.N().S(`(mint.On(w.eventBus, fn))
+}
+
+// observe`)
+//line generator/queries.qtpl:316
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:316
+		qw422016.N().S(` counts subscribers so writes only check membership
+// while someone is listening
+func (w *World) observe`)
+//line generator/queries.qtpl:318
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:318
+		qw422016.N().S(`(unsub func() <-chan struct{}) UnsubscribeFunc {
+    w.`)
+//line generator/queries.qtpl:319
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:319
+		qw422016.N().S(`Observers++
+    var once sync.Once
+    return func() {
+        once.Do(func() {
+            unsub()
+            w.`)
+//line generator/queries.qtpl:324
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:324
+		qw422016.N().S(`Observers--
+        })
+    }
+}
+
+func (w *World) `)
+//line generator/queries.qtpl:329
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:329
+		qw422016.N().S(`Matches(e Entity) bool {
+    return `)
+//line generator/queries.qtpl:330
+		qw422016.N().S(data.MatchCall())
+//line generator/queries.qtpl:330
+		qw422016.N().S(`
+}
+
+// `)
+//line generator/queries.qtpl:333
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:333
+		qw422016.N().S(`Observed is whether e matched before a write, it is always
+// false without observers
+func (w *World) `)
+//line generator/queries.qtpl:335
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:335
+		qw422016.N().S(`Observed(e Entity) bool {
+    return w.`)
+//line generator/queries.qtpl:336
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:336
+		qw422016.N().S(`Observers > 0 && w.`)
+//line generator/queries.qtpl:336
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:336
+		qw422016.N().S(`Matches(e)
+}
+
+// `)
+//line generator/queries.qtpl:339
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:339
+		qw422016.N().S(`Notify fires enter or exit when a write changed whether e
+// matches
+func (w *World) `)
+//line generator/queries.qtpl:341
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:341
+		qw422016.N().S(`Notify(e Entity, was bool) {
+    if w.`)
+//line generator/queries.qtpl:342
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:342
+		qw422016.N().S(`Observers == 0 {
+        return
+    }
+    switch is := w.`)
+//line generator/queries.qtpl:345
+		qw422016.E().S(groupName)
+//line generator/queries.qtpl:345
+		qw422016.N().S(`Matches(e); {
+    case is && !was:
+        fireEvent(w, `)
+//line generator/queries.qtpl:347
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:347
+		qw422016.N().S(`EnterEvent{Entity: e})
+    case was && !is:
+        fireEvent(w, `)
+//line generator/queries.qtpl:349
+		qw422016.E().S(eventName)
+//line generator/queries.qtpl:349
+		qw422016.N().S(`ExitEvent{Entity: e})
+    }
+}
+`)
+//line generator/queries.qtpl:352
+	}
+//line generator/queries.qtpl:352
+	qw422016.N().S(`
+`)
+//line generator/queries.qtpl:354
+}
+
+//line generator/queries.qtpl:354
+func writequeryTemplate(qq422016 qtio422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:354
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/queries.qtpl:354
+	streamqueryTemplate(qw422016, data)
+//line generator/queries.qtpl:354
+	qt422016.ReleaseWriter(qw422016)
+//line generator/queries.qtpl:354
+}
+
+//line generator/queries.qtpl:354
+func queryTemplate(data *queryTmplData) string {
+//line generator/queries.qtpl:354
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/queries.qtpl:354
+	writequeryTemplate(qb422016, data)
+//line generator/queries.qtpl:354
+	qs422016 := string(qb422016.B)
+//line generator/queries.qtpl:354
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/queries.qtpl:354
+	return qs422016
+//line generator/queries.qtpl:354
+}
+
+//line generator/queries.qtpl:356
+func streamqueryObservedBefore(qw422016 *qt422016.Writer, c *componentTmplData, e string) {
+//line generator/queries.qtpl:356
+	for _, q := range c.ObservedBy {
+//line generator/queries.qtpl:356
+		qw422016.N().S(`    was`)
+//line generator/queries.qtpl:357
+		qw422016.E().S(q.Name.Singular.Pascal)
+//line generator/queries.qtpl:357
+		qw422016.N().S(` := w.`)
+//line generator/queries.qtpl:357
+		qw422016.E().S(q.Name.Singular.Camel)
+//line generator/queries.qtpl:357
+		qw422016.N().S(`Observed(`)
+//line generator/queries.qtpl:357
+		qw422016.E().S(e)
+//line generator/queries.qtpl:357
+		qw422016.N().S(`)
+`)
+//line generator/queries.qtpl:358
+	}
+//line generator/queries.qtpl:358
+}
+
+//line generator/queries.qtpl:358
+func writequeryObservedBefore(qq422016 qtio422016.Writer, c *componentTmplData, e string) {
+//line generator/queries.qtpl:358
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/queries.qtpl:358
+	streamqueryObservedBefore(qw422016, c, e)
+//line generator/queries.qtpl:358
+	qt422016.ReleaseWriter(qw422016)
+//line generator/queries.qtpl:358
+}
+
+//line generator/queries.qtpl:358
+func queryObservedBefore(c *componentTmplData, e string) string {
+//line generator/queries.qtpl:358
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/queries.qtpl:358
+	writequeryObservedBefore(qb422016, c, e)
+//line generator/queries.qtpl:358
+	qs422016 := string(qb422016.B)
+//line generator/queries.qtpl:358
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/queries.qtpl:358
+	return qs422016
+//line generator/queries.qtpl:358
+}
+
+//line generator/queries.qtpl:360
+func streamqueryObservedAfter(qw422016 *qt422016.Writer, c *componentTmplData, e string) {
+//line generator/queries.qtpl:360
+	for _, q := range c.ObservedBy {
+//line generator/queries.qtpl:360
+		qw422016.N().S(`    w.`)
+//line generator/queries.qtpl:361
+		qw422016.E().S(q.Name.Singular.Camel)
+//line generator/queries.qtpl:361
+		qw422016.N().S(`Notify(`)
+//line generator/queries.qtpl:361
+		qw422016.E().S(e)
+//line generator/queries.qtpl:361
+		qw422016.N().S(`, was`)
+//line generator/queries.qtpl:361
+		qw422016.E().S(q.Name.Singular.Pascal)
+//line generator/queries.qtpl:361
+		qw422016.N().S(`)
+`)
+//line generator/queries.qtpl:362
+	}
+//line generator/queries.qtpl:362
+}
+
+//line generator/queries.qtpl:362
+func writequeryObservedAfter(qq422016 qtio422016.Writer, c *componentTmplData, e string) {
+//line generator/queries.qtpl:362
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/queries.qtpl:362
+	streamqueryObservedAfter(qw422016, c, e)
+//line generator/queries.qtpl:362
+	qt422016.ReleaseWriter(qw422016)
+//line generator/queries.qtpl:362
+}
+
+//line generator/queries.qtpl:362
+func queryObservedAfter(c *componentTmplData, e string) string {
+//line generator/queries.qtpl:362
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/queries.qtpl:362
+	writequeryObservedAfter(qb422016, c, e)
+//line generator/queries.qtpl:362
+	qs422016 := string(qb422016.B)
+//line generator/queries.qtpl:362
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/queries.qtpl:362
+	return qs422016
+//line generator/queries.qtpl:362
+}
+
+//line generator/queries.qtpl:364
+func streamqueryRequired(qw422016 *qt422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:364
+	for _, e := range data.Required {
+//line generator/queries.qtpl:365
+		if e.ComponentOrTag.IsTag {
+//line generator/queries.qtpl:365
+			qw422016.N().S(`            if !w.Has`)
+//line generator/queries.qtpl:366
+			qw422016.E().S(e.Name.Singular.Pascal)
+//line generator/queries.qtpl:366
+			qw422016.N().S(`Tag(e) {
+                continue
+            }
+`)
+//line generator/queries.qtpl:369
+		} else {
+//line generator/queries.qtpl:370
+			if e.IsMutable {
+//line generator/queries.qtpl:370
+				qw422016.N().S(`            if !w.`)
+//line generator/queries.qtpl:371
+				qw422016.E().S(sparseSetName(e.ComponentOrTag))
+//line generator/queries.qtpl:371
+				qw422016.N().S(`.Contains(e) {
+                continue
+            }
+`)
+//line generator/queries.qtpl:374
+			} else {
+//line generator/queries.qtpl:374
+				qw422016.N().S(`            args.`)
+//line generator/queries.qtpl:375
+				qw422016.E().S(e.Name.Singular.Pascal)
+//line generator/queries.qtpl:375
+				qw422016.N().S(`, ok = w.`)
+//line generator/queries.qtpl:375
+				qw422016.E().S(sparseSetName(e.ComponentOrTag))
+//line generator/queries.qtpl:375
+				qw422016.N().S(`.Data(e)
+            if !ok {
+                continue
+            }
+`)
+//line generator/queries.qtpl:379
+			}
+//line generator/queries.qtpl:380
+		}
+//line generator/queries.qtpl:381
+	}
+//line generator/queries.qtpl:381
+}
+
+//line generator/queries.qtpl:381
+func writequeryRequired(qq422016 qtio422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:381
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/queries.qtpl:381
+	streamqueryRequired(qw422016, data)
+//line generator/queries.qtpl:381
+	qt422016.ReleaseWriter(qw422016)
+//line generator/queries.qtpl:381
+}
+
+//line generator/queries.qtpl:381
+func queryRequired(data *queryTmplData) string {
+//line generator/queries.qtpl:381
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/queries.qtpl:381
+	writequeryRequired(qb422016, data)
+//line generator/queries.qtpl:381
+	qs422016 := string(qb422016.B)
+//line generator/queries.qtpl:381
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/queries.qtpl:381
+	return qs422016
+//line generator/queries.qtpl:381
+}
+
+//line generator/queries.qtpl:383
+func streamqueryChangeFilters(qw422016 *qt422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:383
+	for _, entry := range data.Required {
+//line generator/queries.qtpl:384
+		if entry.IsChanged || entry.IsAdded {
+//line generator/queries.qtpl:384
+			qw422016.N().S(`        if !`)
+//line generator/queries.qtpl:385
+			qw422016.N().S(changeFilterCall(entry))
+//line generator/queries.qtpl:385
+			qw422016.N().S(` {
+            continue
+        }
+`)
+//line generator/queries.qtpl:388
+		}
+//line generator/queries.qtpl:389
+	}
+//line generator/queries.qtpl:389
+}
+
+//line generator/queries.qtpl:389
+func writequeryChangeFilters(qq422016 qtio422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:389
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/queries.qtpl:389
+	streamqueryChangeFilters(qw422016, data)
+//line generator/queries.qtpl:389
+	qt422016.ReleaseWriter(qw422016)
+//line generator/queries.qtpl:389
+}
+
+//line generator/queries.qtpl:389
+func queryChangeFilters(data *queryTmplData) string {
+//line generator/queries.qtpl:389
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/queries.qtpl:389
+	writequeryChangeFilters(qb422016, data)
+//line generator/queries.qtpl:389
+	qs422016 := string(qb422016.B)
+//line generator/queries.qtpl:389
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/queries.qtpl:389
+	return qs422016
+//line generator/queries.qtpl:389
+}
+
+//line generator/queries.qtpl:391
+func streamqueryTermChecks(qw422016 *qt422016.Writer, data *queryTmplData, fillArgs bool) {
+//line generator/queries.qtpl:391
+	qw422016.N().S(`
+`)
+//line generator/queries.qtpl:392
+	for _, entry := range data.Inherited {
+//line generator/queries.qtpl:393
+		if fillArgs {
+//line generator/queries.qtpl:393
+			qw422016.N().S(`        args.`)
+//line generator/queries.qtpl:394
+			qw422016.E().S(entry.Name.Singular.Pascal)
+//line generator/queries.qtpl:394
+			qw422016.N().S(`, ok = w.`)
+//line generator/queries.qtpl:394
+			qw422016.E().S(entry.Name.Singular.Pascal)
+//line generator/queries.qtpl:394
+			qw422016.N().S(`(e)
+        if !ok {
+            continue
+        }
+`)
+//line generator/queries.qtpl:398
+		} else {
+//line generator/queries.qtpl:398
+			qw422016.N().S(`        if _, ok := w.`)
+//line generator/queries.qtpl:399
+			qw422016.E().S(entry.Name.Singular.Pascal)
+//line generator/queries.qtpl:399
+			qw422016.N().S(`(e); !ok {
+            continue
+        }
+`)
+//line generator/queries.qtpl:402
+		}
+//line generator/queries.qtpl:403
+	}
+//line generator/queries.qtpl:404
+	for _, entry := range data.Entries {
+//line generator/queries.qtpl:405
+		if entry.IsWithout {
+//line generator/queries.qtpl:405
+			qw422016.N().S(`        if `)
+//line generator/queries.qtpl:406
+			qw422016.N().S(hasTermCall(entry))
+//line generator/queries.qtpl:406
+			qw422016.N().S(` {
+            continue
+        }
+`)
+//line generator/queries.qtpl:409
+		}
+//line generator/queries.qtpl:410
+	}
+//line generator/queries.qtpl:411
+	for _, group := range data.OrGroups {
+//line generator/queries.qtpl:411
+		qw422016.N().S(`        if `)
+//line generator/queries.qtpl:412
+		qw422016.N().S(orGroupMissing(group))
+//line generator/queries.qtpl:412
+		qw422016.N().S(` {
+            continue
+        }
+`)
+//line generator/queries.qtpl:415
+	}
+//line generator/queries.qtpl:416
+	if fillArgs {
+//line generator/queries.qtpl:417
+		for _, entry := range data.Entries {
+//line generator/queries.qtpl:418
+			if entry.IsOptional || entry.IsOr {
+//line generator/queries.qtpl:419
+				if entry.ComponentOrTag.IsTag {
+//line generator/queries.qtpl:419
+					qw422016.N().S(`        args.Has`)
+//line generator/queries.qtpl:420
+					qw422016.E().S(entry.Name.Singular.Pascal)
+//line generator/queries.qtpl:420
+					qw422016.N().S(` = `)
+//line generator/queries.qtpl:420
+					qw422016.N().S(hasTermCall(entry))
+//line generator/queries.qtpl:420
+					qw422016.N().S(`
+`)
+//line generator/queries.qtpl:421
+				} else if entry.IsMutable {
+//line generator/queries.qtpl:422
+				} else {
+//line generator/queries.qtpl:422
+					qw422016.N().S(`        args.`)
+//line generator/queries.qtpl:423
+					qw422016.E().S(entry.Name.Singular.Camel)
+//line generator/queries.qtpl:423
+					qw422016.N().S(`, args.Has`)
+//line generator/queries.qtpl:423
+					qw422016.E().S(entry.Name.Singular.Pascal)
+//line generator/queries.qtpl:423
+					qw422016.N().S(` = w.`)
+//line generator/queries.qtpl:423
+					qw422016.E().S(sparseSetName(entry.ComponentOrTag))
+//line generator/queries.qtpl:423
+					qw422016.N().S(`.Data(e)
+        args.`)
+//line generator/queries.qtpl:424
+					qw422016.E().S(entry.Name.Singular.Pascal)
+//line generator/queries.qtpl:424
+					qw422016.N().S(` = nil
+        if args.Has`)
+//line generator/queries.qtpl:425
+					qw422016.E().S(entry.Name.Singular.Pascal)
+//line generator/queries.qtpl:425
+					qw422016.N().S(` {
+            args.`)
+//line generator/queries.qtpl:426
+					qw422016.E().S(entry.Name.Singular.Pascal)
+//line generator/queries.qtpl:426
+					qw422016.N().S(` = &args.`)
+//line generator/queries.qtpl:426
+					qw422016.E().S(entry.Name.Singular.Camel)
+//line generator/queries.qtpl:426
+					qw422016.N().S(`
+        }
+`)
+//line generator/queries.qtpl:428
+				}
+//line generator/queries.qtpl:429
+			}
+//line generator/queries.qtpl:430
+		}
+//line generator/queries.qtpl:431
+	}
+//line generator/queries.qtpl:432
+}
+
+//line generator/queries.qtpl:432
+func writequeryTermChecks(qq422016 qtio422016.Writer, data *queryTmplData, fillArgs bool) {
+//line generator/queries.qtpl:432
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/queries.qtpl:432
+	streamqueryTermChecks(qw422016, data, fillArgs)
+//line generator/queries.qtpl:432
+	qt422016.ReleaseWriter(qw422016)
+//line generator/queries.qtpl:432
+}
+
+//line generator/queries.qtpl:432
+func queryTermChecks(data *queryTmplData, fillArgs bool) string {
+//line generator/queries.qtpl:432
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/queries.qtpl:432
+	writequeryTermChecks(qb422016, data, fillArgs)
+//line generator/queries.qtpl:432
+	qs422016 := string(qb422016.B)
+//line generator/queries.qtpl:432
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/queries.qtpl:432
+	return qs422016
+//line generator/queries.qtpl:432
+}
+
+//line generator/queries.qtpl:434
+func streamqueryDriver(qw422016 *qt422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:434
+	first := data.Required[0]
+
+//line generator/queries.qtpl:434
+	qw422016.N().S(`    // drive from the smallest required set and probe the rest, sizes are only
+    // known at call time
+    driver := w.`)
+//line generator/queries.qtpl:437
+	qw422016.E().S(sparseSetName(first.ComponentOrTag))
+//line generator/queries.qtpl:437
+	qw422016.N().S(`.dense
+`)
+//line generator/queries.qtpl:438
+	for _, entry := range data.Required[1:] {
+//line generator/queries.qtpl:438
+		qw422016.N().S(`    if dense := w.`)
+//line generator/queries.qtpl:439
+		qw422016.E().S(sparseSetName(entry.ComponentOrTag))
+//line generator/queries.qtpl:439
+		qw422016.N().S(`.dense; len(dense) < len(driver) {
+        driver = dense
+    }
+`)
+//line generator/queries.qtpl:442
+	}
+//line generator/queries.qtpl:443
+}
+
+//line generator/queries.qtpl:443
+func writequeryDriver(qq422016 qtio422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:443
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/queries.qtpl:443
+	streamqueryDriver(qw422016, data)
+//line generator/queries.qtpl:443
+	qt422016.ReleaseWriter(qw422016)
+//line generator/queries.qtpl:443
+}
+
+//line generator/queries.qtpl:443
+func queryDriver(data *queryTmplData) string {
+//line generator/queries.qtpl:443
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/queries.qtpl:443
+	writequeryDriver(qb422016, data)
+//line generator/queries.qtpl:443
+	qs422016 := string(qb422016.B)
+//line generator/queries.qtpl:443
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/queries.qtpl:443
+	return qs422016
+//line generator/queries.qtpl:443
+}
+
+//line generator/queries.qtpl:445
+func streamqueryJoins(qw422016 *qt422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:445
+	qw422016.N().S(`
+`)
+//line generator/queries.qtpl:446
+	driver := data.TargetDriver()
+
+//line generator/queries.qtpl:447
+	for _, entry := range data.Joins {
+//line generator/queries.qtpl:449
+		name := entry.Name.Singular.Pascal
+		src := entry.SourceExpr()
+
+//line generator/queries.qtpl:452
+		if entry == driver {
+//line generator/queries.qtpl:453
+		} else if entry.ComponentOrTag.IsRelationship && entry.IsWildcard {
+//line generator/queries.qtpl:453
+			qw422016.N().S(`        // collected first so the loop can change the relationship, like
+        // queries driven by a target
+        for _, pair := range slices.Collect(w.`)
+//line generator/queries.qtpl:456
+			qw422016.E().S(name)
+//line generator/queries.qtpl:456
+			qw422016.N().S(`PairsFrom(`)
+//line generator/queries.qtpl:456
+			qw422016.N().S(src)
+//line generator/queries.qtpl:456
+			qw422016.N().S(`)) {
+            args.`)
+//line generator/queries.qtpl:457
+			qw422016.E().S(name)
+//line generator/queries.qtpl:457
+			qw422016.N().S(` = pair
+`)
+//line generator/queries.qtpl:458
+		} else if entry.ComponentOrTag.IsRelationship {
+//line generator/queries.qtpl:458
+			qw422016.N().S(`        args.`)
+//line generator/queries.qtpl:459
+			qw422016.E().S(name)
+//line generator/queries.qtpl:459
+			qw422016.N().S(`, ok = w.`)
+//line generator/queries.qtpl:459
+			qw422016.E().S(name)
+//line generator/queries.qtpl:459
+			qw422016.N().S(`Pair(`)
+//line generator/queries.qtpl:459
+			qw422016.N().S(src)
+//line generator/queries.qtpl:459
+			qw422016.N().S(`, `)
+//line generator/queries.qtpl:459
+			qw422016.N().S(entry.TargetExpr())
+//line generator/queries.qtpl:459
+			qw422016.N().S(`)
+        if !ok {
+            continue
+        }
+`)
+//line generator/queries.qtpl:463
+		} else if entry.ComponentOrTag.IsTag {
+//line generator/queries.qtpl:463
+			qw422016.N().S(`        if !w.Has`)
+//line generator/queries.qtpl:464
+			qw422016.E().S(name)
+//line generator/queries.qtpl:464
+			qw422016.N().S(`Tag(`)
+//line generator/queries.qtpl:464
+			qw422016.N().S(src)
+//line generator/queries.qtpl:464
+			qw422016.N().S(`) {
+            continue
+        }
+`)
+//line generator/queries.qtpl:467
+		} else {
+//line generator/queries.qtpl:468
+			if entry.IsMutable {
+//line generator/queries.qtpl:468
+				qw422016.N().S(`        if !w.`)
+//line generator/queries.qtpl:469
+				qw422016.E().S(sparseSetName(entry.ComponentOrTag))
+//line generator/queries.qtpl:469
+				qw422016.N().S(`.Contains(`)
+//line generator/queries.qtpl:469
+				qw422016.N().S(src)
+//line generator/queries.qtpl:469
+				qw422016.N().S(`) {
+            continue
+        }
+`)
+//line generator/queries.qtpl:472
+			} else {
+//line generator/queries.qtpl:472
+				qw422016.N().S(`        args.`)
+//line generator/queries.qtpl:473
+				qw422016.E().S(name)
+//line generator/queries.qtpl:473
+				qw422016.N().S(`, ok = w.`)
+//line generator/queries.qtpl:473
+				qw422016.E().S(sparseSetName(entry.ComponentOrTag))
+//line generator/queries.qtpl:473
+				qw422016.N().S(`.Data(`)
+//line generator/queries.qtpl:473
+				qw422016.N().S(src)
+//line generator/queries.qtpl:473
+				qw422016.N().S(`)
+        if !ok {
+            continue
+        }
+`)
+//line generator/queries.qtpl:477
+			}
+//line generator/queries.qtpl:478
+		}
+//line generator/queries.qtpl:479
+	}
+//line generator/queries.qtpl:480
+}
+
+//line generator/queries.qtpl:480
+func writequeryJoins(qq422016 qtio422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:480
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/queries.qtpl:480
+	streamqueryJoins(qw422016, data)
+//line generator/queries.qtpl:480
+	qt422016.ReleaseWriter(qw422016)
+//line generator/queries.qtpl:480
+}
+
+//line generator/queries.qtpl:480
+func queryJoins(data *queryTmplData) string {
+//line generator/queries.qtpl:480
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/queries.qtpl:480
+	writequeryJoins(qb422016, data)
+//line generator/queries.qtpl:480
+	qs422016 := string(qb422016.B)
+//line generator/queries.qtpl:480
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/queries.qtpl:480
+	return qs422016
+//line generator/queries.qtpl:480
+}
+
+//line generator/queries.qtpl:482
+func streamqueryMutable(qw422016 *qt422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:482
+	qw422016.N().S(`
+        // mutable terms are only taken once everything matched, so entities
+        // the query skips are not stamped as changed
+`)
+//line generator/queries.qtpl:485
+	for _, entry := range data.Entries {
+//line generator/queries.qtpl:486
+		if entry.IsMutable {
+//line generator/queries.qtpl:488
+			name := entry.Name.Singular.Pascal
+			set := sparseSetName(entry.ComponentOrTag)
+
+//line generator/queries.qtpl:491
+			if entry.IsOptional || entry.IsOr {
+//line generator/queries.qtpl:491
+				qw422016.N().S(`        args.`)
+//line generator/queries.qtpl:492
+				qw422016.E().S(name)
+//line generator/queries.qtpl:492
+				qw422016.N().S(`, args.Has`)
+//line generator/queries.qtpl:492
+				qw422016.E().S(name)
+//line generator/queries.qtpl:492
+				qw422016.N().S(` = w.`)
+//line generator/queries.qtpl:492
+				qw422016.E().S(set)
+//line generator/queries.qtpl:492
+				qw422016.N().S(`.DataMutable(e)
+`)
+//line generator/queries.qtpl:493
+			} else if entry.Source != nil {
+//line generator/queries.qtpl:493
+				qw422016.N().S(`        args.`)
+//line generator/queries.qtpl:494
+				qw422016.E().S(name)
+//line generator/queries.qtpl:494
+				qw422016.N().S(`, _ = w.`)
+//line generator/queries.qtpl:494
+				qw422016.E().S(set)
+//line generator/queries.qtpl:494
+				qw422016.N().S(`.DataMutable(`)
+//line generator/queries.qtpl:494
+				qw422016.N().S(entry.SourceExpr())
+//line generator/queries.qtpl:494
+				qw422016.N().S(`)
+`)
+//line generator/queries.qtpl:495
+			} else if data.IsOwningGroup {
+//line generator/queries.qtpl:495
+				qw422016.N().S(`        args.`)
+//line generator/queries.qtpl:496
+				qw422016.E().S(name)
+//line generator/queries.qtpl:496
+				qw422016.N().S(` = w.`)
+//line generator/queries.qtpl:496
+				qw422016.E().S(set)
+//line generator/queries.qtpl:496
+				qw422016.N().S(`.mutableAt(i)
+`)
+//line generator/queries.qtpl:497
+			} else {
+//line generator/queries.qtpl:497
+				qw422016.N().S(`        args.`)
+//line generator/queries.qtpl:498
+				qw422016.E().S(name)
+//line generator/queries.qtpl:498
+				qw422016.N().S(`, _ = w.`)
+//line generator/queries.qtpl:498
+				qw422016.E().S(set)
+//line generator/queries.qtpl:498
+				qw422016.N().S(`.DataMutable(e)
+`)
+//line generator/queries.qtpl:499
+			}
+//line generator/queries.qtpl:500
+		}
+//line generator/queries.qtpl:501
+	}
+//line generator/queries.qtpl:502
+}
+
+//line generator/queries.qtpl:502
+func writequeryMutable(qq422016 qtio422016.Writer, data *queryTmplData) {
+//line generator/queries.qtpl:502
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/queries.qtpl:502
+	streamqueryMutable(qw422016, data)
+//line generator/queries.qtpl:502
+	qt422016.ReleaseWriter(qw422016)
+//line generator/queries.qtpl:502
+}
+
+//line generator/queries.qtpl:502
+func queryMutable(data *queryTmplData) string {
+//line generator/queries.qtpl:502
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/queries.qtpl:502
+	writequeryMutable(qb422016, data)
+//line generator/queries.qtpl:502
+	qs422016 := string(qb422016.B)
+//line generator/queries.qtpl:502
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/queries.qtpl:502
+	return qs422016
+//line generator/queries.qtpl:502
 }
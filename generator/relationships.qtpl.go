@@ -30,298 +30,795 @@ package `)
 //line generator/relationships.qtpl:4
 	qw422016.N().S(`
 import (
+    "slices"
+
     "github.com/tidwall/btree"
 `)
-//line generator/relationships.qtpl:8
+//line generator/relationships.qtpl:10
 	if data.HasAnyEvents {
-//line generator/relationships.qtpl:8
-		qw422016.N().S(`
-    import "github.com/btvoidx/mint"
-`)
 //line generator/relationships.qtpl:10
+		qw422016.N().S(`    "github.com/btvoidx/mint"
+`)
+//line generator/relationships.qtpl:12
 	}
-//line generator/relationships.qtpl:10
-	qw422016.N().S(`
-)
+//line generator/relationships.qtpl:12
+	qw422016.N().S(`)
 
 `)
-//line generator/relationships.qtpl:14
+//line generator/relationships.qtpl:16
 	nsp := data.Name.Singular.Pascal
 	nsc := data.Name.Singular.Camel
 	pairName := data.Name.Singular.Pascal + "RelationshipPair"
 
-//line generator/relationships.qtpl:17
+//line generator/relationships.qtpl:19
 	qw422016.N().S(`
 type `)
-//line generator/relationships.qtpl:19
+//line generator/relationships.qtpl:21
 	qw422016.E().S(pairName)
-//line generator/relationships.qtpl:19
+//line generator/relationships.qtpl:21
 	qw422016.N().S(` struct {
     From, To Entity
 `)
-//line generator/relationships.qtpl:21
+//line generator/relationships.qtpl:23
 	for _, f := range data.Fields {
-//line generator/relationships.qtpl:21
+//line generator/relationships.qtpl:24
+		if f.IsDeprecated {
+//line generator/relationships.qtpl:24
+			qw422016.N().S(`    // Deprecated: kept so older snapshots can still be read, it is not saved
+    // and the FromValues helpers leave it at its reset value
+`)
+//line generator/relationships.qtpl:27
+		}
+//line generator/relationships.qtpl:27
 		qw422016.N().S(`    `)
-//line generator/relationships.qtpl:22
+//line generator/relationships.qtpl:28
 		qw422016.E().S(f.Name.Singular.Pascal)
-//line generator/relationships.qtpl:22
+//line generator/relationships.qtpl:28
 		qw422016.N().S(` `)
-//line generator/relationships.qtpl:22
+//line generator/relationships.qtpl:28
 		qw422016.E().S(f.Type.Singular.Original)
-//line generator/relationships.qtpl:22
+//line generator/relationships.qtpl:28
 		qw422016.N().S(`
 `)
-//line generator/relationships.qtpl:23
+//line generator/relationships.qtpl:29
 	}
-//line generator/relationships.qtpl:23
+//line generator/relationships.qtpl:29
 	qw422016.N().S(`}
 
 type `)
-//line generator/relationships.qtpl:26
+//line generator/relationships.qtpl:32
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:26
+//line generator/relationships.qtpl:32
 	qw422016.N().S(`Relationship struct {
-    btree *btree.BTreeG[`)
-//line generator/relationships.qtpl:27
+    // byTo is keyed by (To, From) and byFrom by (From, To) so both ends of a
+    // pair can be found without scanning every pair
+    byTo, byFrom *btree.BTreeG[`)
+//line generator/relationships.qtpl:35
 	qw422016.E().S(pairName)
-//line generator/relationships.qtpl:27
+//line generator/relationships.qtpl:35
 	qw422016.N().S(`]
+    // version changes on every write so Diff can skip untouched relationships
+    version uint64
 }
 
 func New`)
-//line generator/relationships.qtpl:30
+//line generator/relationships.qtpl:40
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:30
+//line generator/relationships.qtpl:40
 	qw422016.N().S(`Relationship() *`)
-//line generator/relationships.qtpl:30
+//line generator/relationships.qtpl:40
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:30
+//line generator/relationships.qtpl:40
 	qw422016.N().S(`Relationship {
+    // compare full handles so stale generations never match a living pair
+    opts := btree.Options{NoLocks: true}
     return &`)
-//line generator/relationships.qtpl:31
+//line generator/relationships.qtpl:43
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:31
+//line generator/relationships.qtpl:43
 	qw422016.N().S(`Relationship{
-        btree: btree.NewBTreeG(func(a, b `)
-//line generator/relationships.qtpl:32
+        byTo: btree.NewBTreeGOptions(func(a, b `)
+//line generator/relationships.qtpl:44
 	qw422016.E().S(pairName)
-//line generator/relationships.qtpl:32
+//line generator/relationships.qtpl:44
 	qw422016.N().S(`) bool {
-            ati, bti := a.To.Index(), b.To.Index()
-            if ati == bti {
-                return a.From.Index() < b.From.Index()
+            if a.To == b.To {
+                return a.From < b.From
             }
-            return ati < bti
-        }),
+            return a.To < b.To
+        }, opts),
+        byFrom: btree.NewBTreeGOptions(func(a, b `)
+//line generator/relationships.qtpl:50
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:50
+	qw422016.N().S(`) bool {
+            if a.From == b.From {
+                return a.To < b.To
+            }
+            return a.From < b.From
+        }, opts),
     }
 }
 
 func (r *`)
-//line generator/relationships.qtpl:42
+//line generator/relationships.qtpl:59
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:42
+//line generator/relationships.qtpl:59
 	qw422016.N().S(`Relationship) Clear() {
-    r.btree.Clear()
+    r.version++
+    r.byTo.Clear()
+    r.byFrom.Clear()
+}
+
+func (r *`)
+//line generator/relationships.qtpl:65
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:65
+	qw422016.N().S(`Relationship) Len() int {
+    return r.byTo.Len()
+}
+
+func (r *`)
+//line generator/relationships.qtpl:69
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:69
+	qw422016.N().S(`Relationship) set(pair `)
+//line generator/relationships.qtpl:69
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:69
+	qw422016.N().S(`) (wasAdded bool) {
+    r.version++
+    _, replaced := r.byTo.Set(pair)
+    r.byFrom.Set(pair)
+    return !replaced
+}
+
+// delete returns the stored pair, fields included
+func (r *`)
+//line generator/relationships.qtpl:77
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:77
+	qw422016.N().S(`Relationship) delete(pair `)
+//line generator/relationships.qtpl:77
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:77
+	qw422016.N().S(`) (deleted `)
+//line generator/relationships.qtpl:77
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:77
+	qw422016.N().S(`, wasDeleted bool) {
+    deleted, wasDeleted = r.byTo.Delete(pair)
+    if wasDeleted {
+        r.version++
+        r.byFrom.Delete(pair)
+    }
+    return deleted, wasDeleted
+}
+
+// clone is a copy-on-write copy, cheap until either side is written to
+func (r *`)
+//line generator/relationships.qtpl:87
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:87
+	qw422016.N().S(`Relationship) clone() *`)
+//line generator/relationships.qtpl:87
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:87
+	qw422016.N().S(`Relationship {
+    return &`)
+//line generator/relationships.qtpl:88
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:88
+	qw422016.N().S(`Relationship{
+        byTo:    r.byTo.Copy(),
+        byFrom:  r.byFrom.Copy(),
+        version: r.version,
+    }
+}
+
+func (r *`)
+//line generator/relationships.qtpl:95
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:95
+	qw422016.N().S(`Relationship) get(from, to Entity) (`)
+//line generator/relationships.qtpl:95
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:95
+	qw422016.N().S(`, bool) {
+    return r.byTo.Get(`)
+//line generator/relationships.qtpl:96
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:96
+	qw422016.N().S(`{ From: from, To: to })
+}
+
+// pairsTo yields every pair targeting to
+func (r *`)
+//line generator/relationships.qtpl:100
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:100
+	qw422016.N().S(`Relationship) pairsTo(to Entity, yield func(pair `)
+//line generator/relationships.qtpl:100
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:100
+	qw422016.N().S(`) bool) {
+    r.byTo.Ascend(`)
+//line generator/relationships.qtpl:101
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:101
+	qw422016.N().S(`{ To: to }, func(item `)
+//line generator/relationships.qtpl:101
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:101
+	qw422016.N().S(`) bool {
+        return item.To == to && yield(item)
+    })
+}
+
+// pairsFrom yields every pair originating at from
+func (r *`)
+//line generator/relationships.qtpl:107
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:107
+	qw422016.N().S(`Relationship) pairsFrom(from Entity, yield func(pair `)
+//line generator/relationships.qtpl:107
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:107
+	qw422016.N().S(`) bool) {
+    r.byFrom.Ascend(`)
+//line generator/relationships.qtpl:108
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:108
+	qw422016.N().S(`{ From: from }, func(item `)
+//line generator/relationships.qtpl:108
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:108
+	qw422016.N().S(`) bool {
+        return item.From == from && yield(item)
+    })
+}
+
+// hasSourcesOutside is true when an entity not in batch is linked to to
+func (r *`)
+//line generator/relationships.qtpl:114
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:114
+	qw422016.N().S(`Relationship) hasSourcesOutside(to Entity, batch map[Entity]bool) (found bool) {
+    r.pairsTo(to, func(pair `)
+//line generator/relationships.qtpl:115
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:115
+	qw422016.N().S(`) bool {
+        found = !batch[pair.From]
+        return !found
+    })
+    return found
+}
+
+func (r *`)
+//line generator/relationships.qtpl:122
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:122
+	qw422016.N().S(`Relationship) hasPairsFrom(from Entity) (found bool) {
+    r.pairsFrom(from, func(pair `)
+//line generator/relationships.qtpl:123
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:123
+	qw422016.N().S(`) bool {
+        found = true
+        return false
+    })
+    return found
+}
+
+// removeEntity deletes every pair where e is either the From or the To and
+// returns them
+func (r *`)
+//line generator/relationships.qtpl:132
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:132
+	qw422016.N().S(`Relationship) removeEntity(e Entity) (removed []`)
+//line generator/relationships.qtpl:132
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:132
+	qw422016.N().S(`) {
+    collect := func(pair `)
+//line generator/relationships.qtpl:133
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:133
+	qw422016.N().S(`) bool {
+        removed = append(removed, pair)
+        return true
+    }
+    r.pairsTo(e, collect)
+    r.pairsFrom(e, collect)
+
+    // a pair linking e to itself is found from both ends
+    removed = slices.DeleteFunc(removed, func(pair `)
+//line generator/relationships.qtpl:141
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:141
+	qw422016.N().S(`) bool {
+        _, ok := r.delete(pair)
+        return !ok
+    })
+    return removed
 }
 
 func(w *World) Link`)
-//line generator/relationships.qtpl:46
+//line generator/relationships.qtpl:148
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:46
+//line generator/relationships.qtpl:148
 	qw422016.N().S(`(
     to, from Entity,
 `)
-//line generator/relationships.qtpl:48
-	for _, f := range data.Fields {
-//line generator/relationships.qtpl:48
+//line generator/relationships.qtpl:150
+	for _, f := range data.WritableFields() {
+//line generator/relationships.qtpl:150
 		qw422016.N().S(`    `)
-//line generator/relationships.qtpl:49
+//line generator/relationships.qtpl:151
 		qw422016.E().S(f.Name.Singular.Camel)
-//line generator/relationships.qtpl:49
+//line generator/relationships.qtpl:151
 		qw422016.N().S(`Arg `)
-//line generator/relationships.qtpl:49
+//line generator/relationships.qtpl:151
 		qw422016.E().S(f.Type.Singular.Original)
-//line generator/relationships.qtpl:49
+//line generator/relationships.qtpl:151
 		qw422016.N().S(`,
 `)
-//line generator/relationships.qtpl:50
+//line generator/relationships.qtpl:152
 	}
-//line generator/relationships.qtpl:50
+//line generator/relationships.qtpl:152
 	qw422016.N().S(`) {
+    if !w.IsAlive(from) || !w.IsAlive(to) {
+        return
+    }
+
     pair := `)
-//line generator/relationships.qtpl:52
+//line generator/relationships.qtpl:158
 	qw422016.E().S(pairName)
-//line generator/relationships.qtpl:52
+//line generator/relationships.qtpl:158
 	qw422016.N().S(`{
         From: from, To: to,
 `)
-//line generator/relationships.qtpl:54
+//line generator/relationships.qtpl:160
 	for _, f := range data.Fields {
-//line generator/relationships.qtpl:54
+//line generator/relationships.qtpl:160
 		qw422016.N().S(`        `)
-//line generator/relationships.qtpl:55
+//line generator/relationships.qtpl:161
 		qw422016.E().S(f.Name.Singular.Pascal)
-//line generator/relationships.qtpl:55
+//line generator/relationships.qtpl:161
 		qw422016.N().S(`: `)
-//line generator/relationships.qtpl:55
-		qw422016.E().S(f.Name.Singular.Camel)
-//line generator/relationships.qtpl:55
-		qw422016.N().S(`Arg,
+//line generator/relationships.qtpl:161
+		qw422016.N().S(f.FromValuesArg())
+//line generator/relationships.qtpl:161
+		qw422016.N().S(`,
 `)
-//line generator/relationships.qtpl:56
+//line generator/relationships.qtpl:162
 	}
-//line generator/relationships.qtpl:56
+//line generator/relationships.qtpl:162
 	qw422016.N().S(`    }
-    w.`)
-//line generator/relationships.qtpl:58
+    wasAdded := w.`)
+//line generator/relationships.qtpl:164
 	qw422016.E().S(nsc)
-//line generator/relationships.qtpl:58
-	qw422016.N().S(`Relationships.btree.Set(pair)
-}
+//line generator/relationships.qtpl:164
+	qw422016.N().S(`Relationships.set(pair)
+
+    // depending on the generation flags, this might be unused
+    _ = wasAdded
+
+`)
+//line generator/relationships.qtpl:169
+	if data.ShouldGenAdded {
+//line generator/relationships.qtpl:169
+		qw422016.N().S(`    if wasAdded {
+        fireEvent(w, `)
+//line generator/relationships.qtpl:171
+		qw422016.E().S(nsp)
+//line generator/relationships.qtpl:171
+		qw422016.N().S(`LinkedEvent{Pair: pair})
+    }
+`)
+//line generator/relationships.qtpl:173
+	}
+//line generator/relationships.qtpl:173
+	qw422016.N().S(`}
 
 func(w *World) Unlink`)
-//line generator/relationships.qtpl:61
+//line generator/relationships.qtpl:176
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:61
+//line generator/relationships.qtpl:176
 	qw422016.N().S(`(from, to Entity) {
-    pair := `)
-//line generator/relationships.qtpl:62
+    w.unlink`)
+//line generator/relationships.qtpl:177
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:177
+	qw422016.N().S(`(`)
+//line generator/relationships.qtpl:177
 	qw422016.E().S(pairName)
-//line generator/relationships.qtpl:62
-	qw422016.N().S(`{ From: from, To: to }
-    w.`)
-//line generator/relationships.qtpl:63
+//line generator/relationships.qtpl:177
+	qw422016.N().S(`{ From: from, To: to })
+}
+
+func (w *World) unlink`)
+//line generator/relationships.qtpl:180
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:180
+	qw422016.N().S(`(pair `)
+//line generator/relationships.qtpl:180
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:180
+	qw422016.N().S(`) {
+    pair, wasDeleted := w.`)
+//line generator/relationships.qtpl:181
 	qw422016.E().S(nsc)
-//line generator/relationships.qtpl:63
-	qw422016.N().S(`Relationships.btree.Delete(pair)
+//line generator/relationships.qtpl:181
+	qw422016.N().S(`Relationships.delete(pair)
+
+    // depending on the generation flags, these might be unused
+    _, _ = pair, wasDeleted
+
+`)
+//line generator/relationships.qtpl:186
+	if data.ShouldGenRemoved {
+//line generator/relationships.qtpl:186
+		qw422016.N().S(`    if wasDeleted {
+        fireEvent(w, `)
+//line generator/relationships.qtpl:188
+		qw422016.E().S(nsp)
+//line generator/relationships.qtpl:188
+		qw422016.N().S(`UnlinkedEvent{Pair: pair})
+    }
+`)
+//line generator/relationships.qtpl:190
+	}
+//line generator/relationships.qtpl:190
+	qw422016.N().S(`}
+
+func (cb *CommandBuffer) Link`)
+//line generator/relationships.qtpl:193
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:193
+	qw422016.N().S(`(
+    to, from Entity,
+`)
+//line generator/relationships.qtpl:195
+	for _, f := range data.WritableFields() {
+//line generator/relationships.qtpl:195
+		qw422016.N().S(`    `)
+//line generator/relationships.qtpl:196
+		qw422016.E().S(f.Name.Singular.Camel)
+//line generator/relationships.qtpl:196
+		qw422016.N().S(`Arg `)
+//line generator/relationships.qtpl:196
+		qw422016.E().S(f.Type.Singular.Original)
+//line generator/relationships.qtpl:196
+		qw422016.N().S(`,
+`)
+//line generator/relationships.qtpl:197
+	}
+//line generator/relationships.qtpl:197
+	qw422016.N().S(`) {
+    cb.record(func(w *World) {
+        w.Link`)
+//line generator/relationships.qtpl:200
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:200
+	qw422016.N().S(`(
+            to, from,
+`)
+//line generator/relationships.qtpl:202
+	for _, f := range data.WritableFields() {
+//line generator/relationships.qtpl:202
+		qw422016.N().S(`            `)
+//line generator/relationships.qtpl:203
+		qw422016.E().S(f.Name.Singular.Camel)
+//line generator/relationships.qtpl:203
+		qw422016.N().S(`Arg,
+`)
+//line generator/relationships.qtpl:204
+	}
+//line generator/relationships.qtpl:204
+	qw422016.N().S(`        )
+    })
+}
+
+func (cb *CommandBuffer) Unlink`)
+//line generator/relationships.qtpl:209
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:209
+	qw422016.N().S(`(from, to Entity) {
+    cb.record(func(w *World) {
+        w.Unlink`)
+//line generator/relationships.qtpl:211
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:211
+	qw422016.N().S(`(from, to)
+    })
 }
 
 func (w *World) `)
-//line generator/relationships.qtpl:66
+//line generator/relationships.qtpl:215
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:66
+//line generator/relationships.qtpl:215
 	qw422016.N().S(`IsLinked(from, to Entity) bool {
-    pair := `)
-//line generator/relationships.qtpl:67
-	qw422016.E().S(pairName)
-//line generator/relationships.qtpl:67
-	qw422016.N().S(`{ From: from, To: to }
     _, ok := w.`)
-//line generator/relationships.qtpl:68
+//line generator/relationships.qtpl:216
 	qw422016.E().S(nsc)
-//line generator/relationships.qtpl:68
-	qw422016.N().S(`Relationships.btree.Get(pair)
+//line generator/relationships.qtpl:216
+	qw422016.N().S(`Relationships.get(from, to)
     return ok
 }
 
 func (w *World) `)
-//line generator/relationships.qtpl:72
+//line generator/relationships.qtpl:220
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:220
+	qw422016.N().S(`Pair(from, to Entity) (`)
+//line generator/relationships.qtpl:220
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:220
+	qw422016.N().S(`, bool) {
+    return w.`)
+//line generator/relationships.qtpl:221
+	qw422016.E().S(nsc)
+//line generator/relationships.qtpl:221
+	qw422016.N().S(`Relationships.get(from, to)
+}
+
+// `)
+//line generator/relationships.qtpl:224
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:224
+	qw422016.N().S(` yields every entity linked to the given target
+func (w *World) `)
+//line generator/relationships.qtpl:225
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:72
+//line generator/relationships.qtpl:225
 	qw422016.N().S(`(to Entity) func(yield func(from Entity) bool) {
     return func(yield func(from Entity) bool) {
-        iter := w.`)
-//line generator/relationships.qtpl:74
+        w.`)
+//line generator/relationships.qtpl:227
 	qw422016.E().S(nsc)
-//line generator/relationships.qtpl:74
-	qw422016.N().S(`Relationships.btree.Iter()
-        iter.Seek(`)
-//line generator/relationships.qtpl:75
-	qw422016.E().S(pairName)
-//line generator/relationships.qtpl:75
-	qw422016.N().S(`{ To: to })
-        end := `)
-//line generator/relationships.qtpl:76
-	qw422016.E().S(pairName)
-//line generator/relationships.qtpl:76
-	qw422016.N().S(`{ To: to + 1 }
-        for iter.Next() {
-            item := iter.Item()
-            if item.To >= end.To {
-                break
-            }
+//line generator/relationships.qtpl:227
+	qw422016.N().S(`Relationships.pairsTo(to, func(pair `)
+//line generator/relationships.qtpl:227
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:227
+	qw422016.N().S(`) bool {
+            return yield(pair.From)
+        })
+    }
+}
 
-            if !yield(item.From) {
-                break
-            }
-        }
+// `)
+//line generator/relationships.qtpl:233
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:233
+	qw422016.N().S(`Targets yields every target the given entity is linked to
+func (w *World) `)
+//line generator/relationships.qtpl:234
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:234
+	qw422016.N().S(`Targets(from Entity) func(yield func(to Entity) bool) {
+    return func(yield func(to Entity) bool) {
+        w.`)
+//line generator/relationships.qtpl:236
+	qw422016.E().S(nsc)
+//line generator/relationships.qtpl:236
+	qw422016.N().S(`Relationships.pairsFrom(from, func(pair `)
+//line generator/relationships.qtpl:236
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:236
+	qw422016.N().S(`) bool {
+            return yield(pair.To)
+        })
     }
 }
 
+// `)
+//line generator/relationships.qtpl:242
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:242
+	qw422016.N().S(`PairsFrom yields every pair originating at the given entity
+func (w *World) `)
+//line generator/relationships.qtpl:243
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:243
+	qw422016.N().S(`PairsFrom(from Entity) func(yield func(pair `)
+//line generator/relationships.qtpl:243
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:243
+	qw422016.N().S(`) bool) {
+    return func(yield func(pair `)
+//line generator/relationships.qtpl:244
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:244
+	qw422016.N().S(`) bool) {
+        w.`)
+//line generator/relationships.qtpl:245
+	qw422016.E().S(nsc)
+//line generator/relationships.qtpl:245
+	qw422016.N().S(`Relationships.pairsFrom(from, yield)
+    }
+}
+
+func (w *World) All`)
+//line generator/relationships.qtpl:249
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:249
+	qw422016.N().S(`Pairs(yield func(pair `)
+//line generator/relationships.qtpl:249
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:249
+	qw422016.N().S(`) bool) {
+    w.`)
+//line generator/relationships.qtpl:250
+	qw422016.E().S(nsc)
+//line generator/relationships.qtpl:250
+	qw422016.N().S(`Relationships.byTo.Scan(yield)
+}
+
+// Remove`)
+//line generator/relationships.qtpl:253
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:253
+	qw422016.N().S(`Relationships unlinks each of the given sources from the target
 func (w *World) Remove`)
-//line generator/relationships.qtpl:90
+//line generator/relationships.qtpl:254
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:90
+//line generator/relationships.qtpl:254
 	qw422016.N().S(`Relationships(to Entity, froms ... Entity) {
     for _, from := range froms {
-        pair := `)
-//line generator/relationships.qtpl:92
+        w.unlink`)
+//line generator/relationships.qtpl:256
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:256
+	qw422016.N().S(`(`)
+//line generator/relationships.qtpl:256
 	qw422016.E().S(pairName)
-//line generator/relationships.qtpl:92
-	qw422016.N().S(`{ From: from, To: to }
-        w.`)
-//line generator/relationships.qtpl:93
-	qw422016.E().S(nsc)
-//line generator/relationships.qtpl:93
-	qw422016.N().S(`Relationships.btree.Delete(pair)
+//line generator/relationships.qtpl:256
+	qw422016.N().S(`{ From: from, To: to })
     }
 }
 
 func (w *World) RemoveAll`)
-//line generator/relationships.qtpl:97
+//line generator/relationships.qtpl:260
 	qw422016.E().S(nsp)
-//line generator/relationships.qtpl:97
+//line generator/relationships.qtpl:260
 	qw422016.N().S(`Relationships(to Entity) {
-    iter := w.`)
-//line generator/relationships.qtpl:98
-	qw422016.E().S(nsc)
-//line generator/relationships.qtpl:98
-	qw422016.N().S(`Relationships.btree.Iter()
-    end := `)
-//line generator/relationships.qtpl:99
-	qw422016.E().S(pairName)
-//line generator/relationships.qtpl:99
-	qw422016.N().S(`{ To: to + 1 }
-    for iter.Next() {
-        item := iter.Item()
-        if item.To >= end.To {
-            break
-        }
-        w.`)
-//line generator/relationships.qtpl:105
+    var pairs []`)
+//line generator/relationships.qtpl:261
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:261
+	qw422016.N().S(`
+    w.`)
+//line generator/relationships.qtpl:262
 	qw422016.E().S(nsc)
-//line generator/relationships.qtpl:105
-	qw422016.N().S(`Relationships.btree.Delete(item)
+//line generator/relationships.qtpl:262
+	qw422016.N().S(`Relationships.pairsTo(to, func(pair `)
+//line generator/relationships.qtpl:262
+	qw422016.E().S(pairName)
+//line generator/relationships.qtpl:262
+	qw422016.N().S(`) bool {
+        pairs = append(pairs, pair)
+        return true
+    })
+    for _, pair := range pairs {
+        w.unlink`)
+//line generator/relationships.qtpl:267
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:267
+	qw422016.N().S(`(pair)
     }
 }
 
+func (w *World) `)
+//line generator/relationships.qtpl:271
+	qw422016.E().S(nsp)
+//line generator/relationships.qtpl:271
+	qw422016.N().S(`PairCount() int {
+    return w.`)
+//line generator/relationships.qtpl:272
+	qw422016.E().S(nsc)
+//line generator/relationships.qtpl:272
+	qw422016.N().S(`Relationships.Len()
+}
+
+// Events
+`)
+//line generator/relationships.qtpl:276
+	if data.ShouldGenAdded {
+//line generator/relationships.qtpl:276
+		qw422016.N().S(`type `)
+//line generator/relationships.qtpl:277
+		qw422016.E().S(nsp)
+//line generator/relationships.qtpl:277
+		qw422016.N().S(`LinkedEvent struct {
+    Pair `)
+//line generator/relationships.qtpl:278
+		qw422016.E().S(pairName)
+//line generator/relationships.qtpl:278
+		qw422016.N().S(`
+}
+func (w *World) On`)
+//line generator/relationships.qtpl:280
+		qw422016.E().S(nsp)
+//line generator/relationships.qtpl:280
+		qw422016.N().S(`Linked(fn func(evt `)
+//line generator/relationships.qtpl:280
+		qw422016.E().S(nsp)
+//line generator/relationships.qtpl:280
+		qw422016.N().S(`LinkedEvent)) UnsubscribeFunc {
+    unsub := mint.On(w.eventBus, fn)
+    return func() {
+        unsub()
+    }
+}
+`)
+//line generator/relationships.qtpl:286
+	}
+//line generator/relationships.qtpl:286
+	qw422016.N().S(`
+`)
+//line generator/relationships.qtpl:288
+	if data.ShouldGenRemoved {
+//line generator/relationships.qtpl:288
+		qw422016.N().S(`type `)
+//line generator/relationships.qtpl:289
+		qw422016.E().S(nsp)
+//line generator/relationships.qtpl:289
+		qw422016.N().S(`UnlinkedEvent struct {
+    Pair `)
+//line generator/relationships.qtpl:290
+		qw422016.E().S(pairName)
+//line generator/relationships.qtpl:290
+		qw422016.N().S(`
+}
+func (w *World) On`)
+//line generator/relationships.qtpl:292
+		qw422016.E().S(nsp)
+//line generator/relationships.qtpl:292
+		qw422016.N().S(`Unlinked(fn func(evt `)
+//line generator/relationships.qtpl:292
+		qw422016.E().S(nsp)
+//line generator/relationships.qtpl:292
+		qw422016.N().S(`UnlinkedEvent)) UnsubscribeFunc {
+    unsub := mint.On(w.eventBus, fn)
+    return func() {
+        unsub()
+    }
+}
+`)
+//line generator/relationships.qtpl:298
+	}
+//line generator/relationships.qtpl:298
+	qw422016.N().S(`
 `)
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 }
 
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 func writerelationshipTemplate(qq422016 qtio422016.Writer, data *componentTmplData) {
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 	streamrelationshipTemplate(qw422016, data)
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 	qt422016.ReleaseWriter(qw422016)
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 }
 
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 func relationshipTemplate(data *componentTmplData) string {
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 	qb422016 := qt422016.AcquireByteBuffer()
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 	writerelationshipTemplate(qb422016, data)
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 	qs422016 := string(qb422016.B)
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 	qt422016.ReleaseByteBuffer(qb422016)
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 	return qs422016
-//line generator/relationships.qtpl:109
+//line generator/relationships.qtpl:300
 }
@@ -0,0 +1,1179 @@
+// Code generated by qtc from "snapshot_go.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// package generator
+//
+
+//line generator/snapshot_go.qtpl:3
+package generator
+
+//line generator/snapshot_go.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line generator/snapshot_go.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line generator/snapshot_go.qtpl:3
+func streamsnapshotTemplate(qw422016 *qt422016.Writer, data *ecsTmplData) {
+//line generator/snapshot_go.qtpl:3
+	qw422016.N().S(`
+package `)
+//line generator/snapshot_go.qtpl:4
+	qw422016.E().S(data.PackageName)
+//line generator/snapshot_go.qtpl:4
+	qw422016.N().S(`
+
+import (
+    "bytes"
+    "encoding/binary"
+    "errors"
+    "fmt"
+    "io"
+    "math"
+    "slices"
+
+    "github.com/delaneyj/geck/components/mathx"
+)
+
+// SchemaVersion is GeneratorOptions.Version, snapshots carry it in their header
+const SchemaVersion = `)
+//line generator/snapshot_go.qtpl:19
+	qw422016.N().D(int(data.Version))
+//line generator/snapshot_go.qtpl:19
+	qw422016.N().S(`
+
+var (
+    snapshotMagic = []byte("GECK")
+    errSnapshotTruncated = errors.New("snapshot is truncated")
+)
+
+// snapshotFormat changes when the layout below changes, not the schema
+const snapshotFormat = 1
+
+// Snapshot layout, every number is a varint unless noted
+//
+//  magic "GECK", format, schema version
+//  next entity id, resource entity
+//  living entities, free entities
+//  sets, each with name, kind byte, fields (name, kind byte) and a length
+//  prefixed payload so sets that no longer exist can be skipped
+type snapshotSetKind byte
+
+const (
+    snapshotSetTag snapshotSetKind = iota + 1
+    snapshotSetComponent
+    snapshotSetRelationship
+)
+
+type snapshotKind byte
+
+const (
+    snapshotKindU8 snapshotKind = iota + 1
+    snapshotKindU16
+    snapshotKindU32
+    snapshotKindU64
+    snapshotKindI8
+    snapshotKindI16
+    snapshotKindI32
+    snapshotKindI64
+    snapshotKindF32
+    snapshotKindF64
+    snapshotKindTxt
+    snapshotKindBin
+    snapshotKindEntity
+    snapshotKindEnum
+    // snapshotKindStruct values are length prefixed, their fields are stored by
+    // position
+    snapshotKindStruct
+
+    // snapshotKindSlice is set on fields holding multiple values
+    snapshotKindSlice snapshotKind = 0x80
+)
+
+type snapshotField struct {
+    Name string
+    Kind snapshotKind
+    // Deprecated fields are read from older snapshots but never written
+    Deprecated bool
+}
+
+`)
+//line generator/snapshot_go.qtpl:76
+	for _, c := range data.Components {
+//line generator/snapshot_go.qtpl:77
+		if len(c.Fields) > 0 {
+//line generator/snapshot_go.qtpl:77
+			qw422016.N().S(`var `)
+//line generator/snapshot_go.qtpl:78
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/snapshot_go.qtpl:78
+			qw422016.N().S(`SnapshotFields = []snapshotField{
+`)
+//line generator/snapshot_go.qtpl:79
+			for _, f := range c.Fields {
+//line generator/snapshot_go.qtpl:79
+				qw422016.N().S(`    {Name: "`)
+//line generator/snapshot_go.qtpl:80
+				qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:80
+				qw422016.N().S(`", Kind: snapshotKind`)
+//line generator/snapshot_go.qtpl:80
+				qw422016.E().S(f.Kind)
+//line generator/snapshot_go.qtpl:80
+				if f.IsSlice {
+//line generator/snapshot_go.qtpl:80
+					qw422016.N().S(` | snapshotKindSlice`)
+//line generator/snapshot_go.qtpl:80
+				}
+//line generator/snapshot_go.qtpl:80
+				if f.IsDeprecated {
+//line generator/snapshot_go.qtpl:80
+					qw422016.N().S(`, Deprecated: true`)
+//line generator/snapshot_go.qtpl:80
+				}
+//line generator/snapshot_go.qtpl:80
+				qw422016.N().S(`},
+`)
+//line generator/snapshot_go.qtpl:81
+			}
+//line generator/snapshot_go.qtpl:81
+			qw422016.N().S(`}
+`)
+//line generator/snapshot_go.qtpl:83
+		}
+//line generator/snapshot_go.qtpl:84
+	}
+//line generator/snapshot_go.qtpl:84
+	qw422016.N().S(`
+func (w *World) MarshalBinary() ([]byte, error) {
+    sw := &snapshotWriter{}
+    sw.buf = append(sw.buf, snapshotMagic...)
+    sw.uvarint(snapshotFormat)
+    sw.uvarint(SchemaVersion)
+
+    sw.uvarint(uint64(w.nextEntityID))
+    sw.entity(w.resourceEntity)
+    sw.entities(w.livingEntities.dense)
+    sw.entities(w.freeEntities.dense)
+
+    sw.uvarint(`)
+//line generator/snapshot_go.qtpl:97
+	qw422016.N().D(len(data.Components))
+//line generator/snapshot_go.qtpl:97
+	qw422016.N().S(`)
+`)
+//line generator/snapshot_go.qtpl:98
+	for _, c := range data.Components {
+//line generator/snapshot_go.qtpl:99
+		nsc := c.Name.Singular.Camel
+
+//line generator/snapshot_go.qtpl:100
+		if c.IsTag {
+//line generator/snapshot_go.qtpl:100
+			qw422016.N().S(`    sw.set("`)
+//line generator/snapshot_go.qtpl:101
+			qw422016.E().S(c.SnapshotName())
+//line generator/snapshot_go.qtpl:101
+			qw422016.N().S(`", snapshotSetTag, nil, func(sw *snapshotWriter) {
+        sw.entities(w.`)
+//line generator/snapshot_go.qtpl:102
+			qw422016.E().S(nsc)
+//line generator/snapshot_go.qtpl:102
+			qw422016.N().S(`Tags.dense)
+    })
+`)
+//line generator/snapshot_go.qtpl:104
+		} else if c.IsRelationship {
+//line generator/snapshot_go.qtpl:104
+			qw422016.N().S(`    sw.set("`)
+//line generator/snapshot_go.qtpl:105
+			qw422016.E().S(c.SnapshotName())
+//line generator/snapshot_go.qtpl:105
+			qw422016.N().S(`", snapshotSetRelationship, `)
+//line generator/snapshot_go.qtpl:105
+			if len(c.Fields) > 0 {
+//line generator/snapshot_go.qtpl:105
+				qw422016.E().S(nsc)
+//line generator/snapshot_go.qtpl:105
+				qw422016.N().S(`SnapshotFields`)
+//line generator/snapshot_go.qtpl:105
+			} else {
+//line generator/snapshot_go.qtpl:105
+				qw422016.N().S(`nil`)
+//line generator/snapshot_go.qtpl:105
+			}
+//line generator/snapshot_go.qtpl:105
+			qw422016.N().S(`, func(sw *snapshotWriter) {
+        sw.uvarint(uint64(w.`)
+//line generator/snapshot_go.qtpl:106
+			qw422016.E().S(nsc)
+//line generator/snapshot_go.qtpl:106
+			qw422016.N().S(`Relationships.Len()))
+        w.`)
+//line generator/snapshot_go.qtpl:107
+			qw422016.E().S(nsc)
+//line generator/snapshot_go.qtpl:107
+			qw422016.N().S(`Relationships.byTo.Scan(func(pair `)
+//line generator/snapshot_go.qtpl:107
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:107
+			qw422016.N().S(`RelationshipPair) bool {
+            sw.entity(pair.From)
+            sw.entity(pair.To)
+`)
+//line generator/snapshot_go.qtpl:110
+			for _, f := range c.WritableFields() {
+//line generator/snapshot_go.qtpl:110
+				qw422016.N().S(`            `)
+//line generator/snapshot_go.qtpl:111
+				streamsnapshotWriteField(qw422016, f, "pair."+f.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:111
+			}
+//line generator/snapshot_go.qtpl:111
+			qw422016.N().S(`            return true
+        })
+    })
+`)
+//line generator/snapshot_go.qtpl:115
+		} else {
+//line generator/snapshot_go.qtpl:115
+			qw422016.N().S(`    sw.set("`)
+//line generator/snapshot_go.qtpl:116
+			qw422016.E().S(c.SnapshotName())
+//line generator/snapshot_go.qtpl:116
+			qw422016.N().S(`", snapshotSetComponent, `)
+//line generator/snapshot_go.qtpl:116
+			qw422016.E().S(nsc)
+//line generator/snapshot_go.qtpl:116
+			qw422016.N().S(`SnapshotFields, func(sw *snapshotWriter) {
+        set := w.`)
+//line generator/snapshot_go.qtpl:117
+			qw422016.E().S(nsc)
+//line generator/snapshot_go.qtpl:117
+			qw422016.N().S(`Components
+        sw.uvarint(uint64(len(set.dense)))
+        for i, e := range set.dense {
+            c := &set.data[i]
+            sw.entity(e)
+`)
+//line generator/snapshot_go.qtpl:122
+			for _, f := range c.WritableFields() {
+//line generator/snapshot_go.qtpl:122
+				qw422016.N().S(`            `)
+//line generator/snapshot_go.qtpl:123
+				streamsnapshotWriteField(qw422016, f, "c."+f.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:123
+			}
+//line generator/snapshot_go.qtpl:123
+			qw422016.N().S(`        }
+    })
+`)
+//line generator/snapshot_go.qtpl:126
+		}
+//line generator/snapshot_go.qtpl:127
+	}
+//line generator/snapshot_go.qtpl:127
+	qw422016.N().S(`
+    return sw.buf, nil
+}
+
+func (w *World) SaveSnapshot(writer io.Writer) error {
+    data, err := w.MarshalBinary()
+    if err != nil {
+        return err
+    }
+    _, err = writer.Write(data)
+    return err
+}
+
+// UnmarshalBinary replaces the world's entities with the snapshot's, systems
+// and event handlers are kept. The world is reset if the snapshot is invalid.
+func (w *World) UnmarshalBinary(data []byte) error {
+    if err := w.unmarshalBinary(data); err != nil {
+        w.Reset()
+        return err
+    }
+    return nil
+}
+
+func (w *World) LoadSnapshot(reader io.Reader) error {
+    data, err := io.ReadAll(reader)
+    if err != nil {
+        return fmt.Errorf("failed to read snapshot: %w", err)
+    }
+    return w.UnmarshalBinary(data)
+}
+
+func (w *World) unmarshalBinary(data []byte) error {
+    if !bytes.HasPrefix(data, snapshotMagic) {
+        return errors.New("not a snapshot")
+    }
+    r := &snapshotReader{buf: data, off: len(snapshotMagic)}
+    if format := r.uvarint(); format != snapshotFormat {
+        return fmt.Errorf("unsupported snapshot format %d", format)
+    }
+    version := r.uvarint()
+    if version > SchemaVersion {
+        return fmt.Errorf("snapshot schema version %d is newer than %d", version, SchemaVersion)
+    }
+    // unmatched values are only kept when there are migrations to run
+    var m *Migration
+    if version < SchemaVersion {
+        m = &Migration{
+            Snapshot:  uint32(version),
+            leftovers: map[string][]Leftover{},
+        }
+    }
+
+    w.Reset()
+    w.livingEntities.Clear()
+    w.nextEntityID = int(r.uvarint())
+    w.resourceEntity = r.entity()
+    for range r.count() {
+        w.livingEntities.Upsert(r.entity(), empty{})
+    }
+    for range r.count() {
+        w.freeEntities.Upsert(r.entity(), empty{})
+    }
+
+    for range r.count() {
+        name := r.str()
+        kind := snapshotSetKind(r.byte())
+        fields := r.fields()
+        payload := &snapshotReader{buf: r.raw()}
+        if r.err != nil {
+            break
+        }
+
+        switch {
+`)
+//line generator/snapshot_go.qtpl:201
+	for _, c := range data.Components {
+//line generator/snapshot_go.qtpl:202
+		nsc := c.Name.Singular.Camel
+
+//line generator/snapshot_go.qtpl:203
+		if c.IsTag {
+//line generator/snapshot_go.qtpl:203
+			qw422016.N().S(`        case name == "`)
+//line generator/snapshot_go.qtpl:204
+			qw422016.E().S(c.SnapshotName())
+//line generator/snapshot_go.qtpl:204
+			qw422016.N().S(`" && kind == snapshotSetTag:
+            for range payload.count() {
+                w.`)
+//line generator/snapshot_go.qtpl:206
+			qw422016.E().S(nsc)
+//line generator/snapshot_go.qtpl:206
+			qw422016.N().S(`Tags.Upsert(payload.living(w), empty{})
+            }
+`)
+//line generator/snapshot_go.qtpl:208
+		} else if c.IsRelationship {
+//line generator/snapshot_go.qtpl:208
+			qw422016.N().S(`        case name == "`)
+//line generator/snapshot_go.qtpl:209
+			qw422016.E().S(c.SnapshotName())
+//line generator/snapshot_go.qtpl:209
+			qw422016.N().S(`" && kind == snapshotSetRelationship:
+            w.load`)
+//line generator/snapshot_go.qtpl:210
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:210
+			qw422016.N().S(`Snapshot(payload, fields, m)
+`)
+//line generator/snapshot_go.qtpl:211
+		} else {
+//line generator/snapshot_go.qtpl:211
+			qw422016.N().S(`        case name == "`)
+//line generator/snapshot_go.qtpl:212
+			qw422016.E().S(c.SnapshotName())
+//line generator/snapshot_go.qtpl:212
+			qw422016.N().S(`" && kind == snapshotSetComponent:
+            w.load`)
+//line generator/snapshot_go.qtpl:213
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:213
+			qw422016.N().S(`Snapshot(payload, fields, m)
+`)
+//line generator/snapshot_go.qtpl:214
+		}
+//line generator/snapshot_go.qtpl:215
+	}
+//line generator/snapshot_go.qtpl:215
+	qw422016.N().S(`        default:
+            // the set no longer exists
+            m.readSet(name, kind, fields, payload)
+        }
+
+        if payload.err != nil {
+            return fmt.Errorf("failed to load %s: %w", name, payload.err)
+        }
+    }
+    if r.err != nil {
+        return r.err
+    }
+
+`)
+//line generator/snapshot_go.qtpl:229
+	for _, q := range data.Queries {
+//line generator/snapshot_go.qtpl:230
+		if q.IsOwningGroup {
+//line generator/snapshot_go.qtpl:230
+			qw422016.N().S(`    for _, e := range slices.Clone(w.`)
+//line generator/snapshot_go.qtpl:231
+			qw422016.E().S(sparseSetName(q.Required[0].ComponentOrTag))
+//line generator/snapshot_go.qtpl:231
+			qw422016.N().S(`.dense) {
+        w.`)
+//line generator/snapshot_go.qtpl:232
+			qw422016.E().S(q.Name.Singular.Camel)
+//line generator/snapshot_go.qtpl:232
+			qw422016.N().S(`GroupAdd(e)
+    }
+`)
+//line generator/snapshot_go.qtpl:234
+		}
+//line generator/snapshot_go.qtpl:235
+	}
+//line generator/snapshot_go.qtpl:235
+	qw422016.N().S(`
+    if m != nil {
+        for from := m.Snapshot; from < SchemaVersion; from++ {
+            m.From = from
+            for _, fn := range w.migrations[from] {
+                if err := fn(w, m); err != nil {
+                    return fmt.Errorf("migration from version %d failed: %w", from, err)
+                }
+            }
+        }
+    }
+
+    return nil
+}
+
+`)
+//line generator/snapshot_go.qtpl:251
+	for _, c := range data.Components {
+//line generator/snapshot_go.qtpl:252
+		if !c.IsTag {
+//line generator/snapshot_go.qtpl:253
+			nsc := c.Name.Singular.Camel
+
+//line generator/snapshot_go.qtpl:253
+			qw422016.N().S(`// load`)
+//line generator/snapshot_go.qtpl:254
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:254
+			qw422016.N().S(`Snapshot matches fields by name and kind, missing ones keep their reset value
+func (w *World) load`)
+//line generator/snapshot_go.qtpl:255
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:255
+			qw422016.N().S(`Snapshot(r *snapshotReader, fields []snapshotField, m *Migration) {
+`)
+//line generator/snapshot_go.qtpl:256
+			if len(c.Fields) > 0 {
+//line generator/snapshot_go.qtpl:256
+				qw422016.N().S(`    slots := snapshotSlots(fields, `)
+//line generator/snapshot_go.qtpl:257
+				qw422016.E().S(nsc)
+//line generator/snapshot_go.qtpl:257
+				qw422016.N().S(`SnapshotFields)
+`)
+//line generator/snapshot_go.qtpl:258
+			} else {
+//line generator/snapshot_go.qtpl:258
+				qw422016.N().S(`    slots := snapshotSlots(fields, nil)
+`)
+//line generator/snapshot_go.qtpl:260
+			}
+//line generator/snapshot_go.qtpl:260
+			qw422016.N().S(`    for range r.count() {
+`)
+//line generator/snapshot_go.qtpl:262
+			if c.IsRelationship {
+//line generator/snapshot_go.qtpl:262
+				qw422016.N().S(`        v := `)
+//line generator/snapshot_go.qtpl:263
+				qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:263
+				qw422016.N().S(`RelationshipPair{
+            From: r.living(w),
+            To: r.living(w),
+`)
+//line generator/snapshot_go.qtpl:266
+				for _, f := range c.Fields {
+//line generator/snapshot_go.qtpl:266
+					qw422016.N().S(`            `)
+//line generator/snapshot_go.qtpl:267
+					qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:267
+					qw422016.N().S(`: `)
+//line generator/snapshot_go.qtpl:267
+					qw422016.N().S(f.ResetValue)
+//line generator/snapshot_go.qtpl:267
+					qw422016.N().S(`,
+`)
+//line generator/snapshot_go.qtpl:268
+				}
+//line generator/snapshot_go.qtpl:268
+				qw422016.N().S(`        }
+`)
+//line generator/snapshot_go.qtpl:270
+			} else {
+//line generator/snapshot_go.qtpl:270
+				qw422016.N().S(`        e := r.living(w)
+        v := Default`)
+//line generator/snapshot_go.qtpl:272
+				qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:272
+				qw422016.N().S(`Component()
+`)
+//line generator/snapshot_go.qtpl:273
+			}
+//line generator/snapshot_go.qtpl:273
+			qw422016.N().S(`        var unmatched map[string]any
+        for i, slot := range slots {
+            switch slot {
+`)
+//line generator/snapshot_go.qtpl:277
+			for i, f := range c.Fields {
+//line generator/snapshot_go.qtpl:277
+				qw422016.N().S(`            case `)
+//line generator/snapshot_go.qtpl:278
+				qw422016.N().D(i)
+//line generator/snapshot_go.qtpl:278
+				qw422016.N().S(`:
+                `)
+//line generator/snapshot_go.qtpl:279
+				streamsnapshotReadField(qw422016, f, "v."+f.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:279
+			}
+//line generator/snapshot_go.qtpl:279
+			qw422016.N().S(`            default:
+                unmatched = m.read(r, fields[i], unmatched)
+            }
+        }
+`)
+//line generator/snapshot_go.qtpl:284
+			if c.IsRelationship {
+//line generator/snapshot_go.qtpl:284
+				qw422016.N().S(`        m.keep("`)
+//line generator/snapshot_go.qtpl:285
+				qw422016.E().S(c.SnapshotName())
+//line generator/snapshot_go.qtpl:285
+				qw422016.N().S(`", Leftover{Entity: v.From, To: v.To, Fields: unmatched})
+        w.`)
+//line generator/snapshot_go.qtpl:286
+				qw422016.E().S(nsc)
+//line generator/snapshot_go.qtpl:286
+				qw422016.N().S(`Relationships.set(v)
+`)
+//line generator/snapshot_go.qtpl:287
+			} else {
+//line generator/snapshot_go.qtpl:287
+				qw422016.N().S(`        m.keep("`)
+//line generator/snapshot_go.qtpl:288
+				qw422016.E().S(c.SnapshotName())
+//line generator/snapshot_go.qtpl:288
+				qw422016.N().S(`", Leftover{Entity: e, Fields: unmatched})
+        w.`)
+//line generator/snapshot_go.qtpl:289
+				qw422016.E().S(nsc)
+//line generator/snapshot_go.qtpl:289
+				qw422016.N().S(`Components.Upsert(e, v)
+`)
+//line generator/snapshot_go.qtpl:290
+			}
+//line generator/snapshot_go.qtpl:290
+			qw422016.N().S(`    }
+}
+`)
+//line generator/snapshot_go.qtpl:293
+		}
+//line generator/snapshot_go.qtpl:294
+	}
+//line generator/snapshot_go.qtpl:294
+	qw422016.N().S(`
+// snapshotSlots maps each snapshot field to the index of the current field
+// with the same name and kind, or -1 when there is none
+func snapshotSlots(fields, current []snapshotField) []int {
+    slots := make([]int, len(fields))
+    for i, f := range fields {
+        slots[i] = slices.IndexFunc(current, func(c snapshotField) bool {
+            return c.Name == f.Name && c.Kind == f.Kind
+        })
+    }
+    return slots
+}
+
+// MigrationFunc upgrades a world loaded from an older snapshot by one schema
+// version
+type MigrationFunc func(w *World, m *Migration) error
+
+// RegisterMigration adds a hook upgrading snapshots from the given version to
+// the next one, hooks for the same step run in the order they were added
+func (w *World) RegisterMigration(from uint32, fn MigrationFunc) {
+    if w.migrations == nil {
+        w.migrations = map[uint32][]MigrationFunc{}
+    }
+    w.migrations[from] = append(w.migrations[from], fn)
+}
+
+// Migration is handed to every hook while loading an older snapshot
+type Migration struct {
+    // Snapshot is the version the snapshot was saved with, From the version
+    // the current step upgrades
+    Snapshot, From uint32
+    leftovers      map[string][]Leftover
+}
+
+// Leftover is a snapshot entry the current schema had no place for, either
+// from a set that was removed or values of fields that were removed, renamed
+// or changed type
+type Leftover struct {
+    Entity Entity
+    // To is the target when the entry is a relationship pair
+    To Entity
+    // Fields holds values by field name, numbers are uint64, int64, float32 or
+    // float64, structs are their encoded []byte and multiple values are []any
+    Fields map[string]any
+}
+
+// Leftovers returns the entries of a set by its snapshot name, like
+// "Example.Position"
+func (m *Migration) Leftovers(set string) []Leftover {
+    return m.leftovers[set]
+}
+
+// read keeps a value no current field matches, or skips it when the snapshot
+// doesn't need migrating
+func (m *Migration) read(r *snapshotReader, f snapshotField, unmatched map[string]any) map[string]any {
+    if m == nil {
+        r.skip(f.Kind)
+        return unmatched
+    }
+    if unmatched == nil {
+        unmatched = map[string]any{}
+    }
+    unmatched[f.Name] = r.value(f.Kind)
+    return unmatched
+}
+
+func (m *Migration) keep(set string, leftover Leftover) {
+    if m == nil || leftover.Fields == nil {
+        return
+    }
+    m.leftovers[set] = append(m.leftovers[set], leftover)
+}
+
+// readSet keeps every entry of a set the current schema doesn't have
+func (m *Migration) readSet(set string, kind snapshotSetKind, fields []snapshotField, r *snapshotReader) {
+    if m == nil {
+        return
+    }
+    for range r.count() {
+        leftover := Leftover{Entity: r.entity()}
+        if kind == snapshotSetRelationship {
+            leftover.To = r.entity()
+        }
+        for _, f := range fields {
+            leftover.Fields = m.read(r, f, leftover.Fields)
+        }
+        m.leftovers[set] = append(m.leftovers[set], leftover)
+    }
+}
+
+type snapshotWriter struct {
+    buf []byte
+}
+
+func (sw *snapshotWriter) uvarint(v uint64) {
+    sw.buf = binary.AppendUvarint(sw.buf, v)
+}
+
+func (sw *snapshotWriter) varint(v int64) {
+    sw.buf = binary.AppendVarint(sw.buf, v)
+}
+
+func (sw *snapshotWriter) f32(v float32) {
+    sw.buf = binary.LittleEndian.AppendUint32(sw.buf, math.Float32bits(v))
+}
+
+func (sw *snapshotWriter) f64(v float64) {
+    sw.buf = binary.LittleEndian.AppendUint64(sw.buf, math.Float64bits(v))
+}
+
+func (sw *snapshotWriter) bytes(v []byte) {
+    sw.uvarint(uint64(len(v)))
+    sw.buf = append(sw.buf, v...)
+}
+
+func (sw *snapshotWriter) str(v string) {
+    sw.uvarint(uint64(len(v)))
+    sw.buf = append(sw.buf, v...)
+}
+
+func (sw *snapshotWriter) entity(e Entity) {
+    sw.uvarint(uint64(e))
+}
+
+func (sw *snapshotWriter) entities(entities []Entity) {
+    sw.uvarint(uint64(len(entities)))
+    for _, e := range entities {
+        sw.entity(e)
+    }
+}
+
+func (sw *snapshotWriter) set(name string, kind snapshotSetKind, fields []snapshotField, payload func(sw *snapshotWriter)) {
+    sw.str(name)
+    sw.buf = append(sw.buf, byte(kind))
+    fields = slices.DeleteFunc(slices.Clone(fields), func(f snapshotField) bool {
+        return f.Deprecated
+    })
+    sw.uvarint(uint64(len(fields)))
+    for _, f := range fields {
+        sw.str(f.Name)
+        sw.buf = append(sw.buf, byte(f.Kind))
+    }
+
+    inner := &snapshotWriter{}
+    payload(inner)
+    sw.bytes(inner.buf)
+}
+
+`)
+//line generator/snapshot_go.qtpl:443
+	if structs := data.SnapshotStructs(); len(structs) > 0 {
+//line generator/snapshot_go.qtpl:443
+		qw422016.N().S(`// nested writes a length prefixed value, so readers can skip it whole
+func (sw *snapshotWriter) nested(write func(sw *snapshotWriter)) {
+    inner := &snapshotWriter{}
+    write(inner)
+    sw.bytes(inner.buf)
+}
+
+func (r *snapshotReader) nested(read func(r *snapshotReader)) {
+    inner := &snapshotReader{buf: r.raw()}
+    read(inner)
+    if inner.err != nil && r.err == nil {
+        r.err = inner.err
+    }
+}
+`)
+//line generator/snapshot_go.qtpl:458
+		for _, st := range structs {
+//line generator/snapshot_go.qtpl:458
+			qw422016.N().S(`
+func (sw *snapshotWriter) `)
+//line generator/snapshot_go.qtpl:460
+			qw422016.E().S(st.SnapshotFunc())
+//line generator/snapshot_go.qtpl:460
+			qw422016.N().S(`(v `)
+//line generator/snapshot_go.qtpl:460
+			qw422016.E().S(st.GoType())
+//line generator/snapshot_go.qtpl:460
+			qw422016.N().S(`) {
+    sw.nested(func(sw *snapshotWriter) {
+`)
+//line generator/snapshot_go.qtpl:462
+			for _, f := range st.Fields {
+//line generator/snapshot_go.qtpl:462
+				qw422016.N().S(`        `)
+//line generator/snapshot_go.qtpl:463
+				streamsnapshotWriteField(qw422016, f, "v."+f.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:463
+			}
+//line generator/snapshot_go.qtpl:463
+			qw422016.N().S(`    })
+}
+
+func (r *snapshotReader) `)
+//line generator/snapshot_go.qtpl:467
+			qw422016.E().S(st.SnapshotFunc())
+//line generator/snapshot_go.qtpl:467
+			qw422016.N().S(`() (v `)
+//line generator/snapshot_go.qtpl:467
+			qw422016.E().S(st.GoType())
+//line generator/snapshot_go.qtpl:467
+			qw422016.N().S(`) {
+    r.nested(func(r *snapshotReader) {
+`)
+//line generator/snapshot_go.qtpl:469
+			for _, f := range st.Fields {
+//line generator/snapshot_go.qtpl:469
+				qw422016.N().S(`        `)
+//line generator/snapshot_go.qtpl:470
+				streamsnapshotReadField(qw422016, f, "v."+f.Name.Singular.Pascal)
+//line generator/snapshot_go.qtpl:470
+			}
+//line generator/snapshot_go.qtpl:470
+			qw422016.N().S(`    })
+    return v
+}
+`)
+//line generator/snapshot_go.qtpl:474
+		}
+//line generator/snapshot_go.qtpl:474
+		qw422016.N().S(`
+`)
+//line generator/snapshot_go.qtpl:476
+	}
+//line generator/snapshot_go.qtpl:476
+	qw422016.N().S(`// snapshotReader stops at the first error, every read after it returns zero
+// values so callers only check err once they are done
+type snapshotReader struct {
+    buf []byte
+    off int
+    err error
+}
+
+func (r *snapshotReader) fail() {
+    if r.err == nil {
+        r.err = errSnapshotTruncated
+    }
+    r.off = len(r.buf)
+}
+
+func (r *snapshotReader) byte() byte {
+    if r.off >= len(r.buf) {
+        r.fail()
+        return 0
+    }
+    b := r.buf[r.off]
+    r.off++
+    return b
+}
+
+func (r *snapshotReader) uvarint() uint64 {
+    v, n := binary.Uvarint(r.buf[r.off:])
+    if n <= 0 {
+        r.fail()
+        return 0
+    }
+    r.off += n
+    return v
+}
+
+func (r *snapshotReader) varint() int64 {
+    v, n := binary.Varint(r.buf[r.off:])
+    if n <= 0 {
+        r.fail()
+        return 0
+    }
+    r.off += n
+    return v
+}
+
+// count reads a length, capped by what is left so corrupt input can't
+// allocate more than its own size
+func (r *snapshotReader) count() int {
+    n := r.uvarint()
+    if n > uint64(len(r.buf)-r.off) {
+        r.fail()
+        return 0
+    }
+    return int(n)
+}
+
+func (r *snapshotReader) f32() float32 {
+    if len(r.buf)-r.off < 4 {
+        r.fail()
+        return 0
+    }
+    v := binary.LittleEndian.Uint32(r.buf[r.off:])
+    r.off += 4
+    return math.Float32frombits(v)
+}
+
+func (r *snapshotReader) f64() float64 {
+    if len(r.buf)-r.off < 8 {
+        r.fail()
+        return 0
+    }
+    v := binary.LittleEndian.Uint64(r.buf[r.off:])
+    r.off += 8
+    return math.Float64frombits(v)
+}
+
+// raw returns a length prefixed slice of the underlying buffer
+func (r *snapshotReader) raw() []byte {
+    n := r.count()
+    v := r.buf[r.off : r.off+n]
+    r.off += n
+    return v
+}
+
+func (r *snapshotReader) bytes() []byte {
+    return bytes.Clone(r.raw())
+}
+
+func (r *snapshotReader) str() string {
+    return string(r.raw())
+}
+
+func (r *snapshotReader) entity() Entity {
+    return Entity(r.uvarint())
+}
+
+// living reads an entity sets refer to, snapshots only store the sets of living
+// entities
+func (r *snapshotReader) living(w *World) Entity {
+    e := r.entity()
+    if r.err == nil && !w.IsAlive(e) {
+        r.err = fmt.Errorf("snapshot refers to entity %d which is not alive", e)
+        r.off = len(r.buf)
+    }
+    return e
+}
+
+func (r *snapshotReader) entities() []Entity {
+    entities := readSlice[Entity](r)
+    for i := range entities {
+        entities[i] = r.entity()
+    }
+    return entities
+}
+
+func (r *snapshotReader) fields() []snapshotField {
+    fields := make([]snapshotField, r.count())
+    for i := range fields {
+        fields[i].Name = r.str()
+        fields[i].Kind = snapshotKind(r.byte())
+    }
+    return fields
+}
+
+// skip reads past a value of a field that no longer exists
+func (r *snapshotReader) skip(kind snapshotKind) {
+    if kind&snapshotKindSlice != 0 {
+        for range r.count() {
+            r.skip(kind &^ snapshotKindSlice)
+        }
+        return
+    }
+
+    switch kind {
+    case snapshotKindI8, snapshotKindI16, snapshotKindI32, snapshotKindI64:
+        r.varint()
+    case snapshotKindF32:
+        r.f32()
+    case snapshotKindF64:
+        r.f64()
+    case snapshotKindTxt, snapshotKindBin, snapshotKindStruct:
+        r.raw()
+    case snapshotKindU8, snapshotKindU16, snapshotKindU32, snapshotKindU64,
+        snapshotKindEntity, snapshotKindEnum:
+        r.uvarint()
+    default:
+        if r.err == nil {
+            r.err = fmt.Errorf("unknown snapshot field kind %d", kind)
+        }
+        r.off = len(r.buf)
+    }
+}
+
+// value decodes a field without knowing its Go type, for migrations
+func (r *snapshotReader) value(kind snapshotKind) any {
+    if kind&snapshotKindSlice != 0 {
+        values := make([]any, r.count())
+        for i := range values {
+            values[i] = r.value(kind &^ snapshotKindSlice)
+        }
+        return values
+    }
+
+    switch kind {
+    case snapshotKindI8, snapshotKindI16, snapshotKindI32, snapshotKindI64:
+        return r.varint()
+    case snapshotKindF32:
+        return r.f32()
+    case snapshotKindF64:
+        return r.f64()
+    case snapshotKindTxt:
+        return r.str()
+    case snapshotKindBin, snapshotKindStruct:
+        return r.bytes()
+    case snapshotKindEntity:
+        return r.entity()
+    case snapshotKindU8, snapshotKindU16, snapshotKindU32, snapshotKindU64, snapshotKindEnum:
+        return r.uvarint()
+    default:
+        r.skip(kind)
+        return nil
+    }
+}
+
+`)
+//line generator/snapshot_go.qtpl:661
+}
+
+//line generator/snapshot_go.qtpl:661
+func writesnapshotTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
+//line generator/snapshot_go.qtpl:661
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/snapshot_go.qtpl:661
+	streamsnapshotTemplate(qw422016, data)
+//line generator/snapshot_go.qtpl:661
+	qt422016.ReleaseWriter(qw422016)
+//line generator/snapshot_go.qtpl:661
+}
+
+//line generator/snapshot_go.qtpl:661
+func snapshotTemplate(data *ecsTmplData) string {
+//line generator/snapshot_go.qtpl:661
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/snapshot_go.qtpl:661
+	writesnapshotTemplate(qb422016, data)
+//line generator/snapshot_go.qtpl:661
+	qs422016 := string(qb422016.B)
+//line generator/snapshot_go.qtpl:661
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/snapshot_go.qtpl:661
+	return qs422016
+//line generator/snapshot_go.qtpl:661
+}
+
+//line generator/snapshot_go.qtpl:663
+func streamsnapshotWriteField(qw422016 *qt422016.Writer, f fieldTemplateData, expr string) {
+//line generator/snapshot_go.qtpl:663
+	if f.IsSlice {
+//line generator/snapshot_go.qtpl:663
+		qw422016.N().S(`            sw.uvarint(uint64(len(`)
+//line generator/snapshot_go.qtpl:664
+		qw422016.N().S(expr)
+//line generator/snapshot_go.qtpl:664
+		qw422016.N().S(`)))
+            for _, v := range `)
+//line generator/snapshot_go.qtpl:665
+		qw422016.N().S(expr)
+//line generator/snapshot_go.qtpl:665
+		qw422016.N().S(` {
+                `)
+//line generator/snapshot_go.qtpl:666
+		qw422016.N().S(snapshotWrite(f, "v"))
+//line generator/snapshot_go.qtpl:666
+		qw422016.N().S(`
+            }
+`)
+//line generator/snapshot_go.qtpl:668
+	} else {
+//line generator/snapshot_go.qtpl:668
+		qw422016.N().S(`            `)
+//line generator/snapshot_go.qtpl:669
+		qw422016.N().S(snapshotWrite(f, expr))
+//line generator/snapshot_go.qtpl:669
+		qw422016.N().S(`
+`)
+//line generator/snapshot_go.qtpl:670
+	}
+//line generator/snapshot_go.qtpl:670
+}
+
+//line generator/snapshot_go.qtpl:670
+func writesnapshotWriteField(qq422016 qtio422016.Writer, f fieldTemplateData, expr string) {
+//line generator/snapshot_go.qtpl:670
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/snapshot_go.qtpl:670
+	streamsnapshotWriteField(qw422016, f, expr)
+//line generator/snapshot_go.qtpl:670
+	qt422016.ReleaseWriter(qw422016)
+//line generator/snapshot_go.qtpl:670
+}
+
+//line generator/snapshot_go.qtpl:670
+func snapshotWriteField(f fieldTemplateData, expr string) string {
+//line generator/snapshot_go.qtpl:670
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/snapshot_go.qtpl:670
+	writesnapshotWriteField(qb422016, f, expr)
+//line generator/snapshot_go.qtpl:670
+	qs422016 := string(qb422016.B)
+//line generator/snapshot_go.qtpl:670
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/snapshot_go.qtpl:670
+	return qs422016
+//line generator/snapshot_go.qtpl:670
+}
+
+//line generator/snapshot_go.qtpl:672
+func streamsnapshotReadField(qw422016 *qt422016.Writer, f fieldTemplateData, expr string) {
+//line generator/snapshot_go.qtpl:672
+	if f.IsSlice {
+//line generator/snapshot_go.qtpl:672
+		qw422016.N().S(`                `)
+//line generator/snapshot_go.qtpl:673
+		qw422016.N().S(expr)
+//line generator/snapshot_go.qtpl:673
+		qw422016.N().S(` = make(`)
+//line generator/snapshot_go.qtpl:673
+		qw422016.E().S(f.Type.Singular.Original)
+//line generator/snapshot_go.qtpl:673
+		qw422016.N().S(`, r.count())
+                for j := range `)
+//line generator/snapshot_go.qtpl:674
+		qw422016.N().S(expr)
+//line generator/snapshot_go.qtpl:674
+		qw422016.N().S(` {
+                    `)
+//line generator/snapshot_go.qtpl:675
+		qw422016.N().S(expr)
+//line generator/snapshot_go.qtpl:675
+		qw422016.N().S(`[j] = `)
+//line generator/snapshot_go.qtpl:675
+		qw422016.N().S(snapshotRead(f))
+//line generator/snapshot_go.qtpl:675
+		qw422016.N().S(`
+                }
+`)
+//line generator/snapshot_go.qtpl:677
+	} else {
+//line generator/snapshot_go.qtpl:677
+		qw422016.N().S(`                `)
+//line generator/snapshot_go.qtpl:678
+		qw422016.N().S(expr)
+//line generator/snapshot_go.qtpl:678
+		qw422016.N().S(` = `)
+//line generator/snapshot_go.qtpl:678
+		qw422016.N().S(snapshotRead(f))
+//line generator/snapshot_go.qtpl:678
+		qw422016.N().S(`
+`)
+//line generator/snapshot_go.qtpl:679
+	}
+//line generator/snapshot_go.qtpl:679
+}
+
+//line generator/snapshot_go.qtpl:679
+func writesnapshotReadField(qq422016 qtio422016.Writer, f fieldTemplateData, expr string) {
+//line generator/snapshot_go.qtpl:679
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/snapshot_go.qtpl:679
+	streamsnapshotReadField(qw422016, f, expr)
+//line generator/snapshot_go.qtpl:679
+	qt422016.ReleaseWriter(qw422016)
+//line generator/snapshot_go.qtpl:679
+}
+
+//line generator/snapshot_go.qtpl:679
+func snapshotReadField(f fieldTemplateData, expr string) string {
+//line generator/snapshot_go.qtpl:679
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/snapshot_go.qtpl:679
+	writesnapshotReadField(qb422016, f, expr)
+//line generator/snapshot_go.qtpl:679
+	qs422016 := string(qb422016.B)
+//line generator/snapshot_go.qtpl:679
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/snapshot_go.qtpl:679
+	return qs422016
+//line generator/snapshot_go.qtpl:679
+}
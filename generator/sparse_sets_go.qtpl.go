@@ -30,12 +30,23 @@ package `)
 //line generator/sparse_sets_go.qtpl:4
 	qw422016.N().S(`
 
+import "slices"
+
 const ssTombstoneIndex = -1
 
 type SparseSet[T any] struct {
 	sparse []int
 	dense  []Entity
 	data      []T
+
+	// version changes whenever the set might have been written to, including
+	// handing out mutable pointers, so Diff can skip untouched sets
+	version uint64
+
+	// sets tracking changes stamp the world's change tick per dense slot,
+	// tick is nil otherwise
+	tick           *uint64
+	added, changed []uint64
 }
 
 func NewSparseSet[T any]() *SparseSet[T] {
@@ -43,7 +54,9 @@ func NewSparseSet[T any]() *SparseSet[T] {
 	}
 }
 
-func (s *SparseSet[T]) search(idx int) int {
+// slot returns the dense index stored for idx regardless of the generation
+// of the entity occupying it.
+func (s *SparseSet[T]) slot(idx int) int {
 	sl, dl := len(s.sparse), len(s.dense)
 	if idx >= sl || dl == 0 {
 		return -1
@@ -54,12 +67,21 @@ func (s *SparseSet[T]) search(idx int) int {
 		return -1
 	}
 
-	dense := s.dense[denseIdx]
-	if denseIdx < len(s.dense) && dense.Index() == idx {
-		return denseIdx
+	if s.dense[denseIdx].Index() != idx {
+		return -1
 	}
 
-	return -1
+	return denseIdx
+}
+
+// search returns the dense index of e, rejecting stale handles whose
+// generation doesn't match the entity stored in the slot.
+func (s *SparseSet[T]) search(e Entity) int {
+	denseIdx := s.slot(e.Index())
+	if denseIdx == -1 || s.dense[denseIdx] != e {
+		return -1
+	}
+	return denseIdx
 }
 
 func (s *SparseSet[T]) grow(idx int) {
@@ -73,28 +95,43 @@ func (s *SparseSet[T]) grow(idx int) {
 	}
 }
 
+// Upsert adds or replaces the value of e. A slot holding another generation of
+// e's index is left alone, one of the handles is stale and destroyed entities
+// never keep values, so writers check liveness first.
 func (s *SparseSet[T]) Upsert(e Entity, c T) (old T, wasAdded bool) {
 	idx := e.Index()
-	searchIdx := s.search(idx)
-	if searchIdx != -1 {
-		old = s.data[searchIdx]
-		s.data[searchIdx] = c
+	slotIdx := s.slot(idx)
+	if slotIdx != -1 {
+		if s.dense[slotIdx] != e {
+			return old, false
+		}
+		s.version++
+		old = s.data[slotIdx]
+		s.data[slotIdx] = c
+		s.touch(slotIdx)
 		return old, false
 	}
 
+	s.version++
+
 	s.grow(idx)
 	s.sparse[idx] = len(s.dense)
 	s.dense = append(s.dense, e)
 	s.data = append(s.data, c)
+	if s.tick != nil {
+		s.added = append(s.added, *s.tick)
+		s.changed = append(s.changed, *s.tick)
+	}
 	return old, true
 }
 
 func (s *SparseSet[T]) Remove(e Entity) (wasRemoved bool) {
 	idx := e.Index()
-	sIdx := s.search(idx)
+	sIdx := s.search(e)
 	if sIdx == -1 {
 		return false
 	}
+	s.version++
 
 	lastIdx := len(s.dense) - 1
 	lastEntity := s.dense[lastIdx]
@@ -105,15 +142,38 @@ func (s *SparseSet[T]) Remove(e Entity) (wasRemoved bool) {
 	s.sparse[idx] = ssTombstoneIndex
 	s.dense = s.dense[:lastIdx]
 	s.data = s.data[:lastIdx]
+	if s.tick != nil {
+		s.added[sIdx] = s.added[lastIdx]
+		s.changed[sIdx] = s.changed[lastIdx]
+		s.added = s.added[:lastIdx]
+		s.changed = s.changed[:lastIdx]
+	}
 	return true
 }
 
+// swap exchanges two dense slots, used by owning groups to keep their
+// members packed at the front of the set.
+func (s *SparseSet[T]) swap(i, j int) {
+	if i == j {
+		return
+	}
+	ei, ej := s.dense[i], s.dense[j]
+	s.dense[i], s.dense[j] = ej, ei
+	s.data[i], s.data[j] = s.data[j], s.data[i]
+	if s.tick != nil {
+		s.added[i], s.added[j] = s.added[j], s.added[i]
+		s.changed[i], s.changed[j] = s.changed[j], s.changed[i]
+	}
+	s.sparse[ei.Index()] = j
+	s.sparse[ej.Index()] = i
+}
+
 func (s *SparseSet[T]) Contains(e Entity) bool {
-	return s.search(e.Index()) != -1
+	return s.search(e) != -1
 }
 
 func (s *SparseSet[T]) Data(e Entity) (T, bool) {
-	idx := s.search(e.Index())
+	idx := s.search(e)
 	if idx == -1 {
 		var zero T
 		return zero, false
@@ -122,11 +182,18 @@ func (s *SparseSet[T]) Data(e Entity) (T, bool) {
 }
 
 func (s *SparseSet[T]) DataMutable(e Entity) (*T,bool) {
-	idx := s.search(e.Index())
+	idx := s.search(e)
 	if idx == -1 {
 		return nil, false
 	}
-	return &s.data[idx], true
+	return s.mutableAt(idx), true
+}
+
+// mutableAt hands out the value in a dense slot, stamping it as written
+func (s *SparseSet[T]) mutableAt(i int) *T {
+	s.version++
+	s.touch(i)
+	return &s.data[i]
 }
 
 func (s *SparseSet[T]) All(yield func(e Entity, c T) bool) {
@@ -139,8 +206,10 @@ func (s *SparseSet[T]) All(yield func(e Entity, c T) bool) {
 }
 
 func (s *SparseSet[T]) AllMutable(yield func(e Entity, c *T) bool) {
+	s.version++
 	for i, e := range s.dense {
 		data := &s.data[i]
+		s.touch(i)
 		if !yield(e, data) {
 			break
 		}
@@ -156,9 +225,42 @@ func (s *SparseSet[T]) AllEntities(yield func(e Entity) bool) {
 }
 
 func (s *SparseSet[T]) Clear() {
+	s.version++
 	s.sparse = s.sparse[:0]
 	s.dense = s.dense[:0]
 	s.data = s.data[:0]
+	s.added = s.added[:0]
+	s.changed = s.changed[:0]
+}
+
+// touch stamps a dense slot as changed, mutable pointers are assumed to be
+// written through
+func (s *SparseSet[T]) touch(i int) {
+	if s.tick != nil {
+		s.changed[i] = *s.tick
+	}
+}
+
+// changedSince is true when e was written after the given change tick, adding
+// counts as a change
+func (s *SparseSet[T]) changedSince(e Entity, since uint64) bool {
+	idx := s.search(e)
+	return idx != -1 && s.tick != nil && s.changed[idx] > since
+}
+
+func (s *SparseSet[T]) addedSince(e Entity, since uint64) bool {
+	idx := s.search(e)
+	return idx != -1 && s.tick != nil && s.added[idx] > since
+}
+
+// clone copies the set, values are copied shallowly
+func (s *SparseSet[T]) clone() *SparseSet[T] {
+	return &SparseSet[T]{
+		sparse:  slices.Clone(s.sparse),
+		dense:   slices.Clone(s.dense),
+		data:    slices.Clone(s.data),
+		version: s.version,
+	}
 }
 
 func (s *SparseSet[T]) Len() int {
@@ -170,31 +272,31 @@ func (s *SparseSet[T]) Cap() int {
 }
 
 `)
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 }
 
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 func writesparseSetTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 	streamsparseSetTemplate(qw422016, data)
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 	qt422016.ReleaseWriter(qw422016)
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 }
 
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 func sparseSetTemplate(data *ecsTmplData) string {
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 	qb422016 := qt422016.AcquireByteBuffer()
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 	writesparseSetTemplate(qb422016, data)
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 	qs422016 := string(qb422016.B)
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 	qt422016.ReleaseByteBuffer(qb422016)
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 	return qs422016
-//line generator/sparse_sets_go.qtpl:145
+//line generator/sparse_sets_go.qtpl:247
 }
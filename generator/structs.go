@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"fmt"
+
+	geckpb "github.com/delaneyj/geck/pb/gen/geck/v1"
+	"github.com/delaneyj/toolbelt"
+)
+
+type structTmplData struct {
+	PackageName string
+	BundleName  toolbelt.CasedString
+	Name        InflectionString
+	Description string
+	Fields      []fieldTemplateData
+	// IsMathx structs come from components/mathx, their fields only describe
+	// how they are saved
+	IsMathx bool
+}
+
+// GoType is how fields refer to the struct
+func (s *structTmplData) GoType() string {
+	if s.IsMathx {
+		return "mathx." + s.Name.Singular.Pascal + "[float32]"
+	}
+	return s.Name.Singular.Pascal
+}
+
+// SnapshotFunc names the snapshot reader and writer methods of the struct
+func (s *structTmplData) SnapshotFunc() string {
+	if s.IsMathx {
+		return "mathx" + s.Name.Singular.Pascal
+	}
+	return "struct" + s.Name.Singular.Pascal
+}
+
+func mathxStruct(name string, fields ...fieldTemplateData) *structTmplData {
+	return &structTmplData{
+		Name: InflectionString{
+			Singular: toolbelt.ToCasedString(name),
+			Plural:   toolbelt.ToCasedString(name),
+		},
+		Fields:  fields,
+		IsMathx: true,
+	}
+}
+
+func mathxField(name, kind, typ string, s *structTmplData) fieldTemplateData {
+	cased := InflectionString{
+		Singular: toolbelt.ToCasedString(name),
+		Plural:   toolbelt.ToCasedString(name),
+	}
+	typCased := InflectionString{
+		Singular: toolbelt.ToCasedString(typ),
+		Plural:   toolbelt.ToCasedString(typ),
+	}
+	return fieldTemplateData{Name: cased, Type: typCased, Kind: kind, ElemType: typ, Struct: s}
+}
+
+var (
+	mathxVector3 = mathxStruct(
+		"Vector3",
+		mathxField("X", "F32", "float32", nil),
+		mathxField("Y", "F32", "float32", nil),
+		mathxField("Z", "F32", "float32", nil),
+	)
+	mathxQuaternion = mathxStruct(
+		"Quaternion",
+		mathxField("X", "F32", "float32", nil),
+		mathxField("Y", "F32", "float32", nil),
+		mathxField("Z", "F32", "float32", nil),
+		mathxField("W", "F32", "float32", nil),
+	)
+	mathxBox3 = mathxStruct(
+		"Box3",
+		mathxField("Min", "Struct", mathxVector3.GoType(), mathxVector3),
+		mathxField("Max", "Struct", mathxVector3.GoType(), mathxVector3),
+	)
+)
+
+func vector3Literal(v *geckpb.Vector3) string {
+	return fmt.Sprintf(
+		"%s{X: %f, Y: %f, Z: %f}",
+		mathxVector3.GoType(), v.GetX(), v.GetY(), v.GetZ(),
+	)
+}
+
+// quaternionLiteral resets an all zero quaternion to the identity, a zero
+// rotation is never what is meant
+func quaternionLiteral(q *geckpb.Quaternion) string {
+	x, y, z, w := q.GetX(), q.GetY(), q.GetZ(), q.GetW()
+	if x == 0 && y == 0 && z == 0 && w == 0 {
+		w = 1
+	}
+	return fmt.Sprintf(
+		"%s{X: %f, Y: %f, Z: %f, W: %f}",
+		mathxQuaternion.GoType(), x, y, z, w,
+	)
+}
+
+func box3Literal(b *geckpb.Box3) string {
+	return fmt.Sprintf(
+		"%s{Min: %s, Max: %s}",
+		mathxBox3.GoType(), vector3Literal(b.GetMin()), vector3Literal(b.GetMax()),
+	)
+}
+
+// SnapshotStructs are the structs component fields save, including the
+// structs nested in them
+func (data *ecsTmplData) SnapshotStructs() []*structTmplData {
+	var structs []*structTmplData
+	seen := map[*structTmplData]bool{}
+	var visit func(fields []fieldTemplateData)
+	visit = func(fields []fieldTemplateData) {
+		for _, f := range fields {
+			if f.Struct == nil || seen[f.Struct] {
+				continue
+			}
+			seen[f.Struct] = true
+			visit(f.Struct.Fields)
+			structs = append(structs, f.Struct)
+		}
+	}
+	for _, c := range data.Components {
+		visit(c.Fields)
+	}
+	return structs
+}
@@ -0,0 +1,212 @@
+// Code generated by qtc from "structs.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// package generator
+//
+
+//line generator/structs.qtpl:3
+package generator
+
+//line generator/structs.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line generator/structs.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line generator/structs.qtpl:3
+func streamstructTemplate(qw422016 *qt422016.Writer, data *structTmplData) {
+//line generator/structs.qtpl:3
+	qw422016.N().S(`
+package `)
+//line generator/structs.qtpl:4
+	qw422016.E().S(data.PackageName)
+//line generator/structs.qtpl:4
+	qw422016.N().S(`
+
+import (
+    "github.com/delaneyj/geck/components/mathx"
+)
+
+`)
+//line generator/structs.qtpl:11
+	nsp := data.Name.Singular.Pascal
+
+//line generator/structs.qtpl:12
+	qw422016.N().S(`
+type `)
+//line generator/structs.qtpl:14
+	qw422016.E().S(nsp)
+//line generator/structs.qtpl:14
+	qw422016.N().S(` struct {
+`)
+//line generator/structs.qtpl:15
+	for _, f := range data.Fields {
+//line generator/structs.qtpl:16
+		if f.IsDeprecated {
+//line generator/structs.qtpl:16
+			qw422016.N().S(`    // Deprecated: the FromValues helper leaves it at its reset value
+`)
+//line generator/structs.qtpl:18
+		}
+//line generator/structs.qtpl:18
+		qw422016.N().S(`    `)
+//line generator/structs.qtpl:19
+		qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/structs.qtpl:19
+		qw422016.N().S(` `)
+//line generator/structs.qtpl:19
+		qw422016.E().S(f.Type.Singular.Original)
+//line generator/structs.qtpl:19
+		qw422016.N().S(` `)
+//line generator/structs.qtpl:19
+		qw422016.N().S("`")
+//line generator/structs.qtpl:19
+		qw422016.N().S(`json:"`)
+//line generator/structs.qtpl:19
+		qw422016.E().S(f.Name.Singular.Camel)
+//line generator/structs.qtpl:19
+		qw422016.N().S(`"`)
+//line generator/structs.qtpl:19
+		qw422016.N().S("`")
+//line generator/structs.qtpl:19
+		qw422016.N().S(`
+`)
+//line generator/structs.qtpl:20
+	}
+//line generator/structs.qtpl:20
+	qw422016.N().S(`}
+
+// Default`)
+//line generator/structs.qtpl:23
+	qw422016.E().S(nsp)
+//line generator/structs.qtpl:23
+	qw422016.N().S(` is a `)
+//line generator/structs.qtpl:23
+	qw422016.E().S(nsp)
+//line generator/structs.qtpl:23
+	qw422016.N().S(` with every field at its reset value
+func Default`)
+//line generator/structs.qtpl:24
+	qw422016.E().S(nsp)
+//line generator/structs.qtpl:24
+	qw422016.N().S(`() `)
+//line generator/structs.qtpl:24
+	qw422016.E().S(nsp)
+//line generator/structs.qtpl:24
+	qw422016.N().S(` {
+    return `)
+//line generator/structs.qtpl:25
+	qw422016.E().S(nsp)
+//line generator/structs.qtpl:25
+	qw422016.N().S(`{
+`)
+//line generator/structs.qtpl:26
+	for _, f := range data.Fields {
+//line generator/structs.qtpl:26
+		qw422016.N().S(`        `)
+//line generator/structs.qtpl:27
+		qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/structs.qtpl:27
+		qw422016.N().S(`: `)
+//line generator/structs.qtpl:27
+		qw422016.N().S(f.ResetValue)
+//line generator/structs.qtpl:27
+		qw422016.N().S(`,
+`)
+//line generator/structs.qtpl:28
+	}
+//line generator/structs.qtpl:28
+	qw422016.N().S(`    }
+}
+
+func `)
+//line generator/structs.qtpl:32
+	qw422016.E().S(nsp)
+//line generator/structs.qtpl:32
+	qw422016.N().S(`FromValues(
+`)
+//line generator/structs.qtpl:33
+	for _, f := range data.Fields {
+//line generator/structs.qtpl:34
+		if !f.IsDeprecated {
+//line generator/structs.qtpl:34
+			qw422016.N().S(`    `)
+//line generator/structs.qtpl:35
+			qw422016.E().S(f.Name.Singular.Camel)
+//line generator/structs.qtpl:35
+			qw422016.N().S(`Arg `)
+//line generator/structs.qtpl:35
+			qw422016.E().S(f.Type.Singular.Original)
+//line generator/structs.qtpl:35
+			qw422016.N().S(`,
+`)
+//line generator/structs.qtpl:36
+		}
+//line generator/structs.qtpl:37
+	}
+//line generator/structs.qtpl:37
+	qw422016.N().S(`) `)
+//line generator/structs.qtpl:38
+	qw422016.E().S(nsp)
+//line generator/structs.qtpl:38
+	qw422016.N().S(` {
+    return `)
+//line generator/structs.qtpl:39
+	qw422016.E().S(nsp)
+//line generator/structs.qtpl:39
+	qw422016.N().S(`{
+`)
+//line generator/structs.qtpl:40
+	for _, f := range data.Fields {
+//line generator/structs.qtpl:40
+		qw422016.N().S(`        `)
+//line generator/structs.qtpl:41
+		qw422016.E().S(f.Name.Singular.Pascal)
+//line generator/structs.qtpl:41
+		qw422016.N().S(`: `)
+//line generator/structs.qtpl:41
+		qw422016.N().S(f.FromValuesArg())
+//line generator/structs.qtpl:41
+		qw422016.N().S(`,
+`)
+//line generator/structs.qtpl:42
+	}
+//line generator/structs.qtpl:42
+	qw422016.N().S(`    }
+}
+`)
+//line generator/structs.qtpl:45
+}
+
+//line generator/structs.qtpl:45
+func writestructTemplate(qq422016 qtio422016.Writer, data *structTmplData) {
+//line generator/structs.qtpl:45
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/structs.qtpl:45
+	streamstructTemplate(qw422016, data)
+//line generator/structs.qtpl:45
+	qt422016.ReleaseWriter(qw422016)
+//line generator/structs.qtpl:45
+}
+
+//line generator/structs.qtpl:45
+func structTemplate(data *structTmplData) string {
+//line generator/structs.qtpl:45
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/structs.qtpl:45
+	writestructTemplate(qb422016, data)
+//line generator/structs.qtpl:45
+	qs422016 := string(qb422016.B)
+//line generator/structs.qtpl:45
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/structs.qtpl:45
+	return qs422016
+//line generator/structs.qtpl:45
+}
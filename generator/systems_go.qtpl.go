@@ -0,0 +1,490 @@
+// Code generated by qtc from "systems_go.qtpl". DO NOT EDIT.
+// See https://github.com/valyala/quicktemplate for details.
+
+// package generator
+//
+
+//line generator/systems_go.qtpl:3
+package generator
+
+//line generator/systems_go.qtpl:3
+import (
+	qtio422016 "io"
+
+	qt422016 "github.com/valyala/quicktemplate"
+)
+
+//line generator/systems_go.qtpl:3
+var (
+	_ = qtio422016.Copy
+	_ = qt422016.AcquireByteBuffer
+)
+
+//line generator/systems_go.qtpl:3
+func streamsystemsTemplate(qw422016 *qt422016.Writer, data *ecsTmplData) {
+//line generator/systems_go.qtpl:3
+	qw422016.N().S(`
+package `)
+//line generator/systems_go.qtpl:4
+	qw422016.E().S(data.PackageName)
+//line generator/systems_go.qtpl:4
+	qw422016.N().S(`
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "slices"
+    "strings"
+    "sync"
+)
+
+// AddSystems initializes the systems and schedules them with the ones already
+// added, a system always ticks after every system it relies on
+func (w *World) AddSystems(ctx context.Context, systems ...System) error {
+    scheduled := append([]*scheduledSystem{}, w.systems...)
+    for _, s := range systems {
+        if sysTicker, ok := s.(SystemTicker); ok {
+            scheduled = append(scheduled, &scheduledSystem{ticker: sysTicker})
+        }
+    }
+
+    // check the order before initializing so a cycle leaves the world untouched
+    ordered, stages, err := scheduleSystems(scheduled)
+    if err != nil {
+        return err
+    }
+
+    for _, s := range systems {
+        if err := s.Initialize(ctx, w); err != nil {
+            return fmt.Errorf("failed to initialize system %s: %w", systemName(s), err)
+        }
+    }
+
+    w.systems = ordered
+    w.systemStages = stages
+    return nil
+}
+
+type TickMode int
+
+const (
+    // TickSequential runs one system at a time in dependency order
+    TickSequential TickMode = iota
+    // TickParallel runs each stage's systems in their own goroutines, systems
+    // share a stage when their access doesn't conflict, including the sets
+    // observers and prefabs read behind the declared ones
+    TickParallel
+)
+
+func (w *World) SetTickMode(mode TickMode) {
+    w.tickMode = mode
+}
+
+// Tick runs every system in dependency order, stopping at the first error
+func (w *World) Tick(ctx context.Context) error {
+    if w.tickMode == TickParallel {
+        return w.tickParallel(ctx)
+    }
+
+    for _, s := range w.systems {
+        w.changeTick++
+        if err := s.ticker.Tick(s.context(ctx), w); err != nil {
+            return fmt.Errorf("system %s failed: %w", systemName(s.ticker), err)
+        }
+        s.lastRunTick = w.changeTick
+        w.FlushCommands()
+    }
+    return nil
+}
+
+// scheduledSystem is a system with the change tick it last ran at, systems are
+// never compared so any type can be one
+type scheduledSystem struct {
+    ticker      SystemTicker
+    lastRunTick uint64
+}
+
+type lastRunTickKey struct{}
+
+// LastRunTick is the change tick the ticking system last ran at, pass it to
+// queries with Changed or Added filters to see what was written since. It is
+// 0 on a system's first tick and outside of systems.
+func LastRunTick(ctx context.Context) uint64 {
+    tick, _ := ctx.Value(lastRunTickKey{}).(uint64)
+    return tick
+}
+
+// context carries the tick the system last ran at
+func (s *scheduledSystem) context(ctx context.Context) context.Context {
+    return context.WithValue(ctx, lastRunTickKey{}, s.lastRunTick)
+}
+
+// tickParallel waits for every system in a stage before starting the next,
+// a stage with errors stops the tick. Commands are flushed between stages.
+// Systems in a stage share a change tick, they never write what another one
+// reads.
+func (w *World) tickParallel(ctx context.Context) error {
+    for _, stage := range w.systemStages {
+        w.changeTick++
+        if len(stage) == 1 {
+            s := stage[0]
+            if err := s.ticker.Tick(s.context(ctx), w); err != nil {
+                return fmt.Errorf("system %s failed: %w", systemName(s.ticker), err)
+            }
+            s.lastRunTick = w.changeTick
+            w.FlushCommands()
+            continue
+        }
+
+        stageCtxs := make([]context.Context, len(stage))
+        for i, s := range stage {
+            stageCtxs[i] = s.context(ctx)
+        }
+
+        errs := make([]error, len(stage))
+        wg := sync.WaitGroup{}
+        for i, s := range stage {
+            wg.Add(1)
+            go func() {
+                defer wg.Done()
+                if err := s.ticker.Tick(stageCtxs[i], w); err != nil {
+                    errs[i] = fmt.Errorf("system %s failed: %w", systemName(s.ticker), err)
+                }
+            }()
+        }
+        wg.Wait()
+
+        if err := errors.Join(errs...); err != nil {
+            return err
+        }
+        for _, s := range stage {
+            s.lastRunTick = w.changeTick
+        }
+        w.FlushCommands()
+    }
+    return nil
+}
+
+// ReliedOnIter reports whether a system relies on reliedOn, a nil iter means
+// the system relies on nothing
+type ReliedOnIter func(reliedOn System) bool
+
+type System interface {
+    Initialize(ctx context.Context, w *World) error
+    ReliesOn() ReliedOnIter
+}
+
+type SystemTicker interface {
+    System
+    Tick(ctx context.Context, w *World) error
+}
+
+// scheduleSystems sorts the systems topologically, ties keep the order they
+// were added in. Stages group systems whose dependencies ran in earlier stages
+// and whose access doesn't conflict.
+func scheduleSystems(systems []*scheduledSystem) (ordered []*scheduledSystem, stages [][]*scheduledSystem, err error) {
+    // dependents[i] are the systems that rely on systems[i]
+    dependents := make([][]int, len(systems))
+    reliedOn := make([][]int, len(systems))
+    waitingOn := make([]int, len(systems))
+    for i, s := range systems {
+        reliesOn := s.ticker.ReliesOn()
+        if reliesOn == nil {
+            continue
+        }
+        for j, other := range systems {
+            if i == j || !reliesOn(other.ticker) {
+                continue
+            }
+            dependents[j] = append(dependents[j], i)
+            reliedOn[i] = append(reliedOn[i], j)
+            waitingOn[i]++
+        }
+    }
+
+    ordered = make([]*scheduledSystem, 0, len(systems))
+    scheduled := make([]bool, len(systems))
+    stageOf := make([]int, len(systems))
+    var stageAccess []Access
+    for len(ordered) < len(systems) {
+        next := -1
+        for i := range systems {
+            if !scheduled[i] && waitingOn[i] == 0 {
+                next = i
+                break
+            }
+        }
+        if next == -1 {
+            return nil, nil, systemCycleError(systems, scheduled, waitingOn)
+        }
+
+        scheduled[next] = true
+        ordered = append(ordered, systems[next])
+        for _, dependent := range dependents[next] {
+            waitingOn[dependent]--
+        }
+
+        access := systemAccess(systems[next].ticker)
+        stage := 0
+        for _, dep := range reliedOn[next] {
+            stage = max(stage, stageOf[dep]+1)
+        }
+        for stage < len(stages) && access.conflicts(stageAccess[stage]) {
+            stage++
+        }
+        if stage == len(stages) {
+            stages = append(stages, nil)
+            stageAccess = append(stageAccess, Access{})
+        }
+        stageOf[next] = stage
+        stages[stage] = append(stages[stage], systems[next])
+        stageAccess[stage] = stageAccess[stage].Merge(access)
+    }
+
+    return ordered, stages, nil
+}
+
+func systemCycleError(systems []*scheduledSystem, scheduled []bool, waitingOn []int) error {
+    var names []string
+    for i, s := range systems {
+        if !scheduled[i] && waitingOn[i] > 0 {
+            names = append(names, systemName(s.ticker))
+        }
+    }
+    return fmt.Errorf("dependency cycle, these systems can never tick: %s", strings.Join(names, ", "))
+}
+
+// ComponentID identifies a tag, component or relationship set when declaring
+// system access
+type ComponentID int
+
+const (
+`)
+//line generator/systems_go.qtpl:236
+	for i, c := range data.Components {
+//line generator/systems_go.qtpl:237
+		if i == 0 {
+//line generator/systems_go.qtpl:237
+			qw422016.N().S(`    `)
+//line generator/systems_go.qtpl:238
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/systems_go.qtpl:238
+			qw422016.N().S(`ID ComponentID = iota
+`)
+//line generator/systems_go.qtpl:239
+		} else {
+//line generator/systems_go.qtpl:239
+			qw422016.N().S(`    `)
+//line generator/systems_go.qtpl:240
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/systems_go.qtpl:240
+			qw422016.N().S(`ID
+`)
+//line generator/systems_go.qtpl:241
+		}
+//line generator/systems_go.qtpl:242
+	}
+//line generator/systems_go.qtpl:242
+	qw422016.N().S(`)
+
+// ownedSets are kept in lockstep by owning groups, writing one swaps the others
+var ownedSets = [][]ComponentID{
+`)
+//line generator/systems_go.qtpl:247
+	for _, q := range data.Queries {
+//line generator/systems_go.qtpl:248
+		if q.IsOwningGroup {
+//line generator/systems_go.qtpl:248
+			qw422016.N().S(`    {
+`)
+//line generator/systems_go.qtpl:250
+			for _, entry := range q.Required {
+//line generator/systems_go.qtpl:250
+				qw422016.N().S(`        `)
+//line generator/systems_go.qtpl:251
+				qw422016.E().S(entry.Name.Singular.Pascal)
+//line generator/systems_go.qtpl:251
+				qw422016.N().S(`ID,
+`)
+//line generator/systems_go.qtpl:252
+			}
+//line generator/systems_go.qtpl:252
+			qw422016.N().S(`    },
+`)
+//line generator/systems_go.qtpl:254
+		}
+//line generator/systems_go.qtpl:255
+	}
+//line generator/systems_go.qtpl:255
+	qw422016.N().S(`}
+
+// observedReads are the sets a write to the key checks, to fire enter and exit
+// events for the queries observing it
+var observedReads = map[ComponentID][]ComponentID{
+`)
+//line generator/systems_go.qtpl:261
+	for _, c := range data.Components {
+//line generator/systems_go.qtpl:262
+		if len(c.ObservedBy) > 0 {
+//line generator/systems_go.qtpl:262
+			qw422016.N().S(`    `)
+//line generator/systems_go.qtpl:263
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/systems_go.qtpl:263
+			qw422016.N().S(`ID: {
+`)
+//line generator/systems_go.qtpl:264
+			for _, read := range c.ObservedReads() {
+//line generator/systems_go.qtpl:264
+				qw422016.N().S(`        `)
+//line generator/systems_go.qtpl:265
+				qw422016.E().S(read.Name.Singular.Pascal)
+//line generator/systems_go.qtpl:265
+				qw422016.N().S(`ID,
+`)
+//line generator/systems_go.qtpl:266
+			}
+//line generator/systems_go.qtpl:266
+			qw422016.N().S(`    },
+`)
+//line generator/systems_go.qtpl:268
+		}
+//line generator/systems_go.qtpl:269
+	}
+//line generator/systems_go.qtpl:269
+	qw422016.N().S(`}
+
+// inheritableSets fall back to IsA prefabs, reading or writing one reads IsA
+var inheritableSets = []ComponentID{
+`)
+//line generator/systems_go.qtpl:274
+	for _, c := range data.Components {
+//line generator/systems_go.qtpl:275
+		if c.IsInheritable() {
+//line generator/systems_go.qtpl:275
+			qw422016.N().S(`    `)
+//line generator/systems_go.qtpl:276
+			qw422016.E().S(c.Name.Singular.Pascal)
+//line generator/systems_go.qtpl:276
+			qw422016.N().S(`ID,
+`)
+//line generator/systems_go.qtpl:277
+		}
+//line generator/systems_go.qtpl:278
+	}
+//line generator/systems_go.qtpl:278
+	qw422016.N().S(`}
+
+// Access is what a system reads and writes, the generated QueryXAccess values
+// describe each query
+type Access struct {
+    Reads, Writes []ComponentID
+    // Exclusive systems create or destroy entities, or touch the world in ways
+    // the sets can't describe, they always tick alone
+    Exclusive bool
+}
+
+// SystemAccess is implemented by systems that declare their access, systems
+// without it are exclusive
+type SystemAccess interface {
+    Access() Access
+}
+
+func (a Access) Merge(others ...Access) Access {
+    merged := Access{
+        Reads:     slices.Clone(a.Reads),
+        Writes:    slices.Clone(a.Writes),
+        Exclusive: a.Exclusive,
+    }
+    for _, other := range others {
+        merged.Reads = append(merged.Reads, other.Reads...)
+        merged.Writes = append(merged.Writes, other.Writes...)
+        merged.Exclusive = merged.Exclusive || other.Exclusive
+    }
+    return merged
+}
+
+func (a Access) conflicts(other Access) bool {
+    if a.Exclusive || other.Exclusive {
+        return true
+    }
+    for _, id := range a.Writes {
+        if slices.Contains(other.Reads, id) || slices.Contains(other.Writes, id) {
+            return true
+        }
+    }
+    for _, id := range other.Writes {
+        if slices.Contains(a.Reads, id) {
+            return true
+        }
+    }
+    return false
+}
+
+func systemAccess(s System) Access {
+    declared, ok := s.(SystemAccess)
+    if !ok {
+        return Access{Exclusive: true}
+    }
+
+    // writes and reads touch more than the declared sets, so systems only
+    // share a stage when those don't conflict either
+    access := declared.Access()
+    access.Reads = slices.Clone(access.Reads)
+    access.Writes = slices.Clone(access.Writes)
+    for _, owned := range ownedSets {
+        if slices.ContainsFunc(owned, func(id ComponentID) bool {
+            return slices.Contains(access.Writes, id)
+        }) {
+            access.Writes = append(access.Writes, owned...)
+        }
+    }
+    for _, id := range access.Writes {
+        access.Reads = append(access.Reads, observedReads[id]...)
+    }
+    if slices.ContainsFunc(inheritableSets, func(id ComponentID) bool {
+        return slices.Contains(access.Reads, id) || slices.Contains(access.Writes, id)
+    }) {
+        access.Reads = append(access.Reads, IsAID)
+    }
+    return access
+}
+
+// systemName uses the system's Name method when it has one
+func systemName(s System) string {
+    if named, ok := s.(interface{ Name() string }); ok {
+        return named.Name()
+    }
+    return fmt.Sprintf("%T", s)
+}
+
+`)
+//line generator/systems_go.qtpl:364
+}
+
+//line generator/systems_go.qtpl:364
+func writesystemsTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
+//line generator/systems_go.qtpl:364
+	qw422016 := qt422016.AcquireWriter(qq422016)
+//line generator/systems_go.qtpl:364
+	streamsystemsTemplate(qw422016, data)
+//line generator/systems_go.qtpl:364
+	qt422016.ReleaseWriter(qw422016)
+//line generator/systems_go.qtpl:364
+}
+
+//line generator/systems_go.qtpl:364
+func systemsTemplate(data *ecsTmplData) string {
+//line generator/systems_go.qtpl:364
+	qb422016 := qt422016.AcquireByteBuffer()
+//line generator/systems_go.qtpl:364
+	writesystemsTemplate(qb422016, data)
+//line generator/systems_go.qtpl:364
+	qs422016 := string(qb422016.B)
+//line generator/systems_go.qtpl:364
+	qt422016.ReleaseByteBuffer(qb422016)
+//line generator/systems_go.qtpl:364
+	return qs422016
+//line generator/systems_go.qtpl:364
+}
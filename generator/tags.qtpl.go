@@ -30,121 +30,239 @@ package `)
 //line generator/tags.qtpl:4
 	qw422016.N().S(`
 
+import (
+    "slices"
+    "github.com/btvoidx/mint"
+)
+
 `)
-//line generator/tags.qtpl:7
+//line generator/tags.qtpl:12
 	nsp := data.Name.Singular.Pascal
 	nsc := data.Name.Singular.Camel
 	ss := nsc + "Tags"
 
-//line generator/tags.qtpl:10
+//line generator/tags.qtpl:15
 	qw422016.N().S(`
 
 func (w *World) TagWith`)
-//line generator/tags.qtpl:12
+//line generator/tags.qtpl:17
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:12
+//line generator/tags.qtpl:17
 	qw422016.N().S(`(entities ...Entity) (anyUpdated bool) {
-    for _, e := range entities {
-        if _, updated := w.`)
-//line generator/tags.qtpl:14
+`)
+//line generator/tags.qtpl:18
+	if data.ShouldGenAdded {
+//line generator/tags.qtpl:18
+		qw422016.N().S(`    var added []Entity
+`)
+//line generator/tags.qtpl:20
+	}
+//line generator/tags.qtpl:20
+	qw422016.N().S(`    for _, e := range entities {
+        if !w.IsAlive(e) {
+            continue
+        }
+`)
+//line generator/tags.qtpl:25
+	if len(data.ObservedBy) > 0 {
+//line generator/tags.qtpl:25
+		qw422016.N().S(`        `)
+//line generator/tags.qtpl:26
+		streamqueryObservedBefore(qw422016, data, "e")
+//line generator/tags.qtpl:26
+		qw422016.N().S(`
+`)
+//line generator/tags.qtpl:27
+	}
+//line generator/tags.qtpl:27
+	qw422016.N().S(`        if _, updated := w.`)
+//line generator/tags.qtpl:28
 	qw422016.E().S(ss)
-//line generator/tags.qtpl:14
+//line generator/tags.qtpl:28
 	qw422016.N().S(`.Upsert(e, empty{}); updated{
             anyUpdated = true
 `)
-//line generator/tags.qtpl:16
+//line generator/tags.qtpl:30
+	if data.OwnedBySet != nil {
+//line generator/tags.qtpl:30
+		qw422016.N().S(`            w.`)
+//line generator/tags.qtpl:31
+		qw422016.E().S(data.OwnedBySet.Name.Singular.Camel)
+//line generator/tags.qtpl:31
+		qw422016.N().S(`GroupAdd(e)
+`)
+//line generator/tags.qtpl:32
+	}
+//line generator/tags.qtpl:33
+	if len(data.ObservedBy) > 0 {
+//line generator/tags.qtpl:33
+		qw422016.N().S(`            `)
+//line generator/tags.qtpl:34
+		streamqueryObservedAfter(qw422016, data, "e")
+//line generator/tags.qtpl:34
+		qw422016.N().S(`
+`)
+//line generator/tags.qtpl:35
+	}
+//line generator/tags.qtpl:36
 	if data.ShouldGenAdded {
-//line generator/tags.qtpl:16
-		qw422016.N().S(`            fireEvent(w, `)
-//line generator/tags.qtpl:17
-		qw422016.E().S(nsp)
-//line generator/tags.qtpl:17
-		qw422016.N().S(`AddedEvent{Entities: []Entity{e}})
+//line generator/tags.qtpl:36
+		qw422016.N().S(`            added = append(added, e)
 `)
-//line generator/tags.qtpl:18
+//line generator/tags.qtpl:38
 	}
-//line generator/tags.qtpl:18
+//line generator/tags.qtpl:38
 	qw422016.N().S(`        }
     }
-
+`)
+//line generator/tags.qtpl:41
+	if data.ShouldGenAdded {
+//line generator/tags.qtpl:41
+		qw422016.N().S(`    if len(added) > 0 {
+        fireEvent(w, `)
+//line generator/tags.qtpl:43
+		qw422016.E().S(nsp)
+//line generator/tags.qtpl:43
+		qw422016.N().S(`AddedEvent{Entities: added})
+    }
+`)
+//line generator/tags.qtpl:45
+	}
+//line generator/tags.qtpl:45
+	qw422016.N().S(`
     return anyUpdated
 }
 
 func (w *World) Remove`)
-//line generator/tags.qtpl:25
+//line generator/tags.qtpl:50
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:25
+//line generator/tags.qtpl:50
 	qw422016.N().S(`Tag(entities ...Entity) (anyRemoved bool) {
-    for _, e := range entities {
-        if removed := w.`)
-//line generator/tags.qtpl:27
+`)
+//line generator/tags.qtpl:51
+	if data.ShouldGenRemoved {
+//line generator/tags.qtpl:51
+		qw422016.N().S(`    var removed []Entity
+`)
+//line generator/tags.qtpl:53
+	}
+//line generator/tags.qtpl:53
+	qw422016.N().S(`    for _, e := range entities {
+`)
+//line generator/tags.qtpl:55
+	if len(data.ObservedBy) > 0 {
+//line generator/tags.qtpl:55
+		qw422016.N().S(`        `)
+//line generator/tags.qtpl:56
+		streamqueryObservedBefore(qw422016, data, "e")
+//line generator/tags.qtpl:56
+		qw422016.N().S(`
+`)
+//line generator/tags.qtpl:57
+	}
+//line generator/tags.qtpl:58
+	if data.OwnedBySet != nil {
+//line generator/tags.qtpl:58
+		qw422016.N().S(`        w.`)
+//line generator/tags.qtpl:59
+		qw422016.E().S(data.OwnedBySet.Name.Singular.Camel)
+//line generator/tags.qtpl:59
+		qw422016.N().S(`GroupRemove(e)
+`)
+//line generator/tags.qtpl:60
+	}
+//line generator/tags.qtpl:60
+	qw422016.N().S(`        if w.`)
+//line generator/tags.qtpl:61
 	qw422016.E().S(ss)
-//line generator/tags.qtpl:27
-	qw422016.N().S(`.Remove(e); removed {
+//line generator/tags.qtpl:61
+	qw422016.N().S(`.Remove(e) {
             anyRemoved = true
 `)
-//line generator/tags.qtpl:29
+//line generator/tags.qtpl:63
+	if len(data.ObservedBy) > 0 {
+//line generator/tags.qtpl:63
+		qw422016.N().S(`            `)
+//line generator/tags.qtpl:64
+		streamqueryObservedAfter(qw422016, data, "e")
+//line generator/tags.qtpl:64
+		qw422016.N().S(`
+`)
+//line generator/tags.qtpl:65
+	}
+//line generator/tags.qtpl:66
 	if data.ShouldGenRemoved {
-//line generator/tags.qtpl:29
-		qw422016.N().S(`            fireEvent(w, `)
-//line generator/tags.qtpl:30
-		qw422016.E().S(nsp)
-//line generator/tags.qtpl:30
-		qw422016.N().S(`RemovedEvent{Entities: []Entity{e}})
+//line generator/tags.qtpl:66
+		qw422016.N().S(`            removed = append(removed, e)
 `)
-//line generator/tags.qtpl:31
+//line generator/tags.qtpl:68
 	}
-//line generator/tags.qtpl:31
+//line generator/tags.qtpl:68
 	qw422016.N().S(`        }
     }
-    return anyRemoved
+`)
+//line generator/tags.qtpl:71
+	if data.ShouldGenRemoved {
+//line generator/tags.qtpl:71
+		qw422016.N().S(`    if len(removed) > 0 {
+        fireEvent(w, `)
+//line generator/tags.qtpl:73
+		qw422016.E().S(nsp)
+//line generator/tags.qtpl:73
+		qw422016.N().S(`RemovedEvent{Entities: removed})
+    }
+`)
+//line generator/tags.qtpl:75
+	}
+//line generator/tags.qtpl:75
+	qw422016.N().S(`    return anyRemoved
 }
 
 func (w *World) Has`)
-//line generator/tags.qtpl:37
+//line generator/tags.qtpl:79
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:37
+//line generator/tags.qtpl:79
 	qw422016.N().S(`Tag(entity Entity) bool {
     return w.`)
-//line generator/tags.qtpl:38
+//line generator/tags.qtpl:80
 	qw422016.E().S(ss)
-//line generator/tags.qtpl:38
+//line generator/tags.qtpl:80
 	qw422016.N().S(`.Contains(entity)
 }
 
 func (w *World) `)
-//line generator/tags.qtpl:41
+//line generator/tags.qtpl:83
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:41
+//line generator/tags.qtpl:83
 	qw422016.N().S(`TagCount() int {
     return w.`)
-//line generator/tags.qtpl:42
+//line generator/tags.qtpl:84
 	qw422016.E().S(ss)
-//line generator/tags.qtpl:42
+//line generator/tags.qtpl:84
 	qw422016.N().S(`.Len()
 }
 
 func (w *World) `)
-//line generator/tags.qtpl:45
+//line generator/tags.qtpl:87
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:45
+//line generator/tags.qtpl:87
 	qw422016.N().S(`TagCapacity() int {
     return w.`)
-//line generator/tags.qtpl:46
+//line generator/tags.qtpl:88
 	qw422016.E().S(ss)
-//line generator/tags.qtpl:46
+//line generator/tags.qtpl:88
 	qw422016.N().S(`.Cap()
 }
 
 func (w *World) All`)
-//line generator/tags.qtpl:49
+//line generator/tags.qtpl:91
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:49
+//line generator/tags.qtpl:91
 	qw422016.N().S(`Entities(yield func(e Entity) bool) {
     for e := range w.`)
-//line generator/tags.qtpl:50
+//line generator/tags.qtpl:92
 	qw422016.E().S(ss)
-//line generator/tags.qtpl:50
+//line generator/tags.qtpl:92
 	qw422016.N().S(`.All {
         if !yield(e) {
             break
@@ -153,81 +271,112 @@ func (w *World) All`)
 }
 
 // `)
-//line generator/tags.qtpl:57
+//line generator/tags.qtpl:99
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:57
+//line generator/tags.qtpl:99
 	qw422016.N().S(`Builder
 func With`)
-//line generator/tags.qtpl:58
+//line generator/tags.qtpl:100
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:58
+//line generator/tags.qtpl:100
 	qw422016.N().S(`Tag() EntityBuilderOption {
     return func(w *World, e Entity) {
-        w.`)
-//line generator/tags.qtpl:60
-	qw422016.E().S(ss)
-//line generator/tags.qtpl:60
-	qw422016.N().S(`.Upsert(e, empty{})
+        w.TagWith`)
+//line generator/tags.qtpl:102
+	qw422016.E().S(nsp)
+//line generator/tags.qtpl:102
+	qw422016.N().S(`(e)
     }
 }
 
 // Resource
 func (w *World) ResourceUpsert`)
-//line generator/tags.qtpl:65
+//line generator/tags.qtpl:107
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:65
+//line generator/tags.qtpl:107
 	qw422016.N().S(`Tag() {
-    w.`)
-//line generator/tags.qtpl:66
-	qw422016.E().S(nsc)
-//line generator/tags.qtpl:66
-	qw422016.N().S(`Tags.Upsert(w.resourceEntity, empty{})
+    w.TagWith`)
+//line generator/tags.qtpl:108
+	qw422016.E().S(nsp)
+//line generator/tags.qtpl:108
+	qw422016.N().S(`(w.resourceEntity)
 }
 
 func (w *World) ResourceRemove`)
-//line generator/tags.qtpl:69
+//line generator/tags.qtpl:111
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:69
+//line generator/tags.qtpl:111
 	qw422016.N().S(`Tag() {
-    w.`)
-//line generator/tags.qtpl:70
-	qw422016.E().S(nsc)
-//line generator/tags.qtpl:70
-	qw422016.N().S(`Tags.Remove(w.resourceEntity)
+    w.Remove`)
+//line generator/tags.qtpl:112
+	qw422016.E().S(nsp)
+//line generator/tags.qtpl:112
+	qw422016.N().S(`Tag(w.resourceEntity)
 }
 
 func (w *World) ResourceHas`)
-//line generator/tags.qtpl:73
+//line generator/tags.qtpl:115
 	qw422016.E().S(nsp)
-//line generator/tags.qtpl:73
+//line generator/tags.qtpl:115
 	qw422016.N().S(`Tag() bool {
     return w.`)
-//line generator/tags.qtpl:74
+//line generator/tags.qtpl:116
 	qw422016.E().S(nsc)
-//line generator/tags.qtpl:74
+//line generator/tags.qtpl:116
 	qw422016.N().S(`Tags.Contains(w.resourceEntity)
 }
 
+// Commands
+func (cb *CommandBuffer) TagWith`)
+//line generator/tags.qtpl:120
+	qw422016.E().S(nsp)
+//line generator/tags.qtpl:120
+	qw422016.N().S(`(entities ...Entity) {
+    entities = slices.Clone(entities)
+    cb.record(func(w *World) {
+        w.TagWith`)
+//line generator/tags.qtpl:123
+	qw422016.E().S(nsp)
+//line generator/tags.qtpl:123
+	qw422016.N().S(`(entities...)
+    })
+}
+
+func (cb *CommandBuffer) Remove`)
+//line generator/tags.qtpl:127
+	qw422016.E().S(nsp)
+//line generator/tags.qtpl:127
+	qw422016.N().S(`Tag(entities ...Entity) {
+    entities = slices.Clone(entities)
+    cb.record(func(w *World) {
+        w.Remove`)
+//line generator/tags.qtpl:130
+	qw422016.E().S(nsp)
+//line generator/tags.qtpl:130
+	qw422016.N().S(`Tag(entities...)
+    })
+}
+
 // Events
 `)
-//line generator/tags.qtpl:78
+//line generator/tags.qtpl:135
 	if data.ShouldGenAdded {
-//line generator/tags.qtpl:78
+//line generator/tags.qtpl:135
 		qw422016.N().S(`type `)
-//line generator/tags.qtpl:79
+//line generator/tags.qtpl:136
 		qw422016.E().S(nsp)
-//line generator/tags.qtpl:79
+//line generator/tags.qtpl:136
 		qw422016.N().S(`AddedEvent struct {
     Entities []Entity
 }
 func (w *World) On`)
-//line generator/tags.qtpl:82
+//line generator/tags.qtpl:139
 		qw422016.E().S(nsp)
-//line generator/tags.qtpl:82
+//line generator/tags.qtpl:139
 		qw422016.N().S(`Added(fn func(evt `)
-//line generator/tags.qtpl:82
+//line generator/tags.qtpl:139
 		qw422016.E().S(nsp)
-//line generator/tags.qtpl:82
+//line generator/tags.qtpl:139
 		qw422016.N().S(`AddedEvent)) UnsubscribeFunc {
     unsub := mint.On(w.eventBus, fn)
     return func() {
@@ -235,29 +384,29 @@ func (w *World) On`)
     }
 }
 `)
-//line generator/tags.qtpl:88
+//line generator/tags.qtpl:145
 	}
-//line generator/tags.qtpl:88
+//line generator/tags.qtpl:145
 	qw422016.N().S(`
 `)
-//line generator/tags.qtpl:90
+//line generator/tags.qtpl:147
 	if data.ShouldGenRemoved {
-//line generator/tags.qtpl:90
+//line generator/tags.qtpl:147
 		qw422016.N().S(`type `)
-//line generator/tags.qtpl:91
+//line generator/tags.qtpl:148
 		qw422016.E().S(nsp)
-//line generator/tags.qtpl:91
+//line generator/tags.qtpl:148
 		qw422016.N().S(`RemovedEvent struct {
     Entities []Entity
 }
 func (w *World) On`)
-//line generator/tags.qtpl:94
+//line generator/tags.qtpl:151
 		qw422016.E().S(nsp)
-//line generator/tags.qtpl:94
+//line generator/tags.qtpl:151
 		qw422016.N().S(`Removed(fn func(evt `)
-//line generator/tags.qtpl:94
+//line generator/tags.qtpl:151
 		qw422016.E().S(nsp)
-//line generator/tags.qtpl:94
+//line generator/tags.qtpl:151
 		qw422016.N().S(`RemovedEvent)) UnsubscribeFunc {
     unsub := mint.On(w.eventBus, fn)
     return func() {
@@ -265,36 +414,36 @@ func (w *World) On`)
     }
 }
 `)
-//line generator/tags.qtpl:100
+//line generator/tags.qtpl:157
 	}
-//line generator/tags.qtpl:100
+//line generator/tags.qtpl:157
 	qw422016.N().S(`
 `)
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 }
 
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 func writetagTemplate(qq422016 qtio422016.Writer, data *componentTmplData) {
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 	streamtagTemplate(qw422016, data)
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 	qt422016.ReleaseWriter(qw422016)
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 }
 
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 func tagTemplate(data *componentTmplData) string {
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 	qb422016 := qt422016.AcquireByteBuffer()
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 	writetagTemplate(qb422016, data)
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 	qs422016 := string(qb422016.B)
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 	qt422016.ReleaseByteBuffer(qb422016)
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 	return qs422016
-//line generator/tags.qtpl:102
+//line generator/tags.qtpl:159
 }
@@ -187,10 +187,10 @@ templ SparseSetView[T any](ss *SparseSet[T]) {
                                         for j := 0; j < fields; j++ {
                                             {{
                                                 key := fmt.Sprint(elem.Type().Field(j).Name)
-                                                value := fmt.Sprint(elem.Field(j))
                                             }}
                                             <div>
-                                                { key }➡️<span class="font-bold">{ value }</span>
+                                                { key }➡️
+                                                @FieldView(elem.Field(j))
                                             </div>
                                         }
                                     </td>
@@ -204,32 +204,59 @@ templ SparseSetView[T any](ss *SparseSet[T]) {
     }
 }
 
+// FieldView shows struct and mathx values field by field, anything else as
+// it prints
+templ FieldView(v reflect.Value) {
+    switch {
+        case v.Kind() == reflect.Struct:
+            <div class="pl-4">
+                for i := 0; i < v.NumField(); i++ {
+                    <div>
+                        { v.Type().Field(i).Name }➡️
+                        @FieldView(v.Field(i))
+                    </div>
+                }
+            </div>
+        case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Struct:
+            <div class="pl-4">
+                for i := 0; i < v.Len(); i++ {
+                    <div>
+                        { fmt.Sprint(i) }➡️
+                        @FieldView(v.Index(i))
+                    </div>
+                }
+            </div>
+        default:
+            <span class="font-bold">{ fmt.Sprint(v) }</span>
+    }
+}
+
 `)
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 }
 
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 func writetemplTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 	streamtemplTemplate(qw422016, data)
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 	qt422016.ReleaseWriter(qw422016)
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 }
 
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 func templTemplate(data *ecsTmplData) string {
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 	qb422016 := qt422016.AcquireByteBuffer()
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 	writetemplTemplate(qb422016, data)
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 	qs422016 := string(qb422016.B)
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 	qt422016.ReleaseByteBuffer(qb422016)
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 	return qs422016
-//line generator/templ_templates.qtpl:135
+//line generator/templ_templates.qtpl:162
 }
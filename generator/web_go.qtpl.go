@@ -31,6 +31,8 @@ func streamwebTemplate(qw422016 *qt422016.Writer, data *ecsTmplData) {
 
 import (
     "context"
+    "net/http"
+
     "github.com/go-chi/chi/v5"
 )
 
@@ -45,83 +47,83 @@ func SetupRoutes(setupCtx context.Context, world *World, baseRouter chi.Router)
         })
 
 `)
-//line generator/web_go.qtpl:21
+//line generator/web_go.qtpl:23
 	for _, c := range data.Components {
-//line generator/web_go.qtpl:21
+//line generator/web_go.qtpl:23
 		qw422016.N().S(`            `)
-//line generator/web_go.qtpl:22
+//line generator/web_go.qtpl:24
 		if !c.IsRelationship {
-//line generator/web_go.qtpl:22
+//line generator/web_go.qtpl:24
 			qw422016.N().S(`
             sparseSetsRouter.Route("/`)
-//line generator/web_go.qtpl:23
+//line generator/web_go.qtpl:25
 			qw422016.E().S(c.Name.Plural.Snake)
-//line generator/web_go.qtpl:23
+//line generator/web_go.qtpl:25
 			qw422016.N().S(`", func(ssRouter chi.Router) {
                 ssRouter.Get("/", func(w http.ResponseWriter, r *http.Request) {
 `)
-//line generator/web_go.qtpl:25
+//line generator/web_go.qtpl:27
 			if c.IsTag && !c.IsRelationship {
-//line generator/web_go.qtpl:25
+//line generator/web_go.qtpl:27
 				qw422016.N().S(`                        ss := world.`)
-//line generator/web_go.qtpl:26
+//line generator/web_go.qtpl:28
 				qw422016.E().S(c.Name.Singular.Camel)
-//line generator/web_go.qtpl:26
+//line generator/web_go.qtpl:28
 				qw422016.N().S(`Tags
 `)
-//line generator/web_go.qtpl:27
+//line generator/web_go.qtpl:29
 			} else {
-//line generator/web_go.qtpl:27
+//line generator/web_go.qtpl:29
 				qw422016.N().S(`                        ss := world.`)
-//line generator/web_go.qtpl:28
+//line generator/web_go.qtpl:30
 				qw422016.E().S(c.Name.Singular.Camel)
-//line generator/web_go.qtpl:28
+//line generator/web_go.qtpl:30
 				qw422016.N().S(`Components
 `)
-//line generator/web_go.qtpl:29
+//line generator/web_go.qtpl:31
 			}
-//line generator/web_go.qtpl:29
+//line generator/web_go.qtpl:31
 			qw422016.N().S(`                        SparseSetView(ss).Render(r.Context(),w)
                     })
 
             })
 `)
-//line generator/web_go.qtpl:34
+//line generator/web_go.qtpl:36
 		}
-//line generator/web_go.qtpl:35
+//line generator/web_go.qtpl:37
 	}
-//line generator/web_go.qtpl:35
+//line generator/web_go.qtpl:37
 	qw422016.N().S(`    })
 
     return nil
 }
 
 `)
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 }
 
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 func writewebTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 	streamwebTemplate(qw422016, data)
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 	qt422016.ReleaseWriter(qw422016)
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 }
 
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 func webTemplate(data *ecsTmplData) string {
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 	qb422016 := qt422016.AcquireByteBuffer()
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 	writewebTemplate(qb422016, data)
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 	qs422016 := string(qb422016.B)
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 	qt422016.ReleaseByteBuffer(qb422016)
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 	return qs422016
-//line generator/web_go.qtpl:41
+//line generator/web_go.qtpl:43
 }
@@ -42,73 +42,118 @@ type World struct{
     nextEntityID int
     livingEntities,freeEntities *SparseSet[empty]
     resourceEntity Entity
-    systems []SystemTicker
+    systems []*scheduledSystem
+    systemStages [][]*scheduledSystem
+    tickMode TickMode
+    // changeTick is stamped on writes to sets tracking changes, each scheduled
+    // system holds the tick it last ran at
+    changeTick uint64
+    commands *CommandBuffer
+    migrations map[uint32][]MigrationFunc
     eventBus *mint.Emitter
 
     // Tags
 `)
-//line generator/world_go.qtpl:23
+//line generator/world_go.qtpl:30
 	for _, c := range data.Components {
-//line generator/world_go.qtpl:24
+//line generator/world_go.qtpl:31
 		if c.IsTag {
-//line generator/world_go.qtpl:24
+//line generator/world_go.qtpl:31
 			qw422016.N().S(`    `)
-//line generator/world_go.qtpl:25
+//line generator/world_go.qtpl:32
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/world_go.qtpl:25
+//line generator/world_go.qtpl:32
 			qw422016.N().S(`Tags *SparseSet[empty]
 `)
-//line generator/world_go.qtpl:26
+//line generator/world_go.qtpl:33
 		}
-//line generator/world_go.qtpl:27
+//line generator/world_go.qtpl:34
 	}
-//line generator/world_go.qtpl:27
+//line generator/world_go.qtpl:34
 	qw422016.N().S(`
     // Components
 `)
-//line generator/world_go.qtpl:30
+//line generator/world_go.qtpl:37
 	for _, c := range data.Components {
-//line generator/world_go.qtpl:31
+//line generator/world_go.qtpl:38
 		if !c.IsTag && !c.IsRelationship {
-//line generator/world_go.qtpl:31
+//line generator/world_go.qtpl:38
 			qw422016.N().S(`    `)
-//line generator/world_go.qtpl:32
+//line generator/world_go.qtpl:39
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/world_go.qtpl:32
+//line generator/world_go.qtpl:39
 			qw422016.N().S(`Components *SparseSet[`)
-//line generator/world_go.qtpl:32
+//line generator/world_go.qtpl:39
 			qw422016.E().S(c.Name.Singular.Pascal)
-//line generator/world_go.qtpl:32
+//line generator/world_go.qtpl:39
 			qw422016.N().S(`Component]
 `)
-//line generator/world_go.qtpl:33
+//line generator/world_go.qtpl:40
 		}
-//line generator/world_go.qtpl:34
+//line generator/world_go.qtpl:41
 	}
-//line generator/world_go.qtpl:34
+//line generator/world_go.qtpl:41
 	qw422016.N().S(`
     // Relationships
 `)
-//line generator/world_go.qtpl:37
+//line generator/world_go.qtpl:44
 	for _, c := range data.Components {
-//line generator/world_go.qtpl:38
+//line generator/world_go.qtpl:45
 		if c.IsRelationship {
-//line generator/world_go.qtpl:38
+//line generator/world_go.qtpl:45
 			qw422016.N().S(`    `)
-//line generator/world_go.qtpl:39
+//line generator/world_go.qtpl:46
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/world_go.qtpl:39
+//line generator/world_go.qtpl:46
 			qw422016.N().S(`Relationships *`)
-//line generator/world_go.qtpl:39
+//line generator/world_go.qtpl:46
 			qw422016.E().S(c.Name.Singular.Pascal)
-//line generator/world_go.qtpl:39
+//line generator/world_go.qtpl:46
 			qw422016.N().S(`Relationship
 `)
-//line generator/world_go.qtpl:40
+//line generator/world_go.qtpl:47
 		}
-//line generator/world_go.qtpl:41
+//line generator/world_go.qtpl:48
 	}
-//line generator/world_go.qtpl:41
+//line generator/world_go.qtpl:48
+	qw422016.N().S(`
+    // Owning groups, members are packed at the front of every owned set
+`)
+//line generator/world_go.qtpl:51
+	for _, q := range data.Queries {
+//line generator/world_go.qtpl:52
+		if q.IsOwningGroup {
+//line generator/world_go.qtpl:52
+			qw422016.N().S(`    `)
+//line generator/world_go.qtpl:53
+			qw422016.E().S(q.Name.Singular.Camel)
+//line generator/world_go.qtpl:53
+			qw422016.N().S(`GroupLen int
+`)
+//line generator/world_go.qtpl:54
+		}
+//line generator/world_go.qtpl:55
+	}
+//line generator/world_go.qtpl:55
+	qw422016.N().S(`
+    // Query observers, membership is only checked on writes while non zero
+`)
+//line generator/world_go.qtpl:58
+	for _, q := range data.Queries {
+//line generator/world_go.qtpl:59
+		if q.IsObservable() {
+//line generator/world_go.qtpl:59
+			qw422016.N().S(`    `)
+//line generator/world_go.qtpl:60
+			qw422016.E().S(q.Name.Singular.Camel)
+//line generator/world_go.qtpl:60
+			qw422016.N().S(`Observers int
+`)
+//line generator/world_go.qtpl:61
+		}
+//line generator/world_go.qtpl:62
+	}
+//line generator/world_go.qtpl:62
 	qw422016.N().S(`}
 
 func NewWorld() *World{
@@ -117,207 +162,224 @@ func NewWorld() *World{
         livingEntities: NewSparseSet[empty](),
         freeEntities: NewSparseSet[empty](),
         eventBus: &mint.Emitter{},
+        commands: NewCommandBuffer(),
+        changeTick: 1,
 
         // Initialize tags
 `)
-//line generator/world_go.qtpl:52
+//line generator/world_go.qtpl:75
 	for _, c := range data.Components {
-//line generator/world_go.qtpl:53
+//line generator/world_go.qtpl:76
 		if c.IsTag {
-//line generator/world_go.qtpl:53
+//line generator/world_go.qtpl:76
 			qw422016.N().S(`                `)
-//line generator/world_go.qtpl:54
+//line generator/world_go.qtpl:77
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/world_go.qtpl:54
+//line generator/world_go.qtpl:77
 			qw422016.N().S(`Tags : NewSparseSet[empty](),
 `)
-//line generator/world_go.qtpl:55
+//line generator/world_go.qtpl:78
 		}
-//line generator/world_go.qtpl:56
+//line generator/world_go.qtpl:79
 	}
-//line generator/world_go.qtpl:56
+//line generator/world_go.qtpl:79
 	qw422016.N().S(`
 
         // Initialize components
 `)
-//line generator/world_go.qtpl:60
+//line generator/world_go.qtpl:83
 	for _, c := range data.Components {
-//line generator/world_go.qtpl:61
+//line generator/world_go.qtpl:84
 		if !c.IsTag && !c.IsRelationship {
-//line generator/world_go.qtpl:61
+//line generator/world_go.qtpl:84
 			qw422016.N().S(`                `)
-//line generator/world_go.qtpl:62
+//line generator/world_go.qtpl:85
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/world_go.qtpl:62
+//line generator/world_go.qtpl:85
 			qw422016.N().S(`Components: NewSparseSet[`)
-//line generator/world_go.qtpl:62
+//line generator/world_go.qtpl:85
 			qw422016.E().S(c.Name.Singular.Pascal)
-//line generator/world_go.qtpl:62
+//line generator/world_go.qtpl:85
 			qw422016.N().S(`Component](),
 `)
-//line generator/world_go.qtpl:63
+//line generator/world_go.qtpl:86
 		}
-//line generator/world_go.qtpl:64
+//line generator/world_go.qtpl:87
 	}
-//line generator/world_go.qtpl:64
+//line generator/world_go.qtpl:87
 	qw422016.N().S(`
         // Initialize relationships
 `)
-//line generator/world_go.qtpl:67
+//line generator/world_go.qtpl:90
 	for _, c := range data.Components {
-//line generator/world_go.qtpl:68
+//line generator/world_go.qtpl:91
 		if c.IsRelationship {
-//line generator/world_go.qtpl:68
+//line generator/world_go.qtpl:91
 			qw422016.N().S(`                `)
-//line generator/world_go.qtpl:69
+//line generator/world_go.qtpl:92
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/world_go.qtpl:69
+//line generator/world_go.qtpl:92
 			qw422016.N().S(`Relationships: New`)
-//line generator/world_go.qtpl:69
+//line generator/world_go.qtpl:92
 			qw422016.E().S(c.Name.Singular.Pascal)
-//line generator/world_go.qtpl:69
+//line generator/world_go.qtpl:92
 			qw422016.N().S(`Relationship(),
 `)
-//line generator/world_go.qtpl:70
+//line generator/world_go.qtpl:93
 		}
-//line generator/world_go.qtpl:71
+//line generator/world_go.qtpl:94
 	}
-//line generator/world_go.qtpl:71
+//line generator/world_go.qtpl:94
 	qw422016.N().S(`    }
 
+    // Track changes
+`)
+//line generator/world_go.qtpl:98
+	for _, c := range data.Components {
+//line generator/world_go.qtpl:99
+		if c.ShouldTrackChanges {
+//line generator/world_go.qtpl:99
+			qw422016.N().S(`    w.`)
+//line generator/world_go.qtpl:100
+			qw422016.E().S(c.Name.Singular.Camel)
+//line generator/world_go.qtpl:100
+			qw422016.N().S(`Components.tick = &w.changeTick
+`)
+//line generator/world_go.qtpl:101
+		}
+//line generator/world_go.qtpl:102
+	}
+//line generator/world_go.qtpl:102
+	qw422016.N().S(`
     w.Reset()
 
     return w
 }
 
+// ChangeTick is stamped on every write to components tracking changes, Tick
+// advances it before each system
+func (w *World) ChangeTick() uint64 {
+    return w.changeTick
+}
+
+// AdvanceChangeTick starts a new change tick and returns the previous one, code
+// polling outside of systems passes it to Changed and Added filters later to
+// see what was written after this call
+func (w *World) AdvanceChangeTick() uint64 {
+    w.changeTick++
+    return w.changeTick - 1
+}
+
 func (w *World) Reset(){
     w.nextEntityID = 0
     w.livingEntities.Clear()
     w.freeEntities.Clear()
+    w.commands.Clear()
     w.resourceEntity = w.NextEntity()
 
     // Reset tags
 `)
-//line generator/world_go.qtpl:86
+//line generator/world_go.qtpl:131
 	for _, c := range data.Components {
-//line generator/world_go.qtpl:87
+//line generator/world_go.qtpl:132
 		if c.IsTag {
-//line generator/world_go.qtpl:87
+//line generator/world_go.qtpl:132
 			qw422016.N().S(`            w.`)
-//line generator/world_go.qtpl:88
+//line generator/world_go.qtpl:133
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/world_go.qtpl:88
+//line generator/world_go.qtpl:133
 			qw422016.N().S(`Tags.Clear()
 `)
-//line generator/world_go.qtpl:89
+//line generator/world_go.qtpl:134
 		}
-//line generator/world_go.qtpl:90
+//line generator/world_go.qtpl:135
 	}
-//line generator/world_go.qtpl:90
+//line generator/world_go.qtpl:135
 	qw422016.N().S(`
     // Reset components
 `)
-//line generator/world_go.qtpl:93
+//line generator/world_go.qtpl:138
 	for _, c := range data.Components {
-//line generator/world_go.qtpl:94
+//line generator/world_go.qtpl:139
 		if !c.IsTag && !c.IsRelationship {
-//line generator/world_go.qtpl:94
+//line generator/world_go.qtpl:139
 			qw422016.N().S(`            w.`)
-//line generator/world_go.qtpl:95
+//line generator/world_go.qtpl:140
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/world_go.qtpl:95
+//line generator/world_go.qtpl:140
 			qw422016.N().S(`Components.Clear()
 `)
-//line generator/world_go.qtpl:96
+//line generator/world_go.qtpl:141
 		}
-//line generator/world_go.qtpl:97
+//line generator/world_go.qtpl:142
 	}
-//line generator/world_go.qtpl:97
+//line generator/world_go.qtpl:142
 	qw422016.N().S(`
     // Reset relationships
 `)
-//line generator/world_go.qtpl:100
+//line generator/world_go.qtpl:145
 	for _, c := range data.Components {
-//line generator/world_go.qtpl:101
+//line generator/world_go.qtpl:146
 		if c.IsRelationship {
-//line generator/world_go.qtpl:101
+//line generator/world_go.qtpl:146
 			qw422016.N().S(`            w.`)
-//line generator/world_go.qtpl:102
+//line generator/world_go.qtpl:147
 			qw422016.E().S(c.Name.Singular.Camel)
-//line generator/world_go.qtpl:102
+//line generator/world_go.qtpl:147
 			qw422016.N().S(`Relationships.Clear()
 `)
-//line generator/world_go.qtpl:103
+//line generator/world_go.qtpl:148
+		}
+//line generator/world_go.qtpl:149
+	}
+//line generator/world_go.qtpl:149
+	qw422016.N().S(`
+    // Reset owning groups
+`)
+//line generator/world_go.qtpl:152
+	for _, q := range data.Queries {
+//line generator/world_go.qtpl:153
+		if q.IsOwningGroup {
+//line generator/world_go.qtpl:153
+			qw422016.N().S(`            w.`)
+//line generator/world_go.qtpl:154
+			qw422016.E().S(q.Name.Singular.Camel)
+//line generator/world_go.qtpl:154
+			qw422016.N().S(`GroupLen = 0
+`)
+//line generator/world_go.qtpl:155
 		}
-//line generator/world_go.qtpl:104
+//line generator/world_go.qtpl:156
 	}
-//line generator/world_go.qtpl:104
+//line generator/world_go.qtpl:156
 	qw422016.N().S(`}
 
-func(w *World)  AddSystems(ctx context.Context, systems ...System) error{
-    for _, s := range systems{
-        if err := s.Initialize(ctx, w); err != nil{
-            return fmt.Errorf("failed to initialize system: %w", err)
-        }
-
-        sysTicker, ok := s.(SystemTicker)
-        if !ok{
-            continue
-        }
-
-        w.systems = append(w.systems, sysTicker)
-    }
-
-    return nil
-}
-
-func (w *World) Tick(ctx context.Context) error{
-    for _, s := range w.systems{
-        if err := s.Tick(ctx, w); err != nil{
-            return err
-        }
-    }
-    return nil
-}
-
-type ReliedOnIter func(reliedOn System) bool
-
-type System interface{
-    Initialize(ctx context.Context, w *World) error
-    ReliesOn() ReliedOnIter
-}
-
-type SystemTicker interface{
-    System
-    Tick(ctx context.Context, w *World) error
-}
-
 `)
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 }
 
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 func writeworldTemplate(qq422016 qtio422016.Writer, data *ecsTmplData) {
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 	streamworldTemplate(qw422016, data)
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 	qt422016.ReleaseWriter(qw422016)
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 }
 
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 func worldTemplate(data *ecsTmplData) string {
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 	qb422016 := qt422016.AcquireByteBuffer()
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 	writeworldTemplate(qb422016, data)
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 	qs422016 := string(qb422016.B)
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 	qt422016.ReleaseByteBuffer(qb422016)
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 	return qs422016
-//line generator/world_go.qtpl:145
+//line generator/world_go.qtpl:159
 }
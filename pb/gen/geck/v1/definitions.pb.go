@@ -20,6 +20,124 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// What happens to the sources of a relationship pair when its target is
+// destroyed. Unspecified behaves like REMOVE_PAIR.
+type ComponentDefinition_OnTargetDeleted int32
+
+const (
+	ComponentDefinition_ON_TARGET_DELETED_UNSPECIFIED ComponentDefinition_OnTargetDeleted = 0
+	ComponentDefinition_ON_TARGET_DELETED_REMOVE_PAIR ComponentDefinition_OnTargetDeleted = 1
+	ComponentDefinition_ON_TARGET_DELETED_DELETE      ComponentDefinition_OnTargetDeleted = 2
+	ComponentDefinition_ON_TARGET_DELETED_PANIC       ComponentDefinition_OnTargetDeleted = 3
+)
+
+// Enum value maps for ComponentDefinition_OnTargetDeleted.
+var (
+	ComponentDefinition_OnTargetDeleted_name = map[int32]string{
+		0: "ON_TARGET_DELETED_UNSPECIFIED",
+		1: "ON_TARGET_DELETED_REMOVE_PAIR",
+		2: "ON_TARGET_DELETED_DELETE",
+		3: "ON_TARGET_DELETED_PANIC",
+	}
+	ComponentDefinition_OnTargetDeleted_value = map[string]int32{
+		"ON_TARGET_DELETED_UNSPECIFIED": 0,
+		"ON_TARGET_DELETED_REMOVE_PAIR": 1,
+		"ON_TARGET_DELETED_DELETE":      2,
+		"ON_TARGET_DELETED_PANIC":       3,
+	}
+)
+
+func (x ComponentDefinition_OnTargetDeleted) Enum() *ComponentDefinition_OnTargetDeleted {
+	p := new(ComponentDefinition_OnTargetDeleted)
+	*p = x
+	return p
+}
+
+func (x ComponentDefinition_OnTargetDeleted) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ComponentDefinition_OnTargetDeleted) Descriptor() protoreflect.EnumDescriptor {
+	return file_geck_v1_definitions_proto_enumTypes[0].Descriptor()
+}
+
+func (ComponentDefinition_OnTargetDeleted) Type() protoreflect.EnumType {
+	return &file_geck_v1_definitions_proto_enumTypes[0]
+}
+
+func (x ComponentDefinition_OnTargetDeleted) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ComponentDefinition_OnTargetDeleted.Descriptor instead.
+func (ComponentDefinition_OnTargetDeleted) EnumDescriptor() ([]byte, []int) {
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{6, 0}
+}
+
+// How a term takes part in matching. Unspecified behaves like WITH.
+type QueryDefinition_Operator int32
+
+const (
+	QueryDefinition_OPERATOR_UNSPECIFIED QueryDefinition_Operator = 0
+	QueryDefinition_OPERATOR_WITH        QueryDefinition_Operator = 1
+	QueryDefinition_OPERATOR_WITHOUT     QueryDefinition_Operator = 2
+	QueryDefinition_OPERATOR_OPTIONAL    QueryDefinition_Operator = 3
+	QueryDefinition_OPERATOR_OR          QueryDefinition_Operator = 4
+	// Like WITH but only matches components written or added after the change
+	// tick passed to the query, the component tracks changes when used.
+	QueryDefinition_OPERATOR_CHANGED QueryDefinition_Operator = 5
+	QueryDefinition_OPERATOR_ADDED   QueryDefinition_Operator = 6
+)
+
+// Enum value maps for QueryDefinition_Operator.
+var (
+	QueryDefinition_Operator_name = map[int32]string{
+		0: "OPERATOR_UNSPECIFIED",
+		1: "OPERATOR_WITH",
+		2: "OPERATOR_WITHOUT",
+		3: "OPERATOR_OPTIONAL",
+		4: "OPERATOR_OR",
+		5: "OPERATOR_CHANGED",
+		6: "OPERATOR_ADDED",
+	}
+	QueryDefinition_Operator_value = map[string]int32{
+		"OPERATOR_UNSPECIFIED": 0,
+		"OPERATOR_WITH":        1,
+		"OPERATOR_WITHOUT":     2,
+		"OPERATOR_OPTIONAL":    3,
+		"OPERATOR_OR":          4,
+		"OPERATOR_CHANGED":     5,
+		"OPERATOR_ADDED":       6,
+	}
+)
+
+func (x QueryDefinition_Operator) Enum() *QueryDefinition_Operator {
+	p := new(QueryDefinition_Operator)
+	*p = x
+	return p
+}
+
+func (x QueryDefinition_Operator) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (QueryDefinition_Operator) Descriptor() protoreflect.EnumDescriptor {
+	return file_geck_v1_definitions_proto_enumTypes[1].Descriptor()
+}
+
+func (QueryDefinition_Operator) Type() protoreflect.EnumType {
+	return &file_geck_v1_definitions_proto_enumTypes[1]
+}
+
+func (x QueryDefinition_Operator) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use QueryDefinition_Operator.Descriptor instead.
+func (QueryDefinition_Operator) EnumDescriptor() ([]byte, []int) {
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{8, 0}
+}
+
 type Enum struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -89,6 +207,192 @@ func (x *Enum) GetIsBitmask() bool {
 	return false
 }
 
+// Values of github.com/delaneyj/geck/components/mathx types, fields holding
+// them are generic over float32.
+type Vector3 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X float32 `protobuf:"fixed32,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y float32 `protobuf:"fixed32,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z float32 `protobuf:"fixed32,3,opt,name=z,proto3" json:"z,omitempty"`
+}
+
+func (x *Vector3) Reset() {
+	*x = Vector3{}
+	mi := &file_geck_v1_definitions_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vector3) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vector3) ProtoMessage() {}
+
+func (x *Vector3) ProtoReflect() protoreflect.Message {
+	mi := &file_geck_v1_definitions_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vector3.ProtoReflect.Descriptor instead.
+func (*Vector3) Descriptor() ([]byte, []int) {
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Vector3) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Vector3) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Vector3) GetZ() float32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+// An all zero quaternion resets to the identity.
+type Quaternion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X float32 `protobuf:"fixed32,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y float32 `protobuf:"fixed32,2,opt,name=y,proto3" json:"y,omitempty"`
+	Z float32 `protobuf:"fixed32,3,opt,name=z,proto3" json:"z,omitempty"`
+	W float32 `protobuf:"fixed32,4,opt,name=w,proto3" json:"w,omitempty"`
+}
+
+func (x *Quaternion) Reset() {
+	*x = Quaternion{}
+	mi := &file_geck_v1_definitions_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quaternion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quaternion) ProtoMessage() {}
+
+func (x *Quaternion) ProtoReflect() protoreflect.Message {
+	mi := &file_geck_v1_definitions_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quaternion.ProtoReflect.Descriptor instead.
+func (*Quaternion) Descriptor() ([]byte, []int) {
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Quaternion) GetX() float32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Quaternion) GetY() float32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Quaternion) GetZ() float32 {
+	if x != nil {
+		return x.Z
+	}
+	return 0
+}
+
+func (x *Quaternion) GetW() float32 {
+	if x != nil {
+		return x.W
+	}
+	return 0
+}
+
+type Box3 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min *Vector3 `protobuf:"bytes,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max *Vector3 `protobuf:"bytes,2,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *Box3) Reset() {
+	*x = Box3{}
+	mi := &file_geck_v1_definitions_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Box3) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Box3) ProtoMessage() {}
+
+func (x *Box3) ProtoReflect() protoreflect.Message {
+	mi := &file_geck_v1_definitions_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Box3.ProtoReflect.Descriptor instead.
+func (*Box3) Descriptor() ([]byte, []int) {
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Box3) GetMin() *Vector3 {
+	if x != nil {
+		return x.Min
+	}
+	return nil
+}
+
+func (x *Box3) GetMax() *Vector3 {
+	if x != nil {
+		return x.Max
+	}
+	return nil
+}
+
 type FieldDefinition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -115,12 +419,16 @@ type FieldDefinition struct {
 	//	*FieldDefinition_Bin
 	//	*FieldDefinition_Entity
 	//	*FieldDefinition_Enum
+	//	*FieldDefinition_Struct
+	//	*FieldDefinition_Vector3
+	//	*FieldDefinition_Quaternion
+	//	*FieldDefinition_Box3
 	ResetValue isFieldDefinition_ResetValue `protobuf_oneof:"reset_value"`
 }
 
 func (x *FieldDefinition) Reset() {
 	*x = FieldDefinition{}
-	mi := &file_geck_v1_definitions_proto_msgTypes[1]
+	mi := &file_geck_v1_definitions_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -132,7 +440,7 @@ func (x *FieldDefinition) String() string {
 func (*FieldDefinition) ProtoMessage() {}
 
 func (x *FieldDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_geck_v1_definitions_proto_msgTypes[1]
+	mi := &file_geck_v1_definitions_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -145,7 +453,7 @@ func (x *FieldDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FieldDefinition.ProtoReflect.Descriptor instead.
 func (*FieldDefinition) Descriptor() ([]byte, []int) {
-	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{1}
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *FieldDefinition) GetName() string {
@@ -288,6 +596,34 @@ func (x *FieldDefinition) GetEnum() *Enum_Value {
 	return nil
 }
 
+func (x *FieldDefinition) GetStruct() string {
+	if x, ok := x.GetResetValue().(*FieldDefinition_Struct); ok {
+		return x.Struct
+	}
+	return ""
+}
+
+func (x *FieldDefinition) GetVector3() *Vector3 {
+	if x, ok := x.GetResetValue().(*FieldDefinition_Vector3); ok {
+		return x.Vector3
+	}
+	return nil
+}
+
+func (x *FieldDefinition) GetQuaternion() *Quaternion {
+	if x, ok := x.GetResetValue().(*FieldDefinition_Quaternion); ok {
+		return x.Quaternion
+	}
+	return nil
+}
+
+func (x *FieldDefinition) GetBox3() *Box3 {
+	if x, ok := x.GetResetValue().(*FieldDefinition_Box3); ok {
+		return x.Box3
+	}
+	return nil
+}
+
 type isFieldDefinition_ResetValue interface {
 	isFieldDefinition_ResetValue()
 }
@@ -348,6 +684,24 @@ type FieldDefinition_Enum struct {
 	Enum *Enum_Value `protobuf:"bytes,20,opt,name=enum,proto3,oneof"`
 }
 
+type FieldDefinition_Struct struct {
+	// Name of a struct declared in any bundle, reset to the reset values of
+	// its own fields.
+	Struct string `protobuf:"bytes,21,opt,name=struct,proto3,oneof"`
+}
+
+type FieldDefinition_Vector3 struct {
+	Vector3 *Vector3 `protobuf:"bytes,22,opt,name=vector3,proto3,oneof"`
+}
+
+type FieldDefinition_Quaternion struct {
+	Quaternion *Quaternion `protobuf:"bytes,23,opt,name=quaternion,proto3,oneof"`
+}
+
+type FieldDefinition_Box3 struct {
+	Box3 *Box3 `protobuf:"bytes,24,opt,name=box3,proto3,oneof"`
+}
+
 func (*FieldDefinition_U8) isFieldDefinition_ResetValue() {}
 
 func (*FieldDefinition_U16) isFieldDefinition_ResetValue() {}
@@ -376,25 +730,102 @@ func (*FieldDefinition_Entity) isFieldDefinition_ResetValue() {}
 
 func (*FieldDefinition_Enum) isFieldDefinition_ResetValue() {}
 
+func (*FieldDefinition_Struct) isFieldDefinition_ResetValue() {}
+
+func (*FieldDefinition_Vector3) isFieldDefinition_ResetValue() {}
+
+func (*FieldDefinition_Quaternion) isFieldDefinition_ResetValue() {}
+
+func (*FieldDefinition_Box3) isFieldDefinition_ResetValue() {}
+
+// A plain value type fields can hold, generated as a Go struct. Its values are
+// copied and compared whole, so its fields cannot hold entities, bytes or
+// multiple values. Snapshots store its fields by position, changing them needs
+// a version bump.
+type StructDefinition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string             `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Fields      []*FieldDefinition `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *StructDefinition) Reset() {
+	*x = StructDefinition{}
+	mi := &file_geck_v1_definitions_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StructDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StructDefinition) ProtoMessage() {}
+
+func (x *StructDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_geck_v1_definitions_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StructDefinition.ProtoReflect.Descriptor instead.
+func (*StructDefinition) Descriptor() ([]byte, []int) {
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StructDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StructDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StructDefinition) GetFields() []*FieldDefinition {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
 type ComponentDefinition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name                       string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description                string             `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	IsDeprecated               bool               `protobuf:"varint,3,opt,name=is_deprecated,json=isDeprecated,proto3" json:"is_deprecated,omitempty"`
-	ShouldNotInflect           bool               `protobuf:"varint,4,opt,name=should_not_inflect,json=shouldNotInflect,proto3" json:"should_not_inflect,omitempty"`
-	ShouldGenerateAddedEvent   bool               `protobuf:"varint,5,opt,name=should_generate_added_event,json=shouldGenerateAddedEvent,proto3" json:"should_generate_added_event,omitempty"`
-	ShouldGenerateRemovedEvent bool               `protobuf:"varint,6,opt,name=should_generate_removed_event,json=shouldGenerateRemovedEvent,proto3" json:"should_generate_removed_event,omitempty"`
-	ShouldGenerateChangedEvent bool               `protobuf:"varint,7,opt,name=should_generate_changed_event,json=shouldGenerateChangedEvent,proto3" json:"should_generate_changed_event,omitempty"`
-	Fields                     []*FieldDefinition `protobuf:"bytes,8,rep,name=fields,proto3" json:"fields,omitempty"`
-	IsRelationship             bool               `protobuf:"varint,9,opt,name=is_relationship,json=isRelationship,proto3" json:"is_relationship,omitempty"`
+	Name                       string                              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description                string                              `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	IsDeprecated               bool                                `protobuf:"varint,3,opt,name=is_deprecated,json=isDeprecated,proto3" json:"is_deprecated,omitempty"`
+	ShouldNotInflect           bool                                `protobuf:"varint,4,opt,name=should_not_inflect,json=shouldNotInflect,proto3" json:"should_not_inflect,omitempty"`
+	ShouldGenerateAddedEvent   bool                                `protobuf:"varint,5,opt,name=should_generate_added_event,json=shouldGenerateAddedEvent,proto3" json:"should_generate_added_event,omitempty"`
+	ShouldGenerateRemovedEvent bool                                `protobuf:"varint,6,opt,name=should_generate_removed_event,json=shouldGenerateRemovedEvent,proto3" json:"should_generate_removed_event,omitempty"`
+	ShouldGenerateChangedEvent bool                                `protobuf:"varint,7,opt,name=should_generate_changed_event,json=shouldGenerateChangedEvent,proto3" json:"should_generate_changed_event,omitempty"`
+	Fields                     []*FieldDefinition                  `protobuf:"bytes,8,rep,name=fields,proto3" json:"fields,omitempty"`
+	IsRelationship             bool                                `protobuf:"varint,9,opt,name=is_relationship,json=isRelationship,proto3" json:"is_relationship,omitempty"`
+	OnTargetDeleted            ComponentDefinition_OnTargetDeleted `protobuf:"varint,10,opt,name=on_target_deleted,json=onTargetDeleted,proto3,enum=geck.v1.ComponentDefinition_OnTargetDeleted" json:"on_target_deleted,omitempty"`
+	// Stamps every write with the world's change tick so it can be polled, set
+	// implicitly by queries with CHANGED or ADDED terms.
+	ShouldTrackChanges bool `protobuf:"varint,11,opt,name=should_track_changes,json=shouldTrackChanges,proto3" json:"should_track_changes,omitempty"`
 }
 
 func (x *ComponentDefinition) Reset() {
 	*x = ComponentDefinition{}
-	mi := &file_geck_v1_definitions_proto_msgTypes[2]
+	mi := &file_geck_v1_definitions_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -406,7 +837,7 @@ func (x *ComponentDefinition) String() string {
 func (*ComponentDefinition) ProtoMessage() {}
 
 func (x *ComponentDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_geck_v1_definitions_proto_msgTypes[2]
+	mi := &file_geck_v1_definitions_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -419,7 +850,7 @@ func (x *ComponentDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ComponentDefinition.ProtoReflect.Descriptor instead.
 func (*ComponentDefinition) Descriptor() ([]byte, []int) {
-	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{2}
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ComponentDefinition) GetName() string {
@@ -485,6 +916,20 @@ func (x *ComponentDefinition) GetIsRelationship() bool {
 	return false
 }
 
+func (x *ComponentDefinition) GetOnTargetDeleted() ComponentDefinition_OnTargetDeleted {
+	if x != nil {
+		return x.OnTargetDeleted
+	}
+	return ComponentDefinition_ON_TARGET_DELETED_UNSPECIFIED
+}
+
+func (x *ComponentDefinition) GetShouldTrackChanges() bool {
+	if x != nil {
+		return x.ShouldTrackChanges
+	}
+	return false
+}
+
 type BundleDefinition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -494,11 +939,12 @@ type BundleDefinition struct {
 	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
 	Enums       []*Enum                `protobuf:"bytes,3,rep,name=enums,proto3" json:"enums,omitempty"`
 	Components  []*ComponentDefinition `protobuf:"bytes,4,rep,name=components,proto3" json:"components,omitempty"`
+	Structs     []*StructDefinition    `protobuf:"bytes,5,rep,name=structs,proto3" json:"structs,omitempty"`
 }
 
 func (x *BundleDefinition) Reset() {
 	*x = BundleDefinition{}
-	mi := &file_geck_v1_definitions_proto_msgTypes[3]
+	mi := &file_geck_v1_definitions_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -510,7 +956,7 @@ func (x *BundleDefinition) String() string {
 func (*BundleDefinition) ProtoMessage() {}
 
 func (x *BundleDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_geck_v1_definitions_proto_msgTypes[3]
+	mi := &file_geck_v1_definitions_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -523,7 +969,7 @@ func (x *BundleDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BundleDefinition.ProtoReflect.Descriptor instead.
 func (*BundleDefinition) Descriptor() ([]byte, []int) {
-	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{3}
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *BundleDefinition) GetName() string {
@@ -554,6 +1000,15 @@ func (x *BundleDefinition) GetComponents() []*ComponentDefinition {
 	return nil
 }
 
+func (x *BundleDefinition) GetStructs() []*StructDefinition {
+	if x != nil {
+		return x.Structs
+	}
+	return nil
+}
+
+// Queries without joins or arguments also get OnQuery<Name>Enter and
+// OnQuery<Name>Exit events, fired when writes change whether an entity matches.
 type QueryDefinition struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -561,11 +1016,14 @@ type QueryDefinition struct {
 
 	Alias   string                            `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
 	Entries []*QueryDefinition_ComponentOrTag `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Keep the member sets sorted so matching entities are a contiguous prefix
+	// of every dense array. A set can only be owned by one query.
+	IsOwningGroup bool `protobuf:"varint,3,opt,name=is_owning_group,json=isOwningGroup,proto3" json:"is_owning_group,omitempty"`
 }
 
 func (x *QueryDefinition) Reset() {
 	*x = QueryDefinition{}
-	mi := &file_geck_v1_definitions_proto_msgTypes[4]
+	mi := &file_geck_v1_definitions_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -577,7 +1035,7 @@ func (x *QueryDefinition) String() string {
 func (*QueryDefinition) ProtoMessage() {}
 
 func (x *QueryDefinition) ProtoReflect() protoreflect.Message {
-	mi := &file_geck_v1_definitions_proto_msgTypes[4]
+	mi := &file_geck_v1_definitions_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,7 +1048,7 @@ func (x *QueryDefinition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryDefinition.ProtoReflect.Descriptor instead.
 func (*QueryDefinition) Descriptor() ([]byte, []int) {
-	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{4}
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *QueryDefinition) GetAlias() string {
@@ -607,6 +1065,13 @@ func (x *QueryDefinition) GetEntries() []*QueryDefinition_ComponentOrTag {
 	return nil
 }
 
+func (x *QueryDefinition) GetIsOwningGroup() bool {
+	if x != nil {
+		return x.IsOwningGroup
+	}
+	return false
+}
+
 type GeneratorOptions struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -617,11 +1082,15 @@ type GeneratorOptions struct {
 	Version     uint32              `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
 	Bundles     []*BundleDefinition `protobuf:"bytes,4,rep,name=bundles,proto3" json:"bundles,omitempty"`
 	Queries     []*QueryDefinition  `protobuf:"bytes,5,rep,name=queries,proto3" json:"queries,omitempty"`
+	// Commands run in order inside the folder once every file is written, split
+	// on spaces and run without a shell. The first failing command fails the
+	// generation. None run by default, output is formatted in process.
+	PostGenerateCommands []string `protobuf:"bytes,6,rep,name=post_generate_commands,json=postGenerateCommands,proto3" json:"post_generate_commands,omitempty"`
 }
 
 func (x *GeneratorOptions) Reset() {
 	*x = GeneratorOptions{}
-	mi := &file_geck_v1_definitions_proto_msgTypes[5]
+	mi := &file_geck_v1_definitions_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -633,7 +1102,7 @@ func (x *GeneratorOptions) String() string {
 func (*GeneratorOptions) ProtoMessage() {}
 
 func (x *GeneratorOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_geck_v1_definitions_proto_msgTypes[5]
+	mi := &file_geck_v1_definitions_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -646,7 +1115,7 @@ func (x *GeneratorOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GeneratorOptions.ProtoReflect.Descriptor instead.
 func (*GeneratorOptions) Descriptor() ([]byte, []int) {
-	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{5}
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GeneratorOptions) GetPackageName() string {
@@ -684,6 +1153,13 @@ func (x *GeneratorOptions) GetQueries() []*QueryDefinition {
 	return nil
 }
 
+func (x *GeneratorOptions) GetPostGenerateCommands() []string {
+	if x != nil {
+		return x.PostGenerateCommands
+	}
+	return nil
+}
+
 type Enum_Value struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -695,7 +1171,7 @@ type Enum_Value struct {
 
 func (x *Enum_Value) Reset() {
 	*x = Enum_Value{}
-	mi := &file_geck_v1_definitions_proto_msgTypes[6]
+	mi := &file_geck_v1_definitions_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -707,7 +1183,7 @@ func (x *Enum_Value) String() string {
 func (*Enum_Value) ProtoMessage() {}
 
 func (x *Enum_Value) ProtoReflect() protoreflect.Message {
-	mi := &file_geck_v1_definitions_proto_msgTypes[6]
+	mi := &file_geck_v1_definitions_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -742,14 +1218,30 @@ type QueryDefinition_ComponentOrTag struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BundleName string `protobuf:"bytes,1,opt,name=bundle_name,json=bundleName,proto3" json:"bundle_name,omitempty"`
-	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	IsMutable  bool   `protobuf:"varint,3,opt,name=is_mutable,json=isMutable,proto3" json:"is_mutable,omitempty"`
+	BundleName string                   `protobuf:"bytes,1,opt,name=bundle_name,json=bundleName,proto3" json:"bundle_name,omitempty"`
+	Name       string                   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsMutable  bool                     `protobuf:"varint,3,opt,name=is_mutable,json=isMutable,proto3" json:"is_mutable,omitempty"`
+	Operator   QueryDefinition_Operator `protobuf:"varint,4,opt,name=operator,proto3,enum=geck.v1.QueryDefinition_Operator" json:"operator,omitempty"`
+	// OR terms sharing a group must match at least one of them, terms without
+	// a group name share a single unnamed group.
+	OrGroup string `protobuf:"bytes,5,opt,name=or_group,json=orGroup,proto3" json:"or_group,omitempty"`
+	// Name of an earlier term whose entity this term is matched against, it
+	// must be a component with a single entity field or a relationship. Empty
+	// matches the iterated entity.
+	Source string `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	// Relationship terms only. Empty matches any target and yields once per
+	// pair, "$" adds a target argument to the query and anything else names an
+	// earlier term whose entity must be the target.
+	Target string `protobuf:"bytes,7,opt,name=target,proto3" json:"target,omitempty"`
+	// Components only. Also matches entities inheriting the component from a
+	// prefab they are linked to with the builtin IsA relationship. The term
+	// cannot be mutable and the query needs another required term to iterate.
+	IsInherited bool `protobuf:"varint,8,opt,name=is_inherited,json=isInherited,proto3" json:"is_inherited,omitempty"`
 }
 
 func (x *QueryDefinition_ComponentOrTag) Reset() {
 	*x = QueryDefinition_ComponentOrTag{}
-	mi := &file_geck_v1_definitions_proto_msgTypes[7]
+	mi := &file_geck_v1_definitions_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -761,7 +1253,7 @@ func (x *QueryDefinition_ComponentOrTag) String() string {
 func (*QueryDefinition_ComponentOrTag) ProtoMessage() {}
 
 func (x *QueryDefinition_ComponentOrTag) ProtoReflect() protoreflect.Message {
-	mi := &file_geck_v1_definitions_proto_msgTypes[7]
+	mi := &file_geck_v1_definitions_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -774,7 +1266,7 @@ func (x *QueryDefinition_ComponentOrTag) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryDefinition_ComponentOrTag.ProtoReflect.Descriptor instead.
 func (*QueryDefinition_ComponentOrTag) Descriptor() ([]byte, []int) {
-	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{4, 0}
+	return file_geck_v1_definitions_proto_rawDescGZIP(), []int{8, 0}
 }
 
 func (x *QueryDefinition_ComponentOrTag) GetBundleName() string {
@@ -798,6 +1290,41 @@ func (x *QueryDefinition_ComponentOrTag) GetIsMutable() bool {
 	return false
 }
 
+func (x *QueryDefinition_ComponentOrTag) GetOperator() QueryDefinition_Operator {
+	if x != nil {
+		return x.Operator
+	}
+	return QueryDefinition_OPERATOR_UNSPECIFIED
+}
+
+func (x *QueryDefinition_ComponentOrTag) GetOrGroup() string {
+	if x != nil {
+		return x.OrGroup
+	}
+	return ""
+}
+
+func (x *QueryDefinition_ComponentOrTag) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *QueryDefinition_ComponentOrTag) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *QueryDefinition_ComponentOrTag) GetIsInherited() bool {
+	if x != nil {
+		return x.IsInherited
+	}
+	return false
+}
+
 var File_geck_v1_definitions_proto protoreflect.FileDescriptor
 
 var file_geck_v1_definitions_proto_rawDesc = []byte{
@@ -815,112 +1342,191 @@ var file_geck_v1_definitions_proto_rawDesc = []byte{
 	0x31, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05,
 	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x22, 0xe5, 0x03, 0x0a, 0x0f, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x65, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d,
-	0x69, 0x73, 0x5f, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x44, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65,
-	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x61, 0x73, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x4d, 0x75, 0x6c, 0x74,
-	0x69, 0x70, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x02, 0x75, 0x38,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x02, 0x75, 0x38, 0x12, 0x12, 0x0a, 0x03,
-	0x75, 0x31, 0x36, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x03, 0x75, 0x31, 0x36,
-	0x12, 0x12, 0x0a, 0x03, 0x75, 0x33, 0x32, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52,
-	0x03, 0x75, 0x33, 0x32, 0x12, 0x12, 0x0a, 0x03, 0x75, 0x36, 0x34, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x04, 0x48, 0x00, 0x52, 0x03, 0x75, 0x36, 0x34, 0x12, 0x10, 0x0a, 0x02, 0x69, 0x38, 0x18, 0x0b,
-	0x20, 0x01, 0x28, 0x11, 0x48, 0x00, 0x52, 0x02, 0x69, 0x38, 0x12, 0x12, 0x0a, 0x03, 0x69, 0x31,
-	0x36, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x11, 0x48, 0x00, 0x52, 0x03, 0x69, 0x31, 0x36, 0x12, 0x12,
-	0x0a, 0x03, 0x69, 0x33, 0x32, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x11, 0x48, 0x00, 0x52, 0x03, 0x69,
-	0x33, 0x32, 0x12, 0x12, 0x0a, 0x03, 0x69, 0x36, 0x34, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x12, 0x48,
-	0x00, 0x52, 0x03, 0x69, 0x36, 0x34, 0x12, 0x12, 0x0a, 0x03, 0x66, 0x33, 0x32, 0x18, 0x0f, 0x20,
-	0x01, 0x28, 0x02, 0x48, 0x00, 0x52, 0x03, 0x66, 0x33, 0x32, 0x12, 0x12, 0x0a, 0x03, 0x66, 0x36,
-	0x34, 0x18, 0x10, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x03, 0x66, 0x36, 0x34, 0x12, 0x12,
-	0x0a, 0x03, 0x74, 0x78, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x74,
-	0x78, 0x74, 0x12, 0x12, 0x0a, 0x03, 0x62, 0x69, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0c, 0x48,
-	0x00, 0x52, 0x03, 0x62, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
-	0x18, 0x13, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
-	0x12, 0x29, 0x0a, 0x04, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x2e, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x04, 0x65, 0x6e, 0x75, 0x6d, 0x42, 0x0d, 0x0a, 0x0b, 0x72,
-	0x65, 0x73, 0x65, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xbe, 0x03, 0x0a, 0x13, 0x43,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x64,
-	0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0c, 0x69, 0x73, 0x44, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2c, 0x0a,
-	0x12, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6c,
-	0x65, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x68, 0x6f, 0x75, 0x6c,
-	0x64, 0x4e, 0x6f, 0x74, 0x49, 0x6e, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x3d, 0x0a, 0x1b, 0x73,
-	0x68, 0x6f, 0x75, 0x6c, 0x64, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x61,
-	0x64, 0x64, 0x65, 0x64, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x18, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x41, 0x64, 0x64, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x1d, 0x73, 0x68,
-	0x6f, 0x75, 0x6c, 0x64, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65,
-	0x6d, 0x6f, 0x76, 0x65, 0x64, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x1a, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x41, 0x0a,
-	0x1d, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
-	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x47, 0x65, 0x6e, 0x65,
-	0x72, 0x61, 0x74, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x12, 0x30, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x18, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64,
-	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c,
-	0x64, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x73, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x68, 0x69, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x73, 0x52,
-	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x22, 0xab, 0x01, 0x0a, 0x10,
-	0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
-	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x05, 0x65, 0x6e, 0x75, 0x6d, 0x73, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
-	0x45, 0x6e, 0x75, 0x6d, 0x52, 0x05, 0x65, 0x6e, 0x75, 0x6d, 0x73, 0x12, 0x3c, 0x0a, 0x0a, 0x63,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1c, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
-	0x65, 0x6e, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xd0, 0x01, 0x0a, 0x0f, 0x51, 0x75,
-	0x65, 0x72, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
-	0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c,
-	0x69, 0x61, 0x73, 0x12, 0x41, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x54, 0x61, 0x67, 0x52, 0x07, 0x65,
-	0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x64, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
-	0x65, 0x6e, 0x74, 0x4f, 0x72, 0x54, 0x61, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x6e, 0x64,
-	0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62,
-	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x69, 0x73, 0x5f, 0x6d, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x09, 0x69, 0x73, 0x4d, 0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x22, 0xd9, 0x01, 0x0a,
-	0x10, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x70,
-	0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x64, 0x65,
-	0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
-	0x33, 0x0a, 0x07, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c,
-	0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x62, 0x75, 0x6e,
-	0x64, 0x6c, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18,
-	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x42, 0x8c, 0x01, 0x0a, 0x0b, 0x63, 0x6f, 0x6d,
-	0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x2e, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x65, 0x6c, 0x61, 0x6e, 0x65, 0x79,
-	0x6a, 0x2f, 0x67, 0x65, 0x63, 0x6b, 0x2f, 0x70, 0x62, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x65,
-	0x63, 0x6b, 0x2f, 0x76, 0x31, 0x3b, 0x67, 0x65, 0x63, 0x6b, 0x70, 0x62, 0xa2, 0x02, 0x03, 0x47,
-	0x58, 0x58, 0xaa, 0x02, 0x07, 0x47, 0x65, 0x63, 0x6b, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x07, 0x47,
-	0x65, 0x63, 0x6b, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x13, 0x47, 0x65, 0x63, 0x6b, 0x5c, 0x56, 0x31,
-	0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x08, 0x47,
-	0x65, 0x63, 0x6b, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x75, 0x65, 0x22, 0x33, 0x0a, 0x07, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x33, 0x12, 0x0c, 0x0a,
+	0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x7a, 0x22, 0x44, 0x0a, 0x0a, 0x51, 0x75, 0x61, 0x74, 0x65,
+	0x72, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x02,
+	0x52, 0x01, 0x78, 0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01,
+	0x79, 0x12, 0x0c, 0x0a, 0x01, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x7a, 0x12,
+	0x0c, 0x0a, 0x01, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x01, 0x77, 0x22, 0x4e, 0x0a,
+	0x04, 0x42, 0x6f, 0x78, 0x33, 0x12, 0x22, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x33, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x22, 0x0a, 0x03, 0x6d, 0x61, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x33, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x89, 0x05,
+	0x0a, 0x0f, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x64, 0x65,
+	0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x69, 0x73, 0x44, 0x65, 0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x68, 0x61, 0x73, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0b, 0x68, 0x61, 0x73, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05,
+	0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x02, 0x75, 0x38, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0d, 0x48, 0x00, 0x52, 0x02, 0x75, 0x38, 0x12, 0x12, 0x0a, 0x03, 0x75, 0x31, 0x36, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x03, 0x75, 0x31, 0x36, 0x12, 0x12, 0x0a, 0x03, 0x75,
+	0x33, 0x32, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x00, 0x52, 0x03, 0x75, 0x33, 0x32, 0x12,
+	0x12, 0x0a, 0x03, 0x75, 0x36, 0x34, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x03,
+	0x75, 0x36, 0x34, 0x12, 0x10, 0x0a, 0x02, 0x69, 0x38, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x11, 0x48,
+	0x00, 0x52, 0x02, 0x69, 0x38, 0x12, 0x12, 0x0a, 0x03, 0x69, 0x31, 0x36, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x11, 0x48, 0x00, 0x52, 0x03, 0x69, 0x31, 0x36, 0x12, 0x12, 0x0a, 0x03, 0x69, 0x33, 0x32,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x11, 0x48, 0x00, 0x52, 0x03, 0x69, 0x33, 0x32, 0x12, 0x12, 0x0a,
+	0x03, 0x69, 0x36, 0x34, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x12, 0x48, 0x00, 0x52, 0x03, 0x69, 0x36,
+	0x34, 0x12, 0x12, 0x0a, 0x03, 0x66, 0x33, 0x32, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x02, 0x48, 0x00,
+	0x52, 0x03, 0x66, 0x33, 0x32, 0x12, 0x12, 0x0a, 0x03, 0x66, 0x36, 0x34, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x00, 0x52, 0x03, 0x66, 0x36, 0x34, 0x12, 0x12, 0x0a, 0x03, 0x74, 0x78, 0x74,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x74, 0x78, 0x74, 0x12, 0x12, 0x0a,
+	0x03, 0x62, 0x69, 0x6e, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x03, 0x62, 0x69,
+	0x6e, 0x12, 0x18, 0x0a, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x0d, 0x48, 0x00, 0x52, 0x06, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x04, 0x65,
+	0x6e, 0x75, 0x6d, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x65, 0x63, 0x6b,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00,
+	0x52, 0x04, 0x65, 0x6e, 0x75, 0x6d, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x12, 0x2c, 0x0a, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x33, 0x18, 0x16, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x33, 0x48, 0x00, 0x52, 0x07, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x33, 0x12, 0x35,
+	0x0a, 0x0a, 0x71, 0x75, 0x61, 0x74, 0x65, 0x72, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x61,
+	0x74, 0x65, 0x72, 0x6e, 0x69, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x0a, 0x71, 0x75, 0x61, 0x74, 0x65,
+	0x72, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x04, 0x62, 0x6f, 0x78, 0x33, 0x18, 0x18, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6f,
+	0x78, 0x33, 0x48, 0x00, 0x52, 0x04, 0x62, 0x6f, 0x78, 0x33, 0x42, 0x0d, 0x0a, 0x0b, 0x72, 0x65,
+	0x73, 0x65, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x7a, 0x0a, 0x10, 0x53, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0xdf, 0x05, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x73, 0x5f, 0x64, 0x65, 0x70, 0x72, 0x65, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x44, 0x65,
+	0x70, 0x72, 0x65, 0x63, 0x61, 0x74, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x68, 0x6f, 0x75,
+	0x6c, 0x64, 0x5f, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x4e, 0x6f, 0x74, 0x49,
+	0x6e, 0x66, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x3d, 0x0a, 0x1b, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64,
+	0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x65, 0x64, 0x5f,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x73, 0x68, 0x6f,
+	0x75, 0x6c, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x41, 0x64, 0x64, 0x65, 0x64,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x1d, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x5f,
+	0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64,
+	0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x73, 0x68,
+	0x6f, 0x75, 0x6c, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6d, 0x6f,
+	0x76, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x41, 0x0a, 0x1d, 0x73, 0x68, 0x6f, 0x75,
+	0x6c, 0x64, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x64, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x1a, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x30, 0x0a, 0x06, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x67, 0x65,
+	0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x27, 0x0a,
+	0x0f, 0x69, 0x73, 0x5f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x73, 0x52, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x68, 0x69, 0x70, 0x12, 0x58, 0x0a, 0x11, 0x6f, 0x6e, 0x5f, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x2c, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x4f, 0x6e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x52,
+	0x0f, 0x6f, 0x6e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x12, 0x30, 0x0a, 0x14, 0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12,
+	0x73, 0x68, 0x6f, 0x75, 0x6c, 0x64, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x22, 0x92, 0x01, 0x0a, 0x0f, 0x4f, 0x6e, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x21, 0x0a, 0x1d, 0x4f, 0x4e, 0x5f, 0x54, 0x41, 0x52,
+	0x47, 0x45, 0x54, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x21, 0x0a, 0x1d, 0x4f, 0x4e, 0x5f,
+	0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x5f, 0x52,
+	0x45, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x50, 0x41, 0x49, 0x52, 0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18,
+	0x4f, 0x4e, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45,
+	0x44, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x4f, 0x4e,
+	0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x5f,
+	0x50, 0x41, 0x4e, 0x49, 0x43, 0x10, 0x03, 0x22, 0xe0, 0x01, 0x0a, 0x10, 0x42, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x05, 0x65, 0x6e, 0x75, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6e, 0x75, 0x6d,
+	0x52, 0x05, 0x65, 0x6e, 0x75, 0x6d, 0x73, 0x12, 0x3c, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x65,
+	0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x44,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x22, 0xc8, 0x04, 0x0a, 0x0f, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14,
+	0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61,
+	0x6c, 0x69, 0x61, 0x73, 0x12, 0x41, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x54, 0x61, 0x67, 0x52, 0x07,
+	0x65, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x73, 0x5f, 0x6f, 0x77,
+	0x6e, 0x69, 0x6e, 0x67, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0d, 0x69, 0x73, 0x4f, 0x77, 0x6e, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x1a,
+	0x91, 0x02, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x4f, 0x72, 0x54,
+	0x61, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x6d, 0x75,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x4d,
+	0x75, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x3d, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e,
+	0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x6f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x5f, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x12, 0x21, 0x0a, 0x0c, 0x69, 0x73, 0x5f, 0x69, 0x6e, 0x68, 0x65, 0x72, 0x69, 0x74, 0x65, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x73, 0x49, 0x6e, 0x68, 0x65, 0x72, 0x69,
+	0x74, 0x65, 0x64, 0x22, 0x9f, 0x01, 0x0a, 0x08, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x18, 0x0a, 0x14, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x50,
+	0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x10, 0x01, 0x12, 0x14, 0x0a,
+	0x10, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x4f, 0x55,
+	0x54, 0x10, 0x02, 0x12, 0x15, 0x0a, 0x11, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f,
+	0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x4c, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x50,
+	0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x4f, 0x52, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x4f,
+	0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10,
+	0x05, 0x12, 0x12, 0x0a, 0x0e, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x4f, 0x52, 0x5f, 0x41, 0x44,
+	0x44, 0x45, 0x44, 0x10, 0x06, 0x22, 0x8f, 0x02, 0x0a, 0x10, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x50, 0x61, 0x74, 0x68, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x07, 0x62, 0x75, 0x6e, 0x64,
+	0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x65, 0x63, 0x6b,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x32, 0x0a,
+	0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x65,
+	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61,
+	0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x14, 0x70, 0x6f, 0x73, 0x74, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x42, 0x8c, 0x01, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x2e,
+	0x67, 0x65, 0x63, 0x6b, 0x2e, 0x76, 0x31, 0x42, 0x10, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x2e, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x65, 0x6c, 0x61, 0x6e, 0x65, 0x79, 0x6a,
+	0x2f, 0x67, 0x65, 0x63, 0x6b, 0x2f, 0x70, 0x62, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x65, 0x63,
+	0x6b, 0x2f, 0x76, 0x31, 0x3b, 0x67, 0x65, 0x63, 0x6b, 0x70, 0x62, 0xa2, 0x02, 0x03, 0x47, 0x58,
+	0x58, 0xaa, 0x02, 0x07, 0x47, 0x65, 0x63, 0x6b, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x07, 0x47, 0x65,
+	0x63, 0x6b, 0x5c, 0x56, 0x31, 0xe2, 0x02, 0x13, 0x47, 0x65, 0x63, 0x6b, 0x5c, 0x56, 0x31, 0x5c,
+	0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x08, 0x47, 0x65,
+	0x63, 0x6b, 0x3a, 0x3a, 0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -935,31 +1541,47 @@ func file_geck_v1_definitions_proto_rawDescGZIP() []byte {
 	return file_geck_v1_definitions_proto_rawDescData
 }
 
-var file_geck_v1_definitions_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_geck_v1_definitions_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_geck_v1_definitions_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_geck_v1_definitions_proto_goTypes = []any{
-	(*Enum)(nil),                           // 0: geck.v1.Enum
-	(*FieldDefinition)(nil),                // 1: geck.v1.FieldDefinition
-	(*ComponentDefinition)(nil),            // 2: geck.v1.ComponentDefinition
-	(*BundleDefinition)(nil),               // 3: geck.v1.BundleDefinition
-	(*QueryDefinition)(nil),                // 4: geck.v1.QueryDefinition
-	(*GeneratorOptions)(nil),               // 5: geck.v1.GeneratorOptions
-	(*Enum_Value)(nil),                     // 6: geck.v1.Enum.Value
-	(*QueryDefinition_ComponentOrTag)(nil), // 7: geck.v1.QueryDefinition.ComponentOrTag
+	(ComponentDefinition_OnTargetDeleted)(0), // 0: geck.v1.ComponentDefinition.OnTargetDeleted
+	(QueryDefinition_Operator)(0),            // 1: geck.v1.QueryDefinition.Operator
+	(*Enum)(nil),                             // 2: geck.v1.Enum
+	(*Vector3)(nil),                          // 3: geck.v1.Vector3
+	(*Quaternion)(nil),                       // 4: geck.v1.Quaternion
+	(*Box3)(nil),                             // 5: geck.v1.Box3
+	(*FieldDefinition)(nil),                  // 6: geck.v1.FieldDefinition
+	(*StructDefinition)(nil),                 // 7: geck.v1.StructDefinition
+	(*ComponentDefinition)(nil),              // 8: geck.v1.ComponentDefinition
+	(*BundleDefinition)(nil),                 // 9: geck.v1.BundleDefinition
+	(*QueryDefinition)(nil),                  // 10: geck.v1.QueryDefinition
+	(*GeneratorOptions)(nil),                 // 11: geck.v1.GeneratorOptions
+	(*Enum_Value)(nil),                       // 12: geck.v1.Enum.Value
+	(*QueryDefinition_ComponentOrTag)(nil),   // 13: geck.v1.QueryDefinition.ComponentOrTag
 }
 var file_geck_v1_definitions_proto_depIdxs = []int32{
-	6, // 0: geck.v1.Enum.values:type_name -> geck.v1.Enum.Value
-	6, // 1: geck.v1.FieldDefinition.enum:type_name -> geck.v1.Enum.Value
-	1, // 2: geck.v1.ComponentDefinition.fields:type_name -> geck.v1.FieldDefinition
-	0, // 3: geck.v1.BundleDefinition.enums:type_name -> geck.v1.Enum
-	2, // 4: geck.v1.BundleDefinition.components:type_name -> geck.v1.ComponentDefinition
-	7, // 5: geck.v1.QueryDefinition.entries:type_name -> geck.v1.QueryDefinition.ComponentOrTag
-	3, // 6: geck.v1.GeneratorOptions.bundles:type_name -> geck.v1.BundleDefinition
-	4, // 7: geck.v1.GeneratorOptions.queries:type_name -> geck.v1.QueryDefinition
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	12, // 0: geck.v1.Enum.values:type_name -> geck.v1.Enum.Value
+	3,  // 1: geck.v1.Box3.min:type_name -> geck.v1.Vector3
+	3,  // 2: geck.v1.Box3.max:type_name -> geck.v1.Vector3
+	12, // 3: geck.v1.FieldDefinition.enum:type_name -> geck.v1.Enum.Value
+	3,  // 4: geck.v1.FieldDefinition.vector3:type_name -> geck.v1.Vector3
+	4,  // 5: geck.v1.FieldDefinition.quaternion:type_name -> geck.v1.Quaternion
+	5,  // 6: geck.v1.FieldDefinition.box3:type_name -> geck.v1.Box3
+	6,  // 7: geck.v1.StructDefinition.fields:type_name -> geck.v1.FieldDefinition
+	6,  // 8: geck.v1.ComponentDefinition.fields:type_name -> geck.v1.FieldDefinition
+	0,  // 9: geck.v1.ComponentDefinition.on_target_deleted:type_name -> geck.v1.ComponentDefinition.OnTargetDeleted
+	2,  // 10: geck.v1.BundleDefinition.enums:type_name -> geck.v1.Enum
+	8,  // 11: geck.v1.BundleDefinition.components:type_name -> geck.v1.ComponentDefinition
+	7,  // 12: geck.v1.BundleDefinition.structs:type_name -> geck.v1.StructDefinition
+	13, // 13: geck.v1.QueryDefinition.entries:type_name -> geck.v1.QueryDefinition.ComponentOrTag
+	9,  // 14: geck.v1.GeneratorOptions.bundles:type_name -> geck.v1.BundleDefinition
+	10, // 15: geck.v1.GeneratorOptions.queries:type_name -> geck.v1.QueryDefinition
+	1,  // 16: geck.v1.QueryDefinition.ComponentOrTag.operator:type_name -> geck.v1.QueryDefinition.Operator
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_geck_v1_definitions_proto_init() }
@@ -967,7 +1589,7 @@ func file_geck_v1_definitions_proto_init() {
 	if File_geck_v1_definitions_proto != nil {
 		return
 	}
-	file_geck_v1_definitions_proto_msgTypes[1].OneofWrappers = []any{
+	file_geck_v1_definitions_proto_msgTypes[4].OneofWrappers = []any{
 		(*FieldDefinition_U8)(nil),
 		(*FieldDefinition_U16)(nil),
 		(*FieldDefinition_U32)(nil),
@@ -982,19 +1604,24 @@ func file_geck_v1_definitions_proto_init() {
 		(*FieldDefinition_Bin)(nil),
 		(*FieldDefinition_Entity)(nil),
 		(*FieldDefinition_Enum)(nil),
+		(*FieldDefinition_Struct)(nil),
+		(*FieldDefinition_Vector3)(nil),
+		(*FieldDefinition_Quaternion)(nil),
+		(*FieldDefinition_Box3)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_geck_v1_definitions_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   8,
+			NumEnums:      2,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_geck_v1_definitions_proto_goTypes,
 		DependencyIndexes: file_geck_v1_definitions_proto_depIdxs,
+		EnumInfos:         file_geck_v1_definitions_proto_enumTypes,
 		MessageInfos:      file_geck_v1_definitions_proto_msgTypes,
 	}.Build()
 	File_geck_v1_definitions_proto = out.File